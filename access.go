@@ -0,0 +1,273 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pilosa/pilosa/pql"
+)
+
+// HeaderAuthorization is the HTTP header used to pass an API key.
+// The value should be in the form "Bearer <key>".
+const HeaderAuthorization = "Authorization"
+
+// Wildcard index name used to grant permissions on all indexes.
+const PermissionWildcard = "*"
+
+var (
+	// ErrUnauthenticated is returned when a request has a missing or unknown API key.
+	ErrUnauthenticated = errors.New("authentication required")
+
+	// ErrPermissionDenied is returned when a principal lacks a permission.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Permission represents the level of access a principal has on an index.
+// Each level includes the levels below it.
+type Permission int
+
+// Permission levels.
+const (
+	PermissionNone Permission = iota
+	PermissionRead
+	PermissionWrite
+	PermissionAdmin
+)
+
+// String returns the name of the permission.
+func (p Permission) String() string {
+	switch p {
+	case PermissionRead:
+		return "read"
+	case PermissionWrite:
+		return "write"
+	case PermissionAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// ParsePermission returns the permission with the given name.
+func ParsePermission(s string) (Permission, error) {
+	switch s {
+	case "none":
+		return PermissionNone, nil
+	case "read":
+		return PermissionRead, nil
+	case "write":
+		return PermissionWrite, nil
+	case "admin":
+		return PermissionAdmin, nil
+	default:
+		return PermissionNone, fmt.Errorf("invalid permission: %q", s)
+	}
+}
+
+// MarshalJSON encodes the permission as its name.
+func (p Permission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes the permission from its name.
+func (p *Permission) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := ParsePermission(s)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// Principal represents a caller identified by an API key.
+type Principal struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+
+	// Permissions by index name. The "*" index applies to all indexes.
+	Indexes map[string]Permission `json:"indexes"`
+}
+
+// Permission returns the principal's permission on index. If index is
+// blank then only the wildcard permission is used.
+func (p *Principal) Permission(index string) Permission {
+	perm := p.Indexes[PermissionWildcard]
+	if index != "" {
+		if v, ok := p.Indexes[index]; ok && v > perm {
+			perm = v
+		}
+	}
+	return perm
+}
+
+// Allowed returns true if the principal has at least perm on index.
+func (p *Principal) Allowed(index string, perm Permission) bool {
+	return p.Permission(index) >= perm
+}
+
+// AccessControl authenticates requests by API key and
+// authorizes them against per-index permissions.
+type AccessControl struct {
+	principals map[string]*Principal // by key
+}
+
+// NewAccessControl returns a new instance of AccessControl for principals.
+func NewAccessControl(principals []*Principal) (*AccessControl, error) {
+	a := &AccessControl{principals: make(map[string]*Principal)}
+	for _, p := range principals {
+		if p.Key == "" {
+			return nil, fmt.Errorf("key required for principal: %q", p.Name)
+		} else if _, ok := a.principals[p.Key]; ok {
+			return nil, fmt.Errorf("duplicate key for principal: %q", p.Name)
+		}
+		a.principals[p.Key] = p
+	}
+	return a, nil
+}
+
+// LoadAccessControl reads a JSON credentials file from path in the form:
+//
+//	{"principals": [{"name": "app", "key": "secret", "indexes": {"*": "read", "i": "write"}}]}
+func LoadAccessControl(path string) (*AccessControl, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Principals []*Principal `json:"principals"`
+	}
+	if err := json.Unmarshal(buf, &file); err != nil {
+		return nil, fmt.Errorf("parse credentials: %s", err)
+	}
+	return NewAccessControl(file.Principals)
+}
+
+// Authenticate returns the principal for the API key on r.
+// Returns nil if the key is missing or unknown.
+func (a *AccessControl) Authenticate(r *http.Request) *Principal {
+	return a.AuthenticateKey(r.Header.Get(HeaderAuthorization))
+}
+
+// AuthenticateKey returns the principal for an API key.
+// The key may be prefixed with "Bearer ". Returns nil if the key is unknown.
+func (a *AccessControl) AuthenticateKey(key string) *Principal {
+	key = strings.TrimPrefix(key, "Bearer ")
+	if key == "" {
+		return nil
+	}
+	return a.principals[key]
+}
+
+// Authorize returns an error if the request does not have at least perm on index.
+func (a *AccessControl) Authorize(r *http.Request, index string, perm Permission) error {
+	return a.AuthorizeKey(r.Header.Get(HeaderAuthorization), index, perm)
+}
+
+// AuthorizeKey returns an error if the API key does not have at least perm on index.
+func (a *AccessControl) AuthorizeKey(key string, index string, perm Permission) error {
+	p := a.AuthenticateKey(key)
+	if p == nil {
+		return ErrUnauthenticated
+	} else if !p.Allowed(index, perm) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// writeCalls are the PQL calls which modify data.
+var writeCalls = map[string]struct{}{
+	"SetBit":         {},
+	"ClearBit":       {},
+	"SetRowAttrs":    {},
+	"SetColumnAttrs": {},
+}
+
+// QueryPermission returns the permission required to execute q.
+func QueryPermission(q *pql.Query) Permission {
+	for _, c := range q.Calls {
+		if isWriteCall(c) {
+			return PermissionWrite
+		}
+	}
+	return PermissionRead
+}
+
+// writeCallStrings returns the string representation of each write call in q.
+func writeCallStrings(q *pql.Query) []string {
+	var a []string
+	for _, c := range q.Calls {
+		if isWriteCall(c) {
+			a = append(a, c.String())
+		}
+	}
+	return a
+}
+
+// isWriteCall returns true if c or any of its children modify data.
+func isWriteCall(c *pql.Call) bool {
+	if _, ok := writeCalls[c.Name]; ok {
+		return true
+	}
+	for _, child := range c.Children {
+		if isWriteCall(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyTransport is an http.RoundTripper which adds an API key to each request.
+type APIKeyTransport struct {
+	Key string
+
+	// Underlying transport. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// RoundTrip sends a copy of req with the API key set.
+func (t *APIKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	other := *req
+	other.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		other.Header[k] = v
+	}
+	other.Header.Set(HeaderAuthorization, "Bearer "+t.Key)
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(&other)
+}
+
+// SetAPIKey configures the client to send key with each request.
+func (c *Client) SetAPIKey(key string) {
+	var transport http.RoundTripper
+	if c.HTTPClient != nil {
+		transport = c.HTTPClient.Transport
+	}
+	c.HTTPClient = &http.Client{Transport: &APIKeyTransport{Key: key, Transport: transport}}
+}
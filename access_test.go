@@ -0,0 +1,141 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// Ensure the required permission for a query is based on its calls.
+func TestQueryPermission(t *testing.T) {
+	for _, tt := range []struct {
+		query string
+		perm  pilosa.Permission
+	}{
+		{query: `Count(Bitmap(frame=f, rowID=1))`, perm: pilosa.PermissionRead},
+		{query: `TopN(frame=f)`, perm: pilosa.PermissionRead},
+		{query: `Bitmap(frame=f, rowID=1) SetBit(frame=f, rowID=1, columnID=2)`, perm: pilosa.PermissionWrite},
+		{query: `SetRowAttrs(frame=f, rowID=1, x=1)`, perm: pilosa.PermissionWrite},
+	} {
+		q, err := pql.ParseString(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		} else if perm := pilosa.QueryPermission(q); perm != tt.perm {
+			t.Errorf("%s: unexpected permission: %s", tt.query, perm)
+		}
+	}
+}
+
+// Ensure access control can be loaded from a credentials file.
+func TestLoadAccessControl(t *testing.T) {
+	f, err := ioutil.TempFile("", "pilosa-credentials-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(`{"principals": [{"name": "app", "key": "k", "indexes": {"*": "read", "i": "admin"}}]}`); err != nil {
+		t.Fatal(err)
+	} else if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := pilosa.LoadAccessControl(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := MustNewHTTPRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer k")
+	if err := a.Authorize(r, "j", pilosa.PermissionRead); err != nil {
+		t.Fatal(err)
+	} else if err := a.Authorize(r, "j", pilosa.PermissionWrite); err != pilosa.ErrPermissionDenied {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := a.Authorize(r, "i", pilosa.PermissionAdmin); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Header.Set("Authorization", "Bearer unknown")
+	if err := a.Authorize(r, "i", pilosa.PermissionRead); err != pilosa.ErrUnauthenticated {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure the handler enforces index permissions.
+func TestHandler_AccessControl(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	hldr.MustCreateIndexIfNotExists("j", pilosa.IndexOptions{})
+
+	a, err := pilosa.NewAccessControl([]*pilosa.Principal{
+		{Name: "reader", Key: "r", Indexes: map[string]pilosa.Permission{"i": pilosa.PermissionRead}},
+		{Name: "admin", Key: "a", Indexes: map[string]pilosa.Permission{"*": pilosa.PermissionAdmin}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Cluster = NewCluster(1)
+	h.AccessControl = a
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return []interface{}{uint64(1)}, nil
+	}
+
+	for _, tt := range []struct {
+		method, path, key, body string
+		code                    int
+	}{
+		{method: "POST", path: "/index/i/query", body: `Count(Bitmap(frame=f, rowID=1))`, code: http.StatusUnauthorized},
+		{method: "POST", path: "/index/i/query", key: "r", body: `Count(Bitmap(frame=f, rowID=1))`, code: http.StatusOK},
+		{method: "POST", path: "/index/i/query", key: "r", body: `SetBit(frame=f, rowID=1, columnID=1)`, code: http.StatusForbidden},
+		{method: "POST", path: "/index/j/query", key: "r", body: `Count(Bitmap(frame=f, rowID=1))`, code: http.StatusForbidden},
+		{method: "POST", path: "/index/i/query", key: "a", body: `SetBit(frame=f, rowID=1, columnID=1)`, code: http.StatusOK},
+		{method: "DELETE", path: "/index/i", key: "r", code: http.StatusForbidden},
+		{method: "POST", path: "/index/k", key: "a", body: `{}`, code: http.StatusOK},
+		{method: "GET", path: "/version", code: http.StatusOK},
+	} {
+		r := MustNewHTTPRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		if tt.key != "" {
+			r.Header.Set("Authorization", "Bearer "+tt.key)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.code {
+			t.Errorf("%s %s (key=%q): unexpected status code: %d: %s", tt.method, tt.path, tt.key, w.Code, w.Body.String())
+		}
+	}
+
+	// The schema only includes readable indexes.
+	r := MustNewHTTPRequest("GET", "/schema", nil)
+	r.Header.Set("Authorization", "Bearer r")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if body := w.Body.String(); body != `{"indexes":[{"name":"i","frames":null}]}`+"\n" {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
@@ -15,22 +15,45 @@
 package pilosa
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/sha1"
 	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/pql"
+	"github.com/pilosa/pilosa/roaring"
 )
 
 // AttrBlockSize is the size of attribute blocks for anti-entropy.
 const AttrBlockSize = 100
 
+// AttrIndexMaxStringSize is the largest string value, in bytes, that is
+// added to the secondary index. Longer values are not matched by Find().
+const AttrIndexMaxStringSize = 1024
+
+// AttrImportBatchSize is the number of ids written per transaction
+// during an attribute import.
+const AttrImportBatchSize = 10000
+
+// Attribute import formats.
+const (
+	AttrFormatJSON = "json"
+	AttrFormatCSV  = "csv"
+)
+
 // Attribute data type enum.
 const (
 	AttrTypeString = 1
@@ -74,6 +97,25 @@ func (s *AttrStore) Open() error {
 		if _, err := tx.CreateBucketIfNotExists([]byte("attrs")); err != nil {
 			return err
 		}
+
+		// Build the secondary index for stores created before it existed.
+		if tx.Bucket([]byte("index")) == nil {
+			if _, err := tx.CreateBucket([]byte("index")); err != nil {
+				return err
+			}
+			return tx.Bucket([]byte("attrs")).ForEach(func(k, v []byte) error {
+				var pb internal.AttrMap
+				if err := proto.Unmarshal(v, &pb); err != nil {
+					return err
+				}
+				for name, value := range decodeAttrs(pb.GetAttrs()) {
+					if err := txPutAttrIndex(tx, btou64(k), name, value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -177,6 +219,46 @@ func (s *AttrStore) SetBulkAttrs(m map[uint64]map[string]interface{}) error {
 	return nil
 }
 
+// Find returns the sorted ids whose attributes match every condition in
+// conds. Each condition maps an attribute name to a value, a list of values
+// which matches any of them, or a *pql.Condition. Conditions are evaluated
+// against a secondary index so ids are not scanned individually.
+//
+// Integer and float attributes are compared numerically with each other.
+// A NEQ condition matches ids with the attribute set to a different value
+// of the same kind.
+func (s *AttrStore) Find(conds map[string]interface{}) ([]uint64, error) {
+	names := make([]string, 0, len(conds))
+	for name := range conds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bm *roaring.Bitmap
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range names {
+			other, err := txFindAttrIndex(tx, name, conds[name])
+			if err != nil {
+				return err
+			}
+
+			if bm == nil {
+				bm = other
+			} else {
+				bm = bm.Intersect(other)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if bm == nil {
+		return nil, nil
+	}
+	return bm.Slice(), nil
+}
+
 // Blocks returns a list of all blocks in the store.
 func (s *AttrStore) Blocks() ([]AttrBlock, error) {
 	tx, err := s.db.Begin(false)
@@ -270,6 +352,11 @@ func txUpdateAttrs(tx *bolt.Tx, id uint64, m map[string]interface{}) (map[string
 	// Merge attributes with original values.
 	// Nil values should delete keys.
 	for k, v := range m {
+		if prev, ok := attr[k]; ok {
+			if err := txDeleteAttrIndex(tx, id, k, prev); err != nil {
+				return nil, err
+			}
+		}
 		if v == nil {
 			delete(attr, k)
 			continue
@@ -287,6 +374,10 @@ func txUpdateAttrs(tx *bolt.Tx, id uint64, m map[string]interface{}) (map[string
 		default:
 			return nil, fmt.Errorf("invalid attr type: %T", v)
 		}
+
+		if err := txPutAttrIndex(tx, id, k, attr[k]); err != nil {
+			return nil, err
+		}
 	}
 
 	// Marshal and save new values.
@@ -300,6 +391,284 @@ func txUpdateAttrs(tx *bolt.Tx, id uint64, m map[string]interface{}) (map[string
 	return attr, nil
 }
 
+// txPutAttrIndex adds the index entry for an id's attribute value.
+func txPutAttrIndex(tx *bolt.Tx, id uint64, name string, value interface{}) error {
+	key, ok := attrIndexValueKey(name, value)
+	if !ok {
+		return nil
+	}
+	return tx.Bucket([]byte("index")).Put(append(key, u64tob(id)...), []byte{})
+}
+
+// txDeleteAttrIndex removes the index entry for an id's attribute value.
+func txDeleteAttrIndex(tx *bolt.Tx, id uint64, name string, value interface{}) error {
+	key, ok := attrIndexValueKey(name, value)
+	if !ok {
+		return nil
+	}
+	return tx.Bucket([]byte("index")).Delete(append(key, u64tob(id)...))
+}
+
+// txFindAttrIndex returns the ids with a name attribute matching value.
+func txFindAttrIndex(tx *bolt.Tx, name string, value interface{}) (*roaring.Bitmap, error) {
+	var ranges, exclude []attrIndexRange
+	switch v := value.(type) {
+	case *pql.Condition:
+		switch v.Op {
+		case pql.NEQ:
+			// Match all values of the same kind, except for the given values.
+			for _, value := range attrConditionValues(v.Value) {
+				kinds, err := attrIndexKinds(name, value)
+				if err != nil {
+					return nil, err
+				}
+				ranges = append(ranges, kinds...)
+
+				r, err := attrIndexRanges(name, pql.EQ, value)
+				if err != nil {
+					return nil, err
+				}
+				exclude = append(exclude, r...)
+			}
+		case pql.PREFIX:
+			str, ok := v.Value.(string)
+			if !ok {
+				return nil, fmt.Errorf("prefix value must be a string: %s", name)
+			}
+			prefix := append(attrIndexPrefix(name, AttrTypeString), escapeAttrIndexString(str)...)
+			ranges = append(ranges, attrIndexRange{prefix, prefixEnd(prefix)})
+		case pql.LT, pql.LTE, pql.GT, pql.GTE:
+			r, err := attrIndexRanges(name, v.Op, v.Value)
+			if err != nil {
+				return nil, err
+			}
+			ranges = r
+		default:
+			return nil, fmt.Errorf("invalid operator for %s: %s", name, v.Op)
+		}
+	default:
+		for _, value := range attrConditionValues(value) {
+			r, err := attrIndexRanges(name, pql.EQ, value)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r...)
+		}
+	}
+
+	bm := scanAttrIndex(tx, ranges)
+	if len(exclude) > 0 {
+		bm = bm.Difference(scanAttrIndex(tx, exclude))
+	}
+	return bm, nil
+}
+
+// attrConditionValues returns the values of a list or a single value.
+func attrConditionValues(value interface{}) []interface{} {
+	if a, ok := value.([]interface{}); ok {
+		return a
+	}
+	return []interface{}{value}
+}
+
+// scanAttrIndex returns the ids of all index entries within ranges.
+func scanAttrIndex(tx *bolt.Tx, ranges []attrIndexRange) *roaring.Bitmap {
+	bm := roaring.NewBitmap()
+	cur := tx.Bucket([]byte("index")).Cursor()
+	for _, r := range ranges {
+		for k, _ := cur.Seek(r.start); k != nil; k, _ = cur.Next() {
+			if r.end != nil && bytes.Compare(k, r.end) >= 0 {
+				break
+			}
+			bm.Add(btou64(k[len(k)-8:]))
+		}
+	}
+	return bm
+}
+
+// attrIndexRange represents a range of index keys. A nil end is unbounded.
+type attrIndexRange struct {
+	start, end []byte
+}
+
+// attrIndexRanges returns the index key ranges of values that compare to
+// value with op. Numeric values cover both integer and float entries.
+func attrIndexRanges(name string, op pql.Token, value interface{}) ([]attrIndexRange, error) {
+	switch v := value.(type) {
+	case string:
+		key, _ := attrIndexValueKey(name, v)
+		return []attrIndexRange{opAttrIndexRange(attrIndexPrefix(name, AttrTypeString), key, op)}, nil
+	case bool:
+		if op != pql.EQ {
+			return nil, fmt.Errorf("invalid operator for boolean %s: %s", name, op)
+		}
+		key, _ := attrIndexValueKey(name, v)
+		return []attrIndexRange{{key, prefixEnd(key)}}, nil
+	case int64:
+		intKey, _ := attrIndexValueKey(name, v)
+		floatKey, _ := attrIndexValueKey(name, float64(v))
+		return []attrIndexRange{
+			opAttrIndexRange(attrIndexPrefix(name, AttrTypeInt), intKey, op),
+			opAttrIndexRange(attrIndexPrefix(name, AttrTypeFloat), floatKey, op),
+		}, nil
+	case float64:
+		if math.IsNaN(v) {
+			return nil, nil
+		}
+		floatKey, _ := attrIndexValueKey(name, v)
+		ranges := []attrIndexRange{opAttrIndexRange(attrIndexPrefix(name, AttrTypeFloat), floatKey, op)}
+		if r, ok := floatIntAttrIndexRange(name, op, v); ok {
+			ranges = append(ranges, r)
+		}
+		return ranges, nil
+	default:
+		return nil, fmt.Errorf("invalid value for %s: %T", name, value)
+	}
+}
+
+// floatIntAttrIndexRange returns the range of integer index entries that
+// compare to a float value with op. Returns false if no integers match.
+func floatIntAttrIndexRange(name string, op pql.Token, f float64) (attrIndexRange, bool) {
+	prefix := attrIndexPrefix(name, AttrTypeInt)
+
+	// Compare against the nearest integer that gives the same result.
+	var bound float64
+	switch op {
+	case pql.GT, pql.LTE:
+		bound = math.Floor(f)
+	case pql.GTE, pql.LT:
+		bound = math.Ceil(f)
+	default:
+		if f != math.Trunc(f) {
+			return attrIndexRange{}, false
+		}
+		bound = f
+	}
+
+	// Bounds outside of the int64 range match either all or no integers.
+	if bound >= math.MaxInt64 || bound < math.MinInt64 {
+		above := bound >= math.MaxInt64
+		switch op {
+		case pql.GT, pql.GTE:
+			return attrIndexRange{prefix, prefixEnd(prefix)}, !above
+		case pql.LT, pql.LTE:
+			return attrIndexRange{prefix, prefixEnd(prefix)}, above
+		default:
+			return attrIndexRange{}, false
+		}
+	}
+
+	key, _ := attrIndexValueKey(name, int64(bound))
+	return opAttrIndexRange(prefix, key, op), true
+}
+
+// opAttrIndexRange returns the range of keys under prefix that compare to
+// the value key with op.
+func opAttrIndexRange(prefix, key []byte, op pql.Token) attrIndexRange {
+	switch op {
+	case pql.LT:
+		return attrIndexRange{prefix, key}
+	case pql.LTE:
+		return attrIndexRange{prefix, prefixEnd(key)}
+	case pql.GT:
+		return attrIndexRange{prefixEnd(key), prefixEnd(prefix)}
+	case pql.GTE:
+		return attrIndexRange{key, prefixEnd(prefix)}
+	default:
+		return attrIndexRange{key, prefixEnd(key)}
+	}
+}
+
+// attrIndexKinds returns the ranges covering every value of the same kind.
+func attrIndexKinds(name string, value interface{}) ([]attrIndexRange, error) {
+	var types []byte
+	switch value.(type) {
+	case string:
+		types = []byte{AttrTypeString}
+	case bool:
+		types = []byte{AttrTypeBool}
+	case int64, float64:
+		types = []byte{AttrTypeInt, AttrTypeFloat}
+	default:
+		return nil, fmt.Errorf("invalid value for %s: %T", name, value)
+	}
+
+	ranges := make([]attrIndexRange, len(types))
+	for i, typ := range types {
+		prefix := attrIndexPrefix(name, typ)
+		ranges[i] = attrIndexRange{prefix, prefixEnd(prefix)}
+	}
+	return ranges, nil
+}
+
+// attrIndexPrefix returns the key prefix for all values of a given type.
+func attrIndexPrefix(name string, typ byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(name)+1)
+	buf = buf[:binary.PutUvarint(buf, uint64(len(name)))]
+	buf = append(buf, name...)
+	return append(buf, typ)
+}
+
+// attrIndexValueKey returns the index key prefix for a value. Values are
+// encoded so that keys sort in value order. Returns false if the value is
+// not indexed.
+func attrIndexValueKey(name string, value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case string:
+		if len(v) > AttrIndexMaxStringSize {
+			return nil, false
+		}
+		key := append(attrIndexPrefix(name, AttrTypeString), escapeAttrIndexString(v)...)
+		return append(key, 0, 1), true
+	case int64:
+		return append(attrIndexPrefix(name, AttrTypeInt), u64tob(uint64(v)^(1<<63))...), true
+	case float64:
+		bits := math.Float64bits(v)
+		if bits&(1<<63) == 0 {
+			bits ^= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		return append(attrIndexPrefix(name, AttrTypeFloat), u64tob(bits)...), true
+	case bool:
+		b := byte(0)
+		if v {
+			b = 1
+		}
+		return append(attrIndexPrefix(name, AttrTypeBool), b), true
+	default:
+		return nil, false
+	}
+}
+
+// escapeAttrIndexString escapes zero bytes so that a string value can be
+// terminated by a zero byte while keeping its sort order.
+func escapeAttrIndexString(s string) []byte {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0 {
+			buf = append(buf, 0, 0xFF)
+			continue
+		}
+		buf = append(buf, s[i])
+	}
+	return buf
+}
+
+// prefixEnd returns the first key after all keys starting with prefix.
+// Returns nil if there is no such key.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xFF {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 func encodeAttrs(m map[string]interface{}) []*internal.Attr {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -493,3 +862,168 @@ func (cur *blockCursor) next() (key, value []byte) {
 
 	return key, value
 }
+
+// ReadAttrs reads a set of attributes by id from r in the given format.
+//
+// The JSON format is a stream of objects such as {"id":1,"attrs":{"x":2}}.
+// A null value deletes the attribute. The CSV format has a header row of
+// "id" followed by attribute names. Empty CSV cells are skipped and other
+// values are read as an integer, float, boolean or string, in that order.
+// Attributes for repeated ids are merged.
+func ReadAttrs(r io.Reader, format string) (map[uint64]map[string]interface{}, error) {
+	switch format {
+	case AttrFormatJSON, "":
+		return readJSONAttrs(r)
+	case AttrFormatCSV:
+		return readCSVAttrs(r)
+	default:
+		return nil, fmt.Errorf("invalid attr format: %q", format)
+	}
+}
+
+// readJSONAttrs reads a stream of JSON encoded ColumnAttrSets.
+func readJSONAttrs(r io.Reader) (map[uint64]map[string]interface{}, error) {
+	m := make(map[uint64]map[string]interface{})
+
+	dec := json.NewDecoder(bufio.NewReader(r))
+	dec.UseNumber()
+	for n := 1; ; n++ {
+		var set struct {
+			ID    *uint64                `json:"id"`
+			Attrs map[string]interface{} `json:"attrs"`
+		}
+		if err := dec.Decode(&set); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("record %d: %s", n, err)
+		} else if set.ID == nil {
+			return nil, fmt.Errorf("record %d: id required", n)
+		}
+
+		for k, v := range set.Attrs {
+			switch v := v.(type) {
+			case json.Number:
+				if i, err := v.Int64(); err == nil {
+					set.Attrs[k] = i
+				} else if f, err := v.Float64(); err == nil {
+					set.Attrs[k] = f
+				} else {
+					return nil, fmt.Errorf("record %d: invalid number for %q: %s", n, k, v)
+				}
+			case string, bool, nil:
+			default:
+				return nil, fmt.Errorf("record %d: invalid attr type for %q: %T", n, k, v)
+			}
+		}
+		mergeAttrs(m, *set.ID, set.Attrs)
+	}
+
+	return m, nil
+}
+
+// readCSVAttrs reads attributes from a CSV file with a header row.
+func readCSVAttrs(r io.Reader) (map[uint64]map[string]interface{}, error) {
+	m := make(map[uint64]map[string]interface{})
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(header) < 2 || header[0] != "id" {
+		return nil, errors.New("csv header must be id followed by attribute names")
+	}
+
+	for n := 2; ; n++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		id, err := strconv.ParseUint(record[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid id: %q", n, record[0])
+		}
+
+		attrs := make(map[string]interface{}, len(record)-1)
+		for i, v := range record[1:] {
+			if v == "" {
+				continue
+			}
+			attrs[header[i+1]] = parseAttrValue(v)
+		}
+		mergeAttrs(m, id, attrs)
+	}
+
+	return m, nil
+}
+
+// parseAttrValue returns s as an int64, float64, bool or string.
+func parseAttrValue(s string) interface{} {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v
+	} else if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v
+	} else if v, err := strconv.ParseBool(s); err == nil {
+		return v
+	}
+	return s
+}
+
+// mergeAttrs merges attrs into the attributes for id in m.
+func mergeAttrs(m map[uint64]map[string]interface{}, id uint64, attrs map[string]interface{}) {
+	if m[id] == nil {
+		m[id] = make(map[string]interface{}, len(attrs))
+	}
+	for k, v := range attrs {
+		m[id][k] = v
+	}
+}
+
+// WriteAttrs writes attributes by id to w in the JSON format read by ReadAttrs.
+func WriteAttrs(w io.Writer, m map[uint64]map[string]interface{}) error {
+	ids := make([]uint64, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Sort(uint64Slice(ids))
+
+	enc := json.NewEncoder(w)
+	for _, id := range ids {
+		if err := enc.Encode(struct {
+			ID    uint64                 `json:"id"`
+			Attrs map[string]interface{} `json:"attrs"`
+		}{id, m[id]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SplitAttrs splits a set of attributes by id into batches of at most n ids.
+func SplitAttrs(m map[uint64]map[string]interface{}, n int) []map[uint64]map[string]interface{} {
+	ids := make([]uint64, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Sort(uint64Slice(ids))
+
+	var a []map[uint64]map[string]interface{}
+	for len(ids) > 0 {
+		size := n
+		if size <= 0 || size > len(ids) {
+			size = len(ids)
+		}
+
+		batch := make(map[uint64]map[string]interface{}, size)
+		for _, id := range ids[:size] {
+			batch[id] = m[id]
+		}
+		a = append(a, batch)
+		ids = ids[size:]
+	}
+	return a
+}
@@ -18,9 +18,11 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/pql"
 )
 
 // Ensure database can set and retrieve column attributes.
@@ -143,6 +145,114 @@ func NewAttrStore() *AttrStore {
 	return &AttrStore{AttrStore: pilosa.NewAttrStore(f.Name())}
 }
 
+// Ensure ids can be found by attribute conditions.
+func TestAttrStore_Find(t *testing.T) {
+	s := MustOpenAttrStore()
+	defer s.Close()
+
+	if err := s.SetBulkAttrs(map[uint64]map[string]interface{}{
+		1: {"country": "US", "age": int64(20), "active": true},
+		2: {"country": "USA", "age": 30.5, "active": false},
+		3: {"country": "CA", "age": int64(-5)},
+		4: {"country": "US", "age": int64(40)},
+		5: {"age": "unknown"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing or removing a value must update the index.
+	if err := s.SetAttrs(4, map[string]interface{}{"country": "MX"}); err != nil {
+		t.Fatal(err)
+	} else if err := s.SetAttrs(3, map[string]interface{}{"age": nil}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range []struct {
+		conds map[string]interface{}
+		ids   []uint64
+	}{
+		{map[string]interface{}{"country": "US"}, []uint64{1}},
+		{map[string]interface{}{"country": []interface{}{"US", "CA"}}, []uint64{1, 3}},
+		{map[string]interface{}{"country": &pql.Condition{Op: pql.PREFIX, Value: "US"}}, []uint64{1, 2}},
+		{map[string]interface{}{"country": &pql.Condition{Op: pql.NEQ, Value: "US"}}, []uint64{2, 3, 4}},
+		{map[string]interface{}{"country": &pql.Condition{Op: pql.LT, Value: "US"}}, []uint64{3, 4}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.GT, Value: int64(20)}}, []uint64{2, 4}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.GTE, Value: int64(20)}}, []uint64{1, 2, 4}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.LT, Value: 30.5}}, []uint64{1}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.LTE, Value: 30.5}}, []uint64{1, 2}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.GT, Value: 20.5}}, []uint64{2, 4}},
+		{map[string]interface{}{"age": &pql.Condition{Op: pql.NEQ, Value: int64(40)}}, []uint64{1, 2}},
+		{map[string]interface{}{"age": 40.0}, []uint64{4}},
+		{map[string]interface{}{"active": true}, []uint64{1}},
+		{map[string]interface{}{"country": "US", "age": int64(20)}, []uint64{1}},
+		{map[string]interface{}{"country": "US", "age": int64(40)}, nil},
+	} {
+		if ids, err := s.Find(tt.conds); err != nil {
+			t.Fatalf("%d. %s", i, err)
+		} else if len(ids) != len(tt.ids) || (len(ids) > 0 && !reflect.DeepEqual(ids, tt.ids)) {
+			t.Fatalf("%d. unexpected ids: %v", i, ids)
+		}
+	}
+
+	// Booleans cannot be compared by order.
+	if _, err := s.Find(map[string]interface{}{"active": &pql.Condition{Op: pql.GT, Value: true}}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// Ensure attributes can be read from JSON.
+func TestReadAttrs_JSON(t *testing.T) {
+	m, err := pilosa.ReadAttrs(strings.NewReader(`
+{"id":1,"attrs":{"a":100,"b":"x","c":true,"d":1.5}}
+{"id":2,"attrs":{"a":null}}
+{"id":1,"attrs":{"e":-2}}
+`), pilosa.AttrFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(m, map[uint64]map[string]interface{}{
+		1: {"a": int64(100), "b": "x", "c": true, "d": 1.5, "e": int64(-2)},
+		2: {"a": nil},
+	}) {
+		t.Fatalf("unexpected attrs: %#v", m)
+	}
+
+	if _, err := pilosa.ReadAttrs(strings.NewReader(`{"attrs":{"a":1}}`), pilosa.AttrFormatJSON); err == nil || err.Error() != "record 1: id required" {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := pilosa.ReadAttrs(strings.NewReader(`{"id":1,"attrs":{"a":[1]}}`), pilosa.AttrFormatJSON); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// Ensure attributes can be read from CSV.
+func TestReadAttrs_CSV(t *testing.T) {
+	m, err := pilosa.ReadAttrs(strings.NewReader("id,a,b,c,d\n1,100,x,true,1.5\n2,,\"y,z\",,\n"), pilosa.AttrFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(m, map[uint64]map[string]interface{}{
+		1: {"a": int64(100), "b": "x", "c": true, "d": 1.5},
+		2: {"b": "y,z"},
+	}) {
+		t.Fatalf("unexpected attrs: %#v", m)
+	}
+
+	if _, err := pilosa.ReadAttrs(strings.NewReader("x,a\n1,2\n"), pilosa.AttrFormatCSV); err == nil {
+		t.Fatal("expected header error")
+	} else if _, err := pilosa.ReadAttrs(strings.NewReader("id,a\nfoo,2\n"), pilosa.AttrFormatCSV); err == nil || err.Error() != `line 2: invalid id: "foo"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure attributes can be split into batches.
+func TestSplitAttrs(t *testing.T) {
+	m := map[uint64]map[string]interface{}{1: {"a": 1}, 2: {"a": 2}, 3: {"a": 3}}
+	if a := pilosa.SplitAttrs(m, 2); !reflect.DeepEqual(a, []map[uint64]map[string]interface{}{
+		{1: {"a": 1}, 2: {"a": 2}},
+		{3: {"a": 3}},
+	}) {
+		t.Fatalf("unexpected batches: %#v", a)
+	}
+}
+
 // MustOpenAttrStore returns a new, opened attribute store at a temporary path. Panic on error.
 func MustOpenAttrStore() *AttrStore {
 	s := NewAttrStore()
@@ -0,0 +1,182 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Default audit log rotation settings.
+const (
+	DefaultAuditMaxSize    = 100 * 1024 * 1024
+	DefaultAuditMaxBackups = 5
+)
+
+// Audit entry sources.
+const (
+	AuditSourceHTTP      = "http"
+	AuditSourceBroadcast = "broadcast"
+	AuditSourceGRPC      = "grpc"
+)
+
+// AuditEntry represents a single record in the audit log.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Source     string    `json:"source"`
+	Principal  string    `json:"principal,omitempty"`
+	RemoteAddr string    `json:"remoteAddr,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	Endpoint   string    `json:"endpoint"`
+	Index      string    `json:"index,omitempty"`
+	Frame      string    `json:"frame,omitempty"`
+	Alias      string    `json:"alias,omitempty"`
+	Calls      []string  `json:"calls,omitempty"`
+	Status     int       `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditLogger records schema changes and writes.
+type AuditLogger interface {
+	Log(entry *AuditEntry) error
+}
+
+// NopAuditLogger represents an audit logger that doesn't do anything.
+var NopAuditLogger AuditLogger = &nopAuditLogger{}
+
+type nopAuditLogger struct{}
+
+// Log is a no-op implementation of AuditLogger Log method.
+func (l *nopAuditLogger) Log(entry *AuditEntry) error { return nil }
+
+// AuditLog is an AuditLogger which writes entries to a file as JSON lines.
+// The file is rotated once it exceeds MaxSize bytes and up to MaxBackups
+// rotated files are kept as "<path>.1", "<path>.2", etc.
+type AuditLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+
+	MaxSize    int64
+	MaxBackups int
+}
+
+// NewAuditLog returns a new instance of AuditLog which writes to path.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{
+		path:       path,
+		MaxSize:    DefaultAuditMaxSize,
+		MaxBackups: DefaultAuditMaxBackups,
+	}
+}
+
+// Path returns the path to the current log file.
+func (l *AuditLog) Path() string { return l.path }
+
+// Open opens the log file for appending.
+func (l *AuditLog) Open() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.open()
+}
+
+func (l *AuditLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	l.file, l.size = f, fi.Size()
+	return nil
+}
+
+// Close closes the log file.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// Log writes entry to the log file, rotating it if necessary.
+func (l *AuditLog) Log(entry *AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	buf, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("audit log closed: %s", l.path)
+	}
+
+	if l.MaxSize > 0 && l.size > 0 && l.size+int64(len(buf)) > l.MaxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(buf)
+	l.size += int64(n)
+	return err
+}
+
+// rotate closes the current file, shifts the backups and reopens the log.
+func (l *AuditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+
+	if l.MaxBackups > 0 {
+		// Remove the oldest backup and shift the rest up by one.
+		if err := os.Remove(fmt.Sprintf("%s.%d", l.path, l.MaxBackups)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for i := l.MaxBackups - 1; i > 0; i-- {
+			if err := os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(l.path); err != nil {
+		return err
+	}
+
+	return l.open()
+}
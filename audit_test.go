@@ -0,0 +1,147 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// Ensure the audit log writes JSON lines and rotates files.
+func TestAuditLog_Rotate(t *testing.T) {
+	path, err := ioutil.TempDir("", "pilosa-audit-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	l := pilosa.NewAuditLog(filepath.Join(path, "audit.log"))
+	l.MaxSize = 200
+	l.MaxBackups = 2
+	if err := l.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 10; i++ {
+		if err := l.Log(&pilosa.AuditEntry{Source: pilosa.AuditSourceHTTP, Endpoint: "/index/i", Index: "i"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the configured number of backups should exist.
+	if _, err := os.Stat(l.Path() + ".2"); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(l.Path() + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("unexpected backup: %v", err)
+	}
+
+	// Each line should be a complete entry.
+	f, err := os.Open(l.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry pilosa.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		} else if entry.Index != "i" || entry.Time.IsZero() {
+			t.Fatalf("unexpected entry: %+v", entry)
+		}
+	}
+}
+
+// Ensure the handler records schema changes and write queries.
+func TestHandler_Audit(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+
+	var l AuditLogger
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Cluster = NewCluster(1)
+	h.AuditLogger = &l
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return make([]interface{}, len(query.Calls)), nil
+	}
+
+	h.ServeHTTP(httptest.NewRecorder(), MustNewHTTPRequest("POST", "/index/i/query", strings.NewReader(`Count(Bitmap(frame=f, rowID=1))`)))
+	h.ServeHTTP(httptest.NewRecorder(), MustNewHTTPRequest("POST", "/index/i/query", strings.NewReader(`Count(Bitmap(frame=f, rowID=1)) SetBit(frame=f, rowID=1, columnID=2)`)))
+	h.ServeHTTP(httptest.NewRecorder(), MustNewHTTPRequest("POST", "/index/i/attr/import?remote=true", strings.NewReader(`{"id":1,"attrs":{"x":1}}`)))
+	h.ServeHTTP(httptest.NewRecorder(), MustNewHTTPRequest("DELETE", "/index/i", nil))
+
+	if len(l.entries) != 3 {
+		t.Fatalf("unexpected entries: %+v", l.entries)
+	}
+	if e := l.entries[0]; e.Endpoint != "/index/i/query" || e.Index != "i" || !reflect.DeepEqual(e.Calls, []string{`SetBit(columnID=2, frame="f", rowID=1)`}) || e.Status != http.StatusOK {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	if e := l.entries[1]; e.Method != "POST" || e.Endpoint != "/index/i/attr/import" || e.Index != "i" || e.Status != http.StatusOK {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	if e := l.entries[2]; e.Method != "DELETE" || e.Endpoint != "/index/i" || e.Index != "i" || e.Status != http.StatusOK {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+}
+
+// Ensure the server records schema changes received from other nodes.
+func TestServer_ReceiveMessage_Audit(t *testing.T) {
+	var l AuditLogger
+	s := pilosa.NewServer()
+	s.AuditLogger = &l
+
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	s.Holder = hldr.Holder
+
+	if err := s.ReceiveMessage(&internal.CreateIndexMessage{Index: "i", Meta: &internal.IndexMeta{}}); err != nil {
+		t.Fatal(err)
+	} else if err := s.ReceiveMessage(&internal.CreateSliceMessage{Index: "i", Slice: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(l.entries) != 1 {
+		t.Fatalf("unexpected entries: %+v", l.entries)
+	} else if e := l.entries[0]; e.Source != pilosa.AuditSourceBroadcast || e.Endpoint != "CreateIndex" || e.Index != "i" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+}
+
+// AuditLogger is a mock audit logger which keeps entries in memory.
+type AuditLogger struct {
+	entries []*pilosa.AuditEntry
+}
+
+func (l *AuditLogger) Log(entry *pilosa.AuditEntry) error {
+	l.entries = append(l.entries, entry)
+	return nil
+}
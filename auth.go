@@ -0,0 +1,185 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTP headers used to authenticate requests between nodes.
+const (
+	HeaderNodeTimestamp = "X-Pilosa-Timestamp"
+	HeaderNodeSignature = "X-Pilosa-Signature"
+)
+
+// DefaultNodeAuthWindow is the maximum allowed clock difference between the
+// time a request is signed and the time it is verified.
+const DefaultNodeAuthWindow = 5 * time.Minute
+
+var (
+	// ErrNodeAuthRequired is returned when an internal request is not signed.
+	ErrNodeAuthRequired = errors.New("node authentication required")
+
+	// ErrNodeAuthInvalid is returned when an internal request has an invalid signature.
+	ErrNodeAuthInvalid = errors.New("invalid node signature")
+)
+
+// SignRequest signs req with an HMAC of the request and the shared secret.
+// The request body is read and replaced so that it can still be sent.
+func SignRequest(req *http.Request, secret []byte, now time.Time) error {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set(HeaderNodeTimestamp, timestamp)
+	req.Header.Set(HeaderNodeSignature, hex.EncodeToString(signature(secret, req, timestamp, body)))
+	return nil
+}
+
+// VerifyRequest returns an error if req is not signed by the shared secret
+// or if the signature is outside of the allowed time window.
+// The request body is read and replaced so that it can still be processed.
+func VerifyRequest(req *http.Request, secret []byte, now time.Time) error {
+	timestamp, sig := req.Header.Get(HeaderNodeTimestamp), req.Header.Get(HeaderNodeSignature)
+	if timestamp == "" || sig == "" {
+		return ErrNodeAuthRequired
+	}
+
+	// Reject signatures outside of the time window to limit replays.
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrNodeAuthInvalid
+	} else if d := now.Sub(time.Unix(sec, 0)); d > DefaultNodeAuthWindow || d < -DefaultNodeAuthWindow {
+		return ErrNodeAuthInvalid
+	}
+
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return ErrNodeAuthInvalid
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(expected, signature(secret, req, timestamp, body)) {
+		return ErrNodeAuthInvalid
+	}
+	return nil
+}
+
+// signature returns the HMAC of the request method, URI, timestamp & body.
+func signature(secret []byte, req *http.Request, timestamp string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(req.Method))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(req.URL.RequestURI()))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'\n'})
+	mac.Write(bodyHash[:])
+	return mac.Sum(nil)
+}
+
+// readRequestBody reads the entire body of req and replaces it with a copy.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// NodeTransport is an http.RoundTripper which signs each request with a
+// shared secret before sending it to another node.
+type NodeTransport struct {
+	Secret []byte
+
+	// Underlying transport. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// RoundTrip signs a copy of req and sends it using the underlying transport.
+func (t *NodeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Copy the request so the caller's request is not modified.
+	other := *req
+	other.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		other.Header[k] = v
+	}
+
+	if err := SignRequest(&other, t.Secret, time.Now()); err != nil {
+		return nil, err
+	}
+
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(&other)
+}
+
+// NewNodeHTTPClient returns an HTTP client for communicating between nodes.
+// Requests are signed if secret is set and tlsConfig is used for HTTPS
+// connections. Returns http.DefaultClient if neither is set.
+func NewNodeHTTPClient(secret []byte, tlsConfig *tls.Config) *http.Client {
+	if len(secret) == 0 && tlsConfig == nil {
+		return http.DefaultClient
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if tlsConfig != nil {
+		transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	if len(secret) > 0 {
+		transport = &NodeTransport{Secret: secret, Transport: transport}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// NewNodeClient returns a new instance of Client which signs its requests
+// with secret and connects over HTTPS if tlsConfig is set. This should be
+// used for requests to other nodes in the cluster.
+func NewNodeClient(host string, secret []byte, tlsConfig *tls.Config) (*Client, error) {
+	client, err := NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		client.scheme = "https"
+	}
+	client.HTTPClient = NewNodeHTTPClient(secret, tlsConfig)
+	return client, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa"
+)
+
+// Ensure a signed request can be verified.
+func TestVerifyRequest(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1000, 0)
+
+	req := MustNewHTTPRequest("POST", "/fragment/data?slice=0", strings.NewReader("data"))
+	if err := pilosa.SignRequest(req, secret, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify request and ensure body is still readable.
+	if err := pilosa.VerifyRequest(req, secret, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	} else if buf, err := ioutil.ReadAll(req.Body); err != nil {
+		t.Fatal(err)
+	} else if string(buf) != "data" {
+		t.Fatalf("unexpected body: %q", buf)
+	}
+
+	t.Run("ErrNodeAuthRequired", func(t *testing.T) {
+		req := MustNewHTTPRequest("POST", "/fragment/data?slice=0", strings.NewReader("data"))
+		if err := pilosa.VerifyRequest(req, secret, now); err != pilosa.ErrNodeAuthRequired {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("WrongSecret", func(t *testing.T) {
+		req := MustNewHTTPRequest("POST", "/fragment/data?slice=0", strings.NewReader("data"))
+		if err := pilosa.SignRequest(req, []byte("other"), now); err != nil {
+			t.Fatal(err)
+		} else if err := pilosa.VerifyRequest(req, secret, now); err != pilosa.ErrNodeAuthInvalid {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ModifiedBody", func(t *testing.T) {
+		req := MustNewHTTPRequest("POST", "/fragment/data?slice=0", strings.NewReader("data"))
+		if err := pilosa.SignRequest(req, secret, now); err != nil {
+			t.Fatal(err)
+		}
+		req.Body = ioutil.NopCloser(strings.NewReader("other"))
+		if err := pilosa.VerifyRequest(req, secret, now); err != pilosa.ErrNodeAuthInvalid {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		req := MustNewHTTPRequest("POST", "/fragment/data?slice=0", strings.NewReader("data"))
+		if err := pilosa.SignRequest(req, secret, now); err != nil {
+			t.Fatal(err)
+		} else if err := pilosa.VerifyRequest(req, secret, now.Add(time.Hour)); err != pilosa.ErrNodeAuthInvalid {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure internal endpoints require a signature when a secret is configured.
+func TestHandler_NodeAuth(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	s := NewServer()
+	defer s.Close()
+	s.Handler.Holder = hldr.Holder
+	s.Handler.Cluster = NewCluster(1)
+	s.Handler.Cluster.Secret = []byte("secret")
+
+	// Unsigned requests are rejected.
+	w := httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, MustNewHTTPRequest("GET", "/fragment/blocks?index=i&frame=f&view=standard&slice=0", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	// Signed requests are accepted.
+	client, err := pilosa.NewNodeClient(MustParseURLHost(s.URL), []byte("secret"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FragmentBlocks(context.Background(), "i", "f", pilosa.ViewStandard, 0); err != pilosa.ErrFragmentNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -41,6 +41,18 @@ func NewBitmap(bits ...uint64) *Bitmap {
 	return bm
 }
 
+// copyOnWrite returns a copy of b which shares its segment data. Segments
+// in the copy are cloned before they are changed so b is never modified.
+// Segments in b must not be changed after the copy is made.
+func (b *Bitmap) copyOnWrite() *Bitmap {
+	other := &Bitmap{segments: make([]BitmapSegment, len(b.segments))}
+	for i, s := range b.segments {
+		s.writable = false
+		other.segments[i] = s
+	}
+	return other
+}
+
 // Merge merges data from other into b.
 func (b *Bitmap) Merge(other *Bitmap) {
 	var segments []BitmapSegment
@@ -216,6 +228,25 @@ func (b *Bitmap) Count() uint64 {
 	return n
 }
 
+// size returns the approximate number of bytes held by the bitmap's segments.
+func (b *Bitmap) size() int {
+	var n int
+	for i := range b.segments {
+		n += b.segments[i].data.Size()
+	}
+	return n
+}
+
+// heapSize returns the number of bytes used by b which are allocated on
+// the heap rather than mapped from a fragment's storage.
+func (b *Bitmap) heapSize() int {
+	var n int
+	for i := range b.segments {
+		n += b.segments[i].data.HeapSize()
+	}
+	return n
+}
+
 // MarshalJSON returns a JSON-encoded byte slice of b.
 func (b *Bitmap) MarshalJSON() ([]byte, error) {
 	var o struct {
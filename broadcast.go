@@ -16,7 +16,12 @@ package pilosa
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/internal"
@@ -82,6 +87,139 @@ func (c *nopBroadcaster) SendAsync(pb proto.Message) error {
 	return nil
 }
 
+// Default create slice broadcast retry settings.
+const (
+	DefaultSliceBroadcastMinRetryInterval = 100 * time.Millisecond
+	DefaultSliceBroadcastMaxRetryInterval = 10 * time.Second
+)
+
+// sliceBroadcaster wraps a Broadcaster to reliably deliver create slice
+// messages. Each message is sent synchronously so peers know about a new slice
+// before the caller returns. If delivery fails, the message is queued and
+// retried in the background until it succeeds. Queued messages are coalesced
+// so that only the highest slice per index is retried.
+// All other messages are passed through to the underlying broadcaster.
+type sliceBroadcaster struct {
+	Broadcaster
+
+	mu      sync.Mutex
+	pending map[sliceBroadcastKey]*internal.CreateSliceMessage
+	notify  chan struct{}
+
+	closing <-chan struct{}
+
+	MinRetryInterval time.Duration
+	MaxRetryInterval time.Duration
+
+	LogOutput io.Writer
+}
+
+// sliceBroadcastKey is the key used to coalesce create slice messages.
+type sliceBroadcastKey struct {
+	index     string
+	isInverse bool
+}
+
+// newSliceBroadcaster returns a new instance of sliceBroadcaster wrapping b.
+func newSliceBroadcaster(b Broadcaster, closing <-chan struct{}) *sliceBroadcaster {
+	return &sliceBroadcaster{
+		Broadcaster: b,
+		pending:     make(map[sliceBroadcastKey]*internal.CreateSliceMessage),
+		notify:      make(chan struct{}, 1),
+		closing:     closing,
+
+		MinRetryInterval: DefaultSliceBroadcastMinRetryInterval,
+		MaxRetryInterval: DefaultSliceBroadcastMaxRetryInterval,
+
+		LogOutput: ioutil.Discard,
+	}
+}
+
+// SendAsync sends create slice messages synchronously and queues them for
+// retry on failure. Other messages are sent by the underlying broadcaster.
+func (b *sliceBroadcaster) SendAsync(pb proto.Message) error {
+	msg, ok := pb.(*internal.CreateSliceMessage)
+	if !ok {
+		return b.Broadcaster.SendAsync(pb)
+	}
+
+	if err := b.Broadcaster.SendSync(msg); err != nil {
+		b.logger().Printf("create slice broadcast error, retrying: index=%s, slice=%d, err=%s", msg.Index, msg.Slice, err)
+		b.enqueue(msg)
+	}
+	return nil
+}
+
+// enqueue adds msg to the retry queue unless a higher slice is already queued.
+func (b *sliceBroadcaster) enqueue(msg *internal.CreateSliceMessage) {
+	b.mu.Lock()
+	key := sliceBroadcastKey{index: msg.Index, isInverse: msg.IsInverse}
+	if other := b.pending[key]; other == nil || other.Slice < msg.Slice {
+		b.pending[key] = msg
+	}
+	b.mu.Unlock()
+
+	// Wake up the retry loop.
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+// PendingN returns the number of messages waiting to be retried.
+func (b *sliceBroadcaster) PendingN() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// run retries queued messages until the closing channel is closed.
+func (b *sliceBroadcaster) run() {
+	interval := b.MinRetryInterval
+	for {
+		// Wait for queued messages or close.
+		select {
+		case <-b.closing:
+			return
+		case <-b.notify:
+		}
+
+		// Swap out pending messages.
+		b.mu.Lock()
+		pending := b.pending
+		b.pending = make(map[sliceBroadcastKey]*internal.CreateSliceMessage)
+		b.mu.Unlock()
+
+		// Attempt to send each message and requeue failures.
+		var failed bool
+		for _, msg := range pending {
+			if err := b.Broadcaster.SendSync(msg); err != nil {
+				b.logger().Printf("create slice broadcast retry error: index=%s, slice=%d, err=%s", msg.Index, msg.Slice, err)
+				b.enqueue(msg)
+				failed = true
+			}
+		}
+
+		// Reset the interval if everything was delivered.
+		if !failed {
+			interval = b.MinRetryInterval
+			continue
+		}
+
+		// Otherwise back off before the next attempt.
+		select {
+		case <-b.closing:
+			return
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > b.MaxRetryInterval {
+			interval = b.MaxRetryInterval
+		}
+	}
+}
+
+func (b *sliceBroadcaster) logger() *log.Logger { return log.New(b.LogOutput, "", log.LstdFlags) }
+
 // BroadcastHandler is the interface for the pilosa object which knows how to
 // handle broadcast messages. (Hint: this is implemented by pilosa.Server)
 type BroadcastHandler interface {
@@ -113,6 +251,10 @@ const (
 	MessageTypeDeleteIndex = 3
 	MessageTypeCreateFrame = 4
 	MessageTypeDeleteFrame = 5
+	MessageTypeSetAlias    = 6
+	MessageTypeDeleteAlias = 7
+	MessageTypeUpdateIndex = 8
+	MessageTypeUpdateFrame = 9
 )
 
 // MarshalMessage encodes the protobuf message into a byte slice.
@@ -129,6 +271,14 @@ func MarshalMessage(m proto.Message) ([]byte, error) {
 		typ = MessageTypeCreateFrame
 	case *internal.DeleteFrameMessage:
 		typ = MessageTypeDeleteFrame
+	case *internal.SetAliasMessage:
+		typ = MessageTypeSetAlias
+	case *internal.DeleteAliasMessage:
+		typ = MessageTypeDeleteAlias
+	case *internal.UpdateIndexMessage:
+		typ = MessageTypeUpdateIndex
+	case *internal.UpdateFrameMessage:
+		typ = MessageTypeUpdateFrame
 	default:
 		return nil, fmt.Errorf("message type not implemented for marshalling: %s", reflect.TypeOf(obj))
 	}
@@ -155,6 +305,14 @@ func UnmarshalMessage(buf []byte) (proto.Message, error) {
 		m = &internal.CreateFrameMessage{}
 	case MessageTypeDeleteFrame:
 		m = &internal.DeleteFrameMessage{}
+	case MessageTypeSetAlias:
+		m = &internal.SetAliasMessage{}
+	case MessageTypeDeleteAlias:
+		m = &internal.DeleteAliasMessage{}
+	case MessageTypeUpdateIndex:
+		m = &internal.UpdateIndexMessage{}
+	case MessageTypeUpdateFrame:
+		m = &internal.UpdateFrameMessage{}
 	default:
 		return nil, fmt.Errorf("invalid message type: %d", typ)
 	}
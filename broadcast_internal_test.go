@@ -0,0 +1,131 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pilosa/pilosa/internal"
+)
+
+// Ensure create slice messages are retried until delivered.
+func TestSliceBroadcaster_Retry(t *testing.T) {
+	closing := make(chan struct{})
+	defer close(closing)
+
+	b := &failingBroadcaster{failN: 3}
+	sb := newSliceBroadcaster(b, closing)
+	sb.MinRetryInterval = time.Millisecond
+	go sb.run()
+
+	// Initial delivery fails but the error is not returned.
+	if err := sb.SendAsync(&internal.CreateSliceMessage{Index: "i", Slice: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lower slices for the same index are coalesced.
+	if err := sb.SendAsync(&internal.CreateSliceMessage{Index: "i", Slice: 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for retries to succeed.
+	timeout := time.After(time.Second)
+	for sb.PendingN() > 0 || b.SentN() == 0 {
+		select {
+		case <-timeout:
+			t.Fatal("timed out waiting for delivery")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	if msgs := b.Sent(); len(msgs) != 1 {
+		t.Fatalf("unexpected sent messages: %v", msgs)
+	} else if msg := msgs[0].(*internal.CreateSliceMessage); msg.Index != "i" || msg.Slice != 1 {
+		t.Fatalf("unexpected message: %v", msg)
+	}
+}
+
+// Ensure peers are sent a new max slice before an import returns.
+func TestSliceBroadcaster_Import(t *testing.T) {
+	path, err := ioutil.TempDir("", "pilosa-frame-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	f, err := NewFrame(path, "i", "f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &failingBroadcaster{}
+	f.broadcaster = newSliceBroadcaster(b, nil)
+	if err := f.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := f.Import([]uint64{1}, []uint64{3 * SliceWidth}, []*time.Time{nil}); err != nil {
+		t.Fatal(err)
+	} else if msgs := b.Sent(); len(msgs) != 1 {
+		t.Fatalf("unexpected sent messages: %v", msgs)
+	} else if msg := msgs[0].(*internal.CreateSliceMessage); msg.Index != "i" || msg.Slice != 3 {
+		t.Fatalf("unexpected message: %v", msg)
+	}
+}
+
+// Ensure non-slice messages are passed through to the underlying broadcaster.
+func TestSliceBroadcaster_PassThrough(t *testing.T) {
+	b := &failingBroadcaster{failN: 1}
+	sb := newSliceBroadcaster(b, nil)
+	if err := sb.SendAsync(&internal.DeleteIndexMessage{Index: "i"}); err == nil {
+		t.Fatal("expected error")
+	} else if sb.PendingN() != 0 {
+		t.Fatal("unexpected pending message")
+	}
+}
+
+// failingBroadcaster is a Broadcaster that fails the first failN sends.
+type failingBroadcaster struct {
+	mu    sync.Mutex
+	failN int
+	sent  []proto.Message
+}
+
+func (b *failingBroadcaster) SendSync(pb proto.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failN > 0 {
+		b.failN--
+		return errors.New("marker")
+	}
+	b.sent = append(b.sent, pb)
+	return nil
+}
+
+func (b *failingBroadcaster) SendAsync(pb proto.Message) error { return b.SendSync(pb) }
+
+func (b *failingBroadcaster) Sent() []proto.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sent
+}
+
+func (b *failingBroadcaster) SentN() int { return len(b.Sent()) }
@@ -34,6 +34,28 @@ func TestMessage_Marshal(t *testing.T) {
 	testMessageMarshal(t, &internal.DeleteIndexMessage{
 		Index: "i",
 	})
+
+	testMessageMarshal(t, &internal.SetAliasMessage{
+		Alias: "a",
+		Index: "i",
+	})
+
+	testMessageMarshal(t, &internal.DeleteAliasMessage{
+		Alias: "a",
+	})
+
+	testMessageMarshal(t, &internal.UpdateIndexMessage{
+		Index:  "i",
+		Option: "replicaN",
+		Value:  "2",
+	})
+
+	testMessageMarshal(t, &internal.UpdateFrameMessage{
+		Index:  "i",
+		Frame:  "f",
+		Option: "cacheType",
+		Value:  "lru",
+	})
 }
 
 func testMessageMarshal(t *testing.T, m proto.Message) {
@@ -16,21 +16,81 @@ package pilosa
 
 import (
 	"bytes"
+	"container/heap"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/golang/groupcache/lru"
 	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/roaring"
 )
 
 const (
+	// DefaultRowCacheSize is the default size of a RowCache, in bytes.
+	DefaultRowCacheSize = 256 * (1 << 20)
+
 	// ThresholdFactor is used to calculate the threshold for new items entering the cache
 	ThresholdFactor = 1.1
+
+	// DefaultCacheInterval is the minimum time between rankings of a cache.
+	DefaultCacheInterval = 10 * time.Second
 )
 
+// newCache returns a new cache of the given type. The interval limits how
+// often ranked caches are sorted and halfLife is used by decaying caches.
+func newCache(cacheType string, size uint32, interval, halfLife time.Duration) (Cache, error) {
+	if interval <= 0 {
+		interval = DefaultCacheInterval
+	}
+
+	switch cacheType {
+	case CacheTypeRanked:
+		c := NewRankCache(size)
+		c.interval = interval
+		return c, nil
+	case CacheTypeLRU:
+		return NewLRUCache(size), nil
+	case CacheTypeWindow:
+		c := NewWindowCache(size)
+		c.interval = interval
+		return c, nil
+	case CacheTypeDecay:
+		c := NewDecayCache(size, halfLife)
+		c.interval = interval
+		return c, nil
+	default:
+		return nil, ErrInvalidCacheType
+	}
+}
+
+// usesCacheInterval returns true if caches of cacheType rank on an interval.
+func usesCacheInterval(cacheType string) bool {
+	switch cacheType {
+	case CacheTypeRanked, CacheTypeWindow, CacheTypeDecay:
+		return true
+	default:
+		return false
+	}
+}
+
+// countsRows returns true if the counts in c are the full row counts.
+func countsRows(c Cache) bool {
+	switch c.(type) {
+	case *WindowCache, *DecayCache:
+		return false
+	default:
+		return true
+	}
+}
+
 // Cache represents a cache of counts.
 type Cache interface {
 	Add(id uint64, n uint64)
@@ -131,6 +191,9 @@ type RankCache struct {
 	updateN    int
 	updateTime time.Time
 
+	// interval is the minimum time between rankings.
+	interval time.Duration
+
 	// maxEntries is the user defined size of the cache
 	maxEntries uint32
 
@@ -148,6 +211,7 @@ func NewRankCache(maxEntries uint32) *RankCache {
 		maxEntries:      maxEntries,
 		thresholdBuffer: int(ThresholdFactor * float64(maxEntries)),
 		entries:         make(map[uint64]uint64),
+		interval:        DefaultCacheInterval,
 	}
 }
 
@@ -155,13 +219,10 @@ func NewRankCache(maxEntries uint32) *RankCache {
 func (c *RankCache) Add(id uint64, n uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	// Ignore if the bit count is below the threshold.
-	if n < c.thresholdValue {
+	if !c.set(id, n) {
 		return
 	}
 
-	c.entries[id] = n
-
 	c.invalidate()
 }
 
@@ -169,11 +230,36 @@ func (c *RankCache) Add(id uint64, n uint64) {
 func (c *RankCache) BulkAdd(id uint64, n uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.set(id, n)
+}
+
+// set updates the count of id. Counts below the threshold are ignored unless
+// the row is already cached so that cached counts are lowered as bits are
+// cleared. Returns false if the count was ignored.
+func (c *RankCache) set(id uint64, n uint64) bool {
+	if _, ok := c.entries[id]; ok {
+		if n == 0 {
+			delete(c.entries, id)
+		} else {
+			c.entries[id] = n
+		}
+		return true
+	}
+
+	// Ignore if the bit count is below the threshold.
 	if n < c.thresholdValue {
-		return
+		return false
 	}
 
 	c.entries[id] = n
+	return true
+}
+
+// threshold returns the lowest count which is added to the cache.
+func (c *RankCache) threshold() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.thresholdValue
 }
 
 // Get returns a count for a given id.
@@ -217,9 +303,8 @@ func (c *RankCache) Recalculate() {
 }
 
 func (c *RankCache) invalidate() {
-	// Don't invalidate more than once every X seconds.
-	// TODO: consider making this configurable.
-	if time.Now().Sub(c.updateTime).Seconds() < 10 {
+	// Don't invalidate more than once per interval.
+	if time.Now().Sub(c.updateTime) < c.interval {
 		return
 	}
 	c.recalculate()
@@ -274,6 +359,323 @@ func (c *RankCache) ReadFrom(r io.Reader) (n int64, err error) {
 // Ensure RankCache implements Cache.
 var _ Cache = &RankCache{}
 
+// cacheCounts returns the cached count of each of ids.
+func cacheCounts(c Cache, ids []uint64) []uint64 {
+	counts := make([]uint64, len(ids))
+
+	// Read LRU counts directly so the recency of each row is unchanged.
+	if c, ok := c.(*LRUCache); ok {
+		for i, id := range ids {
+			counts[i] = c.counts[id]
+		}
+		return counts
+	}
+
+	for i, id := range ids {
+		counts[i] = c.Get(id)
+	}
+	return counts
+}
+
+// CacheFile represents the row counts persisted for a fragment's cache.
+type CacheFile struct {
+	IDs    []uint64
+	Counts []uint64
+
+	// Number of ops in the fragment's ops log when the cache was flushed.
+	OpN int
+}
+
+// ReadCacheFile reads the cache file at path.
+func ReadCacheFile(path string) (*CacheFile, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pb internal.Cache
+	if err := proto.Unmarshal(buf, &pb); err != nil {
+		return nil, err
+	}
+	return &CacheFile{IDs: pb.IDs, Counts: pb.Counts, OpN: int(pb.OpN)}, nil
+}
+
+// HasCounts returns true if the file holds a count for each row id. Cache
+// files written by older versions and by window and decay caches only hold
+// row ids.
+func (cf *CacheFile) HasCounts() bool {
+	return len(cf.IDs) == 0 || len(cf.Counts) == len(cf.IDs)
+}
+
+// changedRows returns the ids of rows changed by ops written after the cache
+// was flushed. storage must be the bitmap unmarshaled from data. Returns an
+// error if the cache was flushed before the last snapshot.
+func (cf *CacheFile) changedRows(storage *roaring.Bitmap, data []byte) (map[uint64]struct{}, error) {
+	if cf.OpN > storage.OpN() {
+		return nil, fmt.Errorf("cache ops log position ahead of storage: %d > %d", cf.OpN, storage.OpN())
+	}
+
+	values, err := roaring.OpValues(data, storage.OpN()-cf.OpN)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[uint64]struct{})
+	for _, v := range values {
+		m[v/SliceWidth] = struct{}{}
+	}
+	return m, nil
+}
+
+// CacheDrift represents a cached row count which differs from storage.
+type CacheDrift struct {
+	ID     uint64
+	Cached uint64
+	Count  uint64
+}
+
+// Check compares the persisted counts against storage, which must be the
+// fragment bitmap unmarshaled from data. Rows changed by ops written after
+// the cache was flushed are skipped as they are recounted when the fragment
+// is opened.
+func (cf *CacheFile) Check(storage *roaring.Bitmap, data []byte) ([]CacheDrift, error) {
+	if !cf.HasCounts() {
+		return nil, errors.New("cache file has no counts")
+	}
+
+	changed, err := cf.changedRows(storage, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var a []CacheDrift
+	for i, id := range cf.IDs {
+		if _, ok := changed[id]; ok {
+			continue
+		}
+		if n := storage.CountRange(id*SliceWidth, (id+1)*SliceWidth); n != cf.Counts[i] {
+			a = append(a, CacheDrift{ID: id, Cached: cf.Counts[i], Count: n})
+		}
+	}
+	return a, nil
+}
+
+// WindowCache represents a ranked cache of row counts within a sliding window
+// of time views. Counts are not updated as bits are set. Instead the frame
+// replaces them with SetCounts when the window is queried.
+type WindowCache struct {
+	*RankCache
+}
+
+// NewWindowCache returns a new instance of WindowCache.
+func NewWindowCache(maxEntries uint32) *WindowCache {
+	return &WindowCache{RankCache: NewRankCache(maxEntries)}
+}
+
+// Add is a no-op. Counts are only set by SetCounts.
+func (c *WindowCache) Add(id uint64, n uint64) {}
+
+// BulkAdd is a no-op. Counts are only set by SetCounts.
+func (c *WindowCache) BulkAdd(id uint64, n uint64) {}
+
+// SetCounts replaces all counts in the cache and ranks them.
+func (c *WindowCache) SetCounts(pairs []BitmapPair) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[uint64]uint64, len(pairs))
+	for _, pair := range pairs {
+		c.entries[pair.ID] = pair.Count
+	}
+	c.recalculate()
+}
+
+// Ensure WindowCache implements Cache.
+var _ Cache = &WindowCache{}
+
+// DecayCache represents a ranked cache of scores which decay exponentially
+// over time. Each bit added to a row increases its score by one and the score
+// is halved every half-life.
+//
+// Scores are not persisted. When a fragment is opened each row starts with
+// its full count. A row which has been trimmed from the cache restarts with a
+// score of one when its next bit is set.
+type DecayCache struct {
+	mu       sync.Mutex
+	entries  map[uint64]*decayEntry
+	rankings []BitmapPair // cached, ordered list
+
+	updateTime time.Time
+	interval   time.Duration
+	halfLife   time.Duration
+
+	maxEntries      uint32
+	thresholdBuffer int
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// decayEntry holds the last known row count and the score of a row as of
+// the time it was last updated.
+type decayEntry struct {
+	count   uint64
+	score   float64
+	updated time.Time
+}
+
+// NewDecayCache returns a new instance of DecayCache.
+func NewDecayCache(maxEntries uint32, halfLife time.Duration) *DecayCache {
+	return &DecayCache{
+		entries:         make(map[uint64]*decayEntry),
+		interval:        DefaultCacheInterval,
+		halfLife:        halfLife,
+		maxEntries:      maxEntries,
+		thresholdBuffer: int(ThresholdFactor * float64(maxEntries)),
+		Now:             time.Now,
+	}
+}
+
+// Add updates the row count of id and adds any new bits to its score.
+func (c *DecayCache) Add(id uint64, n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A row which isn't cached is assumed to have had a single bit set.
+	if c.entries[id] == nil && n > 0 {
+		c.entries[id] = &decayEntry{count: n - 1, updated: c.Now()}
+	}
+	c.add(id, n)
+	c.invalidate()
+}
+
+// BulkAdd updates the row count of id unsorted. You should Invalidate after completion.
+func (c *DecayCache) BulkAdd(id uint64, n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(id, n)
+}
+
+func (c *DecayCache) add(id uint64, n uint64) {
+	now := c.Now()
+
+	e := c.entries[id]
+	if e == nil {
+		e = &decayEntry{updated: now}
+		c.entries[id] = e
+	}
+
+	e.score, e.updated = c.decay(e, now), now
+	if n > e.count {
+		e.score += float64(n - e.count)
+	}
+	e.count = n
+}
+
+// decay returns the score of e at time t.
+func (c *DecayCache) decay(e *decayEntry, t time.Time) float64 {
+	dt := t.Sub(e.updated)
+	if c.halfLife <= 0 || dt <= 0 {
+		return e.score
+	}
+	return e.score * math.Exp2(-float64(dt)/float64(c.halfLife))
+}
+
+// Get returns the current score for a given id, rounded to the nearest integer.
+func (c *DecayCache) Get(id uint64) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.entries[id]
+	if e == nil {
+		return 0
+	}
+	return uint64(c.decay(e, c.Now()) + 0.5)
+}
+
+// Len returns the number of items in the cache.
+func (c *DecayCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// IDs returns a list of all IDs in the cache.
+func (c *DecayCache) IDs() []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a := make([]uint64, 0, len(c.entries))
+	for id := range c.entries {
+		a = append(a, id)
+	}
+	sort.Sort(uint64Slice(a))
+	return a
+}
+
+// Invalidate ranks the entries by score if the interval has elapsed.
+func (c *DecayCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate()
+}
+
+// Recalculate ranks the entries by score.
+func (c *DecayCache) Recalculate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recalculate()
+}
+
+func (c *DecayCache) invalidate() {
+	if c.Now().Sub(c.updateTime) < c.interval {
+		return
+	}
+	c.recalculate()
+}
+
+func (c *DecayCache) recalculate() {
+	now := c.Now()
+
+	// Decay every score to the current time. Decay preserves the order of
+	// scores so the rankings remain ordered until the next update.
+	scores := make([]float64, 0, len(c.entries))
+	rankings := make([]BitmapPair, 0, len(c.entries))
+	for id, e := range c.entries {
+		e.score, e.updated = c.decay(e, now), now
+		scores = append(scores, e.score)
+		if n := uint64(e.score + 0.5); n > 0 {
+			rankings = append(rankings, BitmapPair{ID: id, Count: n})
+		}
+	}
+	sort.Sort(BitmapPairs(rankings))
+	if len(rankings) > int(c.maxEntries) {
+		rankings = rankings[:c.maxEntries]
+	}
+	c.rankings = rankings
+	c.updateTime = now
+
+	// If size is larger than the threshold then trim the lowest scores.
+	if len(c.entries) > c.thresholdBuffer {
+		sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+		threshold := scores[c.maxEntries]
+		for id, e := range c.entries {
+			if e.score <= threshold {
+				delete(c.entries, id)
+			}
+		}
+	}
+}
+
+// Top returns an ordered list of pairs.
+func (c *DecayCache) Top() []BitmapPair {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rankings
+}
+
+// Ensure DecayCache implements Cache.
+var _ Cache = &DecayCache{}
+
 // BitmapPair represents a id/count pair with an associated identifier.
 type BitmapPair struct {
 	ID    uint64
@@ -360,6 +762,55 @@ func (p Pairs) Add(other []Pair) []Pair {
 	return a
 }
 
+// Top returns the n pairs with the highest counts, ordered by count and
+// then by id. The pairs are selected with a heap bounded to n so that p is
+// not sorted in full. All pairs are returned if n is zero.
+func (p Pairs) Top(n int) []Pair {
+	if n == 0 || n > len(p) {
+		n = len(p)
+	}
+
+	h := make(rankedPairHeap, 0, n)
+	for _, pair := range p {
+		if h.Len() < n {
+			heap.Push(&h, pair)
+		} else if n > 0 && rankedPairLess(h[0], pair) {
+			h[0] = pair
+			heap.Fix(&h, 0)
+		}
+	}
+
+	// Pop the lowest ranked pairs off first.
+	a := make([]Pair, h.Len())
+	for i := len(a) - 1; i >= 0; i-- {
+		a[i] = heap.Pop(&h).(Pair)
+	}
+	return a
+}
+
+// rankedPairLess returns true if a ranks below b.
+// Pairs with equal counts rank higher with a lower id.
+func rankedPairLess(a, b Pair) bool {
+	if a.Count != b.Count {
+		return a.Count < b.Count
+	}
+	return a.ID > b.ID
+}
+
+// rankedPairHeap is a min-heap of pairs by rank.
+type rankedPairHeap []Pair
+
+func (h rankedPairHeap) Len() int            { return len(h) }
+func (h rankedPairHeap) Less(i, j int) bool  { return rankedPairLess(h[i], h[j]) }
+func (h rankedPairHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *rankedPairHeap) Push(x interface{}) { *h = append(*h, x.(Pair)) }
+func (h *rankedPairHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
 // Keys returns a slice of all keys in p.
 func (p Pairs) Keys() []uint64 {
 	a := make([]uint64, len(p))
@@ -438,6 +889,9 @@ func (p uint64Slice) merge(other []uint64) []uint64 {
 type BitmapCache interface {
 	Fetch(id uint64) (*Bitmap, bool)
 	Add(id uint64, b *Bitmap)
+
+	// Removes all bitmaps from the cache.
+	Clear()
 }
 
 // SimpleCache implements BitmapCache
@@ -459,3 +913,220 @@ func (s *SimpleCache) Fetch(id uint64) (*Bitmap, bool) {
 func (s *SimpleCache) Add(id uint64, b *Bitmap) {
 	s.cache[id] = b
 }
+
+// Clear removes all bitmaps from the cache.
+func (s *SimpleCache) Clear() {
+	s.cache = make(map[uint64]*Bitmap)
+}
+
+// RowCache is a memory budget for rows cached by many fragments.
+//
+// Each fragment caches its rows in a BoundedBitmapCache created from a
+// shared RowCache. Caches are only locked individually when rows are read or
+// written. Once the total size of the cached rows exceeds the budget, the
+// least recently used rows of any fragment are evicted in a single batch
+// until the size is below the low water mark.
+type RowCache struct {
+	mu       sync.Mutex // held while evicting
+	maxBytes int64
+	size     int64  // atomic
+	clock    uint64 // atomic, incremented on each use of a row
+	evicting int32  // atomic, set while a batch is being evicted
+	caches   map[*BoundedBitmapCache]struct{}
+
+	Stats StatsClient
+}
+
+// NewRowCache returns a new instance of RowCache which holds at most maxBytes of rows.
+func NewRowCache(maxBytes int64) *RowCache {
+	return &RowCache{
+		maxBytes: maxBytes,
+		caches:   make(map[*BoundedBitmapCache]struct{}),
+		Stats:    NopStatsClient,
+	}
+}
+
+// Len returns the number of rows held by all caches.
+func (c *RowCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int
+	for s := range c.caches {
+		s.mu.Lock()
+		n += len(s.entries)
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// Size returns the total size of the rows held by all caches, in bytes.
+func (c *RowCache) Size() int64 {
+	return atomic.LoadInt64(&c.size)
+}
+
+// ReportStats sends the size of the cached rows to the stats client.
+func (c *RowCache) ReportStats() {
+	c.Stats.Gauge("rowCacheSize", float64(c.Size()))
+}
+
+// NewBitmapCache returns a new cache for a single fragment's rows.
+func (c *RowCache) NewBitmapCache() *BoundedBitmapCache {
+	return &BoundedBitmapCache{
+		budget:  c,
+		entries: make(map[uint64]*rowCacheEntry),
+	}
+}
+
+// track registers s for eviction if it holds any rows and unregisters it
+// otherwise.
+func (c *RowCache) track(s *BoundedBitmapCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) > 0 {
+		c.caches[s] = struct{}{}
+	} else {
+		delete(c.caches, s)
+	}
+}
+
+// evict removes the least recently used rows across all caches until the
+// total size is below the low water mark. Returns immediately if another
+// goroutine is already evicting.
+func (c *RowCache) evict() {
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target := c.maxBytes - c.maxBytes/10
+	if atomic.LoadInt64(&c.size) <= target {
+		return
+	}
+
+	// Collect every cached row and order by last use.
+	var candidates rowCacheCandidates
+	for s := range c.caches {
+		s.mu.Lock()
+		for id, entry := range s.entries {
+			candidates = append(candidates, rowCacheCandidate{cache: s, id: id, entry: entry, used: atomic.LoadUint64(&entry.used)})
+		}
+		s.mu.Unlock()
+	}
+	sort.Sort(candidates)
+
+	var n int64
+	for _, cand := range candidates {
+		if atomic.LoadInt64(&c.size) <= target {
+			break
+		}
+
+		// Skip rows which were replaced since they were collected.
+		s := cand.cache
+		s.mu.Lock()
+		if s.entries[cand.id] == cand.entry {
+			delete(s.entries, cand.id)
+			atomic.AddInt64(&c.size, -cand.entry.size)
+			n++
+		}
+		if len(s.entries) == 0 {
+			delete(c.caches, s)
+		}
+		s.mu.Unlock()
+	}
+	if n > 0 {
+		c.Stats.Count("rowCacheEvict", n)
+	}
+}
+
+// rowCacheEntry is a single row held by a BoundedBitmapCache.
+type rowCacheEntry struct {
+	used uint64 // atomic, first for 64-bit alignment
+	bm   *Bitmap
+	size int64
+}
+
+// rowCacheCandidate is a row which may be evicted from a RowCache.
+type rowCacheCandidate struct {
+	cache *BoundedBitmapCache
+	id    uint64
+	entry *rowCacheEntry
+	used  uint64
+}
+
+// rowCacheCandidates sorts candidates from least to most recently used.
+type rowCacheCandidates []rowCacheCandidate
+
+func (p rowCacheCandidates) Len() int           { return len(p) }
+func (p rowCacheCandidates) Less(i, j int) bool { return p[i].used < p[j].used }
+func (p rowCacheCandidates) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// BoundedBitmapCache implements BitmapCache within the budget of a RowCache.
+type BoundedBitmapCache struct {
+	budget  *RowCache
+	mu      sync.Mutex
+	entries map[uint64]*rowCacheEntry
+}
+
+// Fetch retrieves the bitmap at the id in the cache.
+func (s *BoundedBitmapCache) Fetch(id uint64) (*Bitmap, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return nil, false
+	}
+	atomic.StoreUint64(&entry.used, atomic.AddUint64(&s.budget.clock, 1))
+	return entry.bm, true
+}
+
+// Add adds the bitmap to the cache, keyed on the id.
+// Adding a bitmap which is already cached updates its size. Only containers
+// allocated on the heap count towards the size.
+func (s *BoundedBitmapCache) Add(id uint64, b *Bitmap) {
+	c := s.budget
+	size := int64(b.heapSize())
+	used := atomic.AddUint64(&c.clock, 1)
+
+	s.mu.Lock()
+	empty := len(s.entries) == 0
+	var delta int64
+	if entry, ok := s.entries[id]; ok {
+		delete(s.entries, id)
+		delta -= entry.size
+	}
+	if size <= c.maxBytes {
+		s.entries[id] = &rowCacheEntry{bm: b, size: size, used: used}
+		delta += size
+	}
+	changed := empty != (len(s.entries) == 0)
+	s.mu.Unlock()
+
+	if changed {
+		c.track(s)
+	}
+	if atomic.AddInt64(&c.size, delta) > c.maxBytes {
+		c.evict()
+	}
+}
+
+// Clear removes all bitmaps from the cache and releases their budget.
+func (s *BoundedBitmapCache) Clear() {
+	s.mu.Lock()
+	var size int64
+	for _, entry := range s.entries {
+		size += entry.size
+	}
+	s.entries = make(map[uint64]*rowCacheEntry)
+	s.mu.Unlock()
+
+	atomic.AddInt64(&s.budget.size, -size)
+	s.budget.track(s)
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa"
+)
+
+// Ensure a rank cache updates rows which fall below the threshold.
+func TestRankCache_Add_BelowThreshold(t *testing.T) {
+	c := pilosa.NewRankCache(1)
+	c.BulkAdd(1, 10)
+	c.BulkAdd(2, 5)
+	c.BulkAdd(3, 4)
+	c.Recalculate()
+
+	// New rows below the threshold are ignored.
+	c.Add(4, 3)
+	if n := c.Get(4); n != 0 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// Cached rows are updated as their bits are cleared.
+	c.Add(1, 2)
+	if n := c.Get(1); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+	c.Add(1, 0)
+	if n := c.Len(); n != 0 {
+		t.Fatalf("unexpected len: %d", n)
+	}
+}
+
+// Ensure fragment row caches share a memory budget and evict the least recently used rows.
+func TestRowCache(t *testing.T) {
+	// Returns a bitmap of n bits, which uses 4 bytes per bit.
+	bits := func(n int) *pilosa.Bitmap {
+		bm := pilosa.NewBitmap()
+		for i := 0; i < n; i++ {
+			bm.SetBit(uint64(i))
+		}
+		return bm
+	}
+
+	budget := pilosa.NewRowCache(100)
+	c0, c1 := budget.NewBitmapCache(), budget.NewBitmapCache()
+
+	c0.Add(1, bits(10))
+	c0.Add(2, bits(10))
+	if n := budget.Size(); n != 80 {
+		t.Fatalf("unexpected size: %d", n)
+	}
+
+	// Fetching a row marks it as recently used.
+	if _, ok := c0.Fetch(1); !ok {
+		t.Fatal("expected row 1")
+	}
+
+	// Rows from any cache are evicted to make room.
+	c1.Add(1, bits(6))
+	if _, ok := c0.Fetch(2); ok {
+		t.Fatal("expected row 2 to be evicted")
+	} else if _, ok := c0.Fetch(1); !ok {
+		t.Fatal("expected row 1")
+	} else if n, sz := budget.Len(), budget.Size(); n != 2 || sz != 64 {
+		t.Fatalf("unexpected len/size: %d/%d", n, sz)
+	}
+
+	// Eviction continues below the budget so it isn't run on every add.
+	c1.Add(2, bits(1))
+	c1.Fetch(1)
+	c0.Fetch(1)
+	c1.Add(3, bits(9))
+	if n, sz := budget.Len(), budget.Size(); n != 2 || sz != 76 {
+		t.Fatalf("unexpected len/size: %d/%d", n, sz)
+	} else if _, ok := c1.Fetch(1); ok {
+		t.Fatal("expected row 1 to be evicted")
+	} else if _, ok := c0.Fetch(1); !ok {
+		t.Fatal("expected row 1")
+	}
+
+	// Rows larger than the budget are not cached.
+	c1.Add(4, bits(30))
+	if _, ok := c1.Fetch(4); ok {
+		t.Fatal("expected row 4 to be skipped")
+	}
+
+	// Clearing a cache releases its rows from the budget.
+	c1.Clear()
+	if n, sz := budget.Len(), budget.Size(); n != 1 || sz != 40 {
+		t.Fatalf("unexpected len/size: %d/%d", n, sz)
+	}
+}
+
+// Ensure a decay cache ranks rows by scores which halve every half-life.
+func TestDecayCache(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := pilosa.NewDecayCache(10, time.Hour)
+	c.Now = func() time.Time { return now }
+
+	c.BulkAdd(1, 4)
+	c.BulkAdd(2, 2)
+	c.Recalculate()
+	if pairs := c.Top(); !reflect.DeepEqual(pairs, []pilosa.BitmapPair{{ID: 1, Count: 4}, {ID: 2, Count: 2}}) {
+		t.Fatalf("unexpected pairs: %+v", pairs)
+	}
+
+	// After two half-lives only new bits keep their full weight.
+	now = now.Add(2 * time.Hour)
+	c.Add(2, 4)
+	if n := c.Get(1); n != 1 {
+		t.Fatalf("unexpected score: %d", n)
+	} else if n := c.Get(2); n != 3 {
+		t.Fatalf("unexpected score: %d", n)
+	}
+
+	// Rows which aren't cached start with the bit that was just set.
+	c.Add(3, 10)
+	if n := c.Get(3); n != 1 {
+		t.Fatalf("unexpected score: %d", n)
+	}
+
+	// Clearing bits doesn't change the score.
+	c.Add(2, 3)
+	if n := c.Get(2); n != 3 {
+		t.Fatalf("unexpected score: %d", n)
+	}
+
+	c.Recalculate()
+	if pairs := c.Top(); !reflect.DeepEqual(pairs[0], pilosa.BitmapPair{ID: 2, Count: 3}) {
+		t.Fatalf("unexpected pairs: %+v", pairs)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,7 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -37,7 +39,8 @@ import (
 
 // Client represents a client to the Pilosa cluster.
 type Client struct {
-	host string
+	host   string
+	scheme string
 
 	// The client to use for HTTP communication.
 	// Defaults to the http.DefaultClient.
@@ -45,17 +48,38 @@ type Client struct {
 }
 
 // NewClient returns a new instance of Client to connect to host.
+// The host may be prefixed with "https://" to connect over TLS.
 func NewClient(host string) (*Client, error) {
+	scheme := "http"
+	if strings.HasPrefix(host, "https://") {
+		host, scheme = strings.TrimPrefix(host, "https://"), "https"
+	} else if strings.HasPrefix(host, "http://") {
+		host = strings.TrimPrefix(host, "http://")
+	}
+
 	if host == "" {
 		return nil, ErrHostRequired
 	}
 
 	return &Client{
 		host:       host,
+		scheme:     scheme,
 		HTTPClient: http.DefaultClient,
 	}, nil
 }
 
+// NewTLSClient returns a new instance of Client to connect to host over HTTPS.
+// The tlsConfig is used to verify the server and can be nil to use the defaults.
+func NewTLSClient(host string, tlsConfig *tls.Config) (*Client, error) {
+	client, err := NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+	client.scheme = "https"
+	client.HTTPClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	return client, nil
+}
+
 // Host returns the host the client was initialized with.
 func (c *Client) Host() string { return c.host }
 
@@ -73,7 +97,7 @@ func (c *Client) MaxInverseSliceByIndex(ctx context.Context) (map[string]uint64,
 func (c *Client) maxSliceByIndex(ctx context.Context, inverse bool) (map[string]uint64, error) {
 	// Execute request against the host.
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   "/slices/max",
 		RawQuery: (&url.Values{
@@ -108,7 +132,7 @@ func (c *Client) maxSliceByIndex(ctx context.Context, inverse bool) (map[string]
 func (c *Client) Schema(ctx context.Context) ([]*IndexInfo, error) {
 	// Execute request against the host.
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   "/schema",
 	}
@@ -146,7 +170,7 @@ func (c *Client) CreateIndex(ctx context.Context, index string, opt IndexOptions
 	}
 
 	// Create URL & HTTP request.
-	u := url.URL{Scheme: "http", Host: c.host, Path: fmt.Sprintf("/index/%s", index)}
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: fmt.Sprintf("/index/%s", index)}
 	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return err
@@ -183,7 +207,7 @@ func (c *Client) CreateIndex(ctx context.Context, index string, opt IndexOptions
 func (c *Client) FragmentNodes(ctx context.Context, index string, slice uint64) ([]*Node, error) {
 	// Execute request against the host.
 	u := url.URL{
-		Scheme:   "http",
+		Scheme:   c.scheme,
 		Host:     c.host,
 		Path:     "/fragment/nodes",
 		RawQuery: (url.Values{"index": {index}, "slice": {strconv.FormatUint(slice, 10)}}).Encode(),
@@ -231,7 +255,7 @@ func (c *Client) ExecuteQuery(ctx context.Context, index, query string, allowRed
 
 	// Create URL & HTTP request.
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   fmt.Sprintf("/index/%s/query", index),
 	}
@@ -271,7 +295,7 @@ func (c *Client) ExecuteQuery(ctx context.Context, index, query string, allowRed
 // ExecutePQL executes query string against index on the server.
 func (c *Client) ExecutePQL(ctx context.Context, index, query string) (interface{}, error) {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   "/query",
 		RawQuery: url.Values{
@@ -354,7 +378,7 @@ func MarshalImportPayload(index, frame string, slice uint64, bits []Bit) ([]byte
 // importNode sends a pre-marshaled import request to a node.
 func (c *Client) importNode(ctx context.Context, node *Node, buf []byte) error {
 	// Create URL & HTTP request.
-	u := url.URL{Scheme: "http", Host: node.Host, Path: "/import"}
+	u := url.URL{Scheme: c.scheme, Host: node.Host, Path: "/import"}
 	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return err
@@ -388,6 +412,58 @@ func (c *Client) importNode(ctx context.Context, node *Node, buf []byte) error {
 	return nil
 }
 
+// ImportAttrs sets attributes by id on a frame's rows or, if frame is
+// empty, on an index's columns. The host forwards them to the rest of the cluster.
+func (c *Client) ImportAttrs(ctx context.Context, index, frame string, m map[uint64]map[string]interface{}) error {
+	if index == "" {
+		return ErrIndexRequired
+	}
+	return c.importAttrs(ctx, index, frame, m, false)
+}
+
+// importAttrs sends attributes to the host. Remote requests are not forwarded.
+func (c *Client) importAttrs(ctx context.Context, index, frame string, m map[uint64]map[string]interface{}, remote bool) error {
+	var buf bytes.Buffer
+	if err := WriteAttrs(&buf, m); err != nil {
+		return err
+	}
+
+	// Create URL & HTTP request.
+	path := fmt.Sprintf("/index/%s/attr/import", index)
+	if frame != "" {
+		path = fmt.Sprintf("/index/%s/frame/%s/attr/import", index, frame)
+	}
+	u := url.URL{
+		Scheme:   c.scheme,
+		Host:     c.host,
+		Path:     path,
+		RawQuery: url.Values{"remote": {strconv.FormatBool(remote)}}.Encode(),
+	}
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Length", strconv.Itoa(buf.Len()))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request against the host.
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	} else if resp.StatusCode != http.StatusOK {
+		return errors.New(string(body))
+	}
+	return nil
+}
+
 // ExportCSV bulk exports data for a single slice from a host to CSV format.
 func (c *Client) ExportCSV(ctx context.Context, index, frame string, slice uint64, w io.Writer) error {
 	if index == "" {
@@ -422,7 +498,7 @@ func (c *Client) ExportCSV(ctx context.Context, index, frame string, slice uint6
 func (c *Client) exportNodeCSV(ctx context.Context, node *Node, index, frame string, slice uint64, w io.Writer) error {
 	// Create URL.
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   node.Host,
 		Path:   "/export",
 		RawQuery: url.Values{
@@ -555,7 +631,7 @@ func (c *Client) BackupSlice(ctx context.Context, index, frame, view string, sli
 
 func (c *Client) backupSliceNode(ctx context.Context, index, frame, view string, slice uint64, node *Node) (io.ReadCloser, error) {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   node.Host,
 		Path:   "/fragment/data",
 		RawQuery: url.Values{
@@ -640,7 +716,7 @@ func (c *Client) restoreSliceFrom(ctx context.Context, buf []byte, index, frame,
 	// Restore slice to each owner.
 	for _, node := range nodes {
 		u := url.URL{
-			Scheme: "http",
+			Scheme: c.scheme,
 			Host:   node.Host,
 			Path:   "/fragment/data",
 			RawQuery: url.Values{
@@ -688,7 +764,7 @@ func (c *Client) CreateFrame(ctx context.Context, index, frame string, opt Frame
 	}
 
 	// Create URL & HTTP request.
-	u := url.URL{Scheme: "http", Host: c.host, Path: fmt.Sprintf("/index/%s/frame/%s", index, frame)}
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: fmt.Sprintf("/index/%s/frame/%s", index, frame)}
 	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return err
@@ -724,7 +800,7 @@ func (c *Client) CreateFrame(ctx context.Context, index, frame string, opt Frame
 // RestoreFrame restores an entire frame from a host in another cluster.
 func (c *Client) RestoreFrame(ctx context.Context, host, index, frame string) error {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.Host(),
 		Path:   fmt.Sprintf("/index/%s/frame/%s/restore", index, frame),
 		RawQuery: url.Values{
@@ -758,7 +834,7 @@ func (c *Client) RestoreFrame(ctx context.Context, host, index, frame string) er
 func (c *Client) FrameViews(ctx context.Context, index, frame string) ([]string, error) {
 	// Create URL & HTTP request.
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   fmt.Sprintf("/index/%s/frame/%s/views", index, frame),
 	}
@@ -793,11 +869,215 @@ func (c *Client) FrameViews(ctx context.Context, index, frame string) ([]string,
 	return rsp.Views, nil
 }
 
+// IndexSummary returns an index's options, frames and statistics.
+// Statistics only include data stored on the client's host.
+func (c *Client) IndexSummary(ctx context.Context, index string) (*IndexSummary, error) {
+	var rsp getIndexResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("/index/%s", index), url.Values{"summary": {"true"}}, ErrIndexNotFound, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp.Index, nil
+}
+
+// Frames returns the name and options of every frame in an index.
+func (c *Client) Frames(ctx context.Context, index string) ([]FrameDefinition, error) {
+	var rsp getFramesResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("/index/%s/frame", index), nil, ErrIndexNotFound, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp.Frames, nil
+}
+
+// Frame returns the name and options of a frame.
+func (c *Client) Frame(ctx context.Context, index, frame string) (*FrameDefinition, error) {
+	var rsp getFrameResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("/index/%s/frame/%s", index, frame), nil, ErrFrameNotFound, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp.Frame, nil
+}
+
+// FrameStats returns bit counts and on-disk sizes for each view and slice
+// of a frame. Statistics only include data stored on the client's host.
+func (c *Client) FrameStats(ctx context.Context, index, frame string) (*FrameStats, error) {
+	var rsp getFrameStatsResponse
+	if err := c.getJSON(ctx, fmt.Sprintf("/index/%s/frame/%s/stats", index, frame), nil, ErrFrameNotFound, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp.Stats, nil
+}
+
+// ApplySchema updates the cluster's schema to match def and returns the
+// changes required. Indexes and frames which are not in def are only deleted
+// if destructive is true; otherwise ErrSchemaChangeDestructive is returned
+// along with the changes. No changes are applied if dryRun is true. If a
+// change fails to apply, the changes applied before it are returned along
+// with an error describing the failed change.
+func (c *Client) ApplySchema(ctx context.Context, def *SchemaDefinition, destructive, dryRun bool) ([]SchemaChange, error) {
+	buf, err := json.Marshal(def)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create URL & HTTP request.
+	u := url.URL{
+		Scheme: c.scheme,
+		Host:   c.host,
+		Path:   "/schema",
+		RawQuery: url.Values{
+			"destructive": {strconv.FormatBool(destructive)},
+			"dryRun":      {strconv.FormatBool(dryRun)},
+		}.Encode(),
+	}
+	req, err := http.NewRequest("PUT", u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Length", strconv.Itoa(len(buf)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request against the host.
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle response based on status code. A failed apply returns the
+	// changes which were applied before the failure.
+	var rsp putSchemaResponse
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusConflict, http.StatusInternalServerError:
+		if err := json.Unmarshal(body, &rsp); err != nil {
+			return nil, errors.New(string(body))
+		}
+	default:
+		return nil, errors.New(string(body))
+	}
+
+	switch rsp.Error {
+	case "":
+		return rsp.Changes, nil
+	case ErrSchemaChangeDestructive.Error():
+		return rsp.Changes, ErrSchemaChangeDestructive
+	default:
+		return rsp.Changes, errors.New(rsp.Error)
+	}
+}
+
+// Aliases returns the index names by alias.
+func (c *Client) Aliases(ctx context.Context) (map[string]string, error) {
+	var rsp getAliasesResponse
+	if err := c.getJSON(ctx, "/alias", nil, nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp.Aliases, nil
+}
+
+// SetAlias points alias at index, replacing any existing target.
+func (c *Client) SetAlias(ctx context.Context, alias, index string) error {
+	buf, err := json.Marshal(&putAliasRequest{Index: index})
+	if err != nil {
+		return err
+	}
+
+	// Create URL & HTTP request.
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: fmt.Sprintf("/alias/%s", alias)}
+	req, err := http.NewRequest("PUT", u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Length", strconv.Itoa(len(buf)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	return c.doAliasRequest(ctx, req)
+}
+
+// DeleteAlias removes an alias.
+func (c *Client) DeleteAlias(ctx context.Context, alias string) error {
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: fmt.Sprintf("/alias/%s", alias)}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return c.doAliasRequest(ctx, req)
+}
+
+// doAliasRequest executes an alias request and converts error responses.
+func (c *Client) doAliasRequest(ctx context.Context, req *http.Request) error {
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Handle response based on status code.
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil // ok
+	case http.StatusConflict:
+		return ErrIndexExists
+	case http.StatusNotFound:
+		if req.Method == "DELETE" {
+			return ErrAliasNotFound
+		}
+		return ErrIndexNotFound
+	default:
+		return errors.New(string(body))
+	}
+}
+
+// getJSON executes a GET request against path and query and decodes the JSON response
+// into v. Returns notFound if the server responds with a 404.
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, notFound error, v interface{}) error {
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: path, RawQuery: query.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request against the host.
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Handle response based on status code.
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return notFound
+	default:
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.New(string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
 // FragmentBlocks returns a list of block checksums for a fragment on a host.
 // Only returns blocks which contain data.
 func (c *Client) FragmentBlocks(ctx context.Context, index, frame, view string, slice uint64) ([]FragmentBlock, error) {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   "/fragment/blocks",
 		RawQuery: url.Values{
@@ -851,7 +1131,7 @@ func (c *Client) BlockData(ctx context.Context, index, frame, view string, slice
 		return nil, nil, err
 	}
 
-	u := url.URL{Scheme: "http", Host: c.host, Path: "/fragment/block/data"}
+	u := url.URL{Scheme: c.scheme, Host: c.host, Path: "/fragment/block/data"}
 	req, err := http.NewRequest("GET", u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return nil, nil, err
@@ -888,7 +1168,7 @@ func (c *Client) BlockData(ctx context.Context, index, frame, view string, slice
 // ColumnAttrDiff returns data from differing blocks on a remote host.
 func (c *Client) ColumnAttrDiff(ctx context.Context, index string, blks []AttrBlock) (map[uint64]map[string]interface{}, error) {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   fmt.Sprintf("/index/%s/attr/diff", index),
 	}
@@ -931,7 +1211,7 @@ func (c *Client) ColumnAttrDiff(ctx context.Context, index string, blks []AttrBl
 // RowAttrDiff returns data from differing blocks on a remote host.
 func (c *Client) RowAttrDiff(ctx context.Context, index, frame string, blks []AttrBlock) (map[uint64]map[string]interface{}, error) {
 	u := url.URL{
-		Scheme: "http",
+		Scheme: c.scheme,
 		Host:   c.host,
 		Path:   fmt.Sprintf("/index/%s/frame/%s/attr/diff", index, frame),
 	}
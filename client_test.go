@@ -17,8 +17,12 @@ package pilosa_test
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -42,6 +46,38 @@ func createCluster(c *pilosa.Cluster) ([]*Server, []*Holder) {
 	return server, hldr
 }
 
+// Ensure the client can connect to a server over HTTPS.
+func TestClient_TLS(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFrameIfNotExists("i", "f")
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	s := httptest.NewTLSServer(h)
+	defer s.Close()
+
+	// Plain HTTP clients cannot connect.
+	if u, err := url.Parse(s.URL); err != nil {
+		t.Fatal(err)
+	} else if client, err := pilosa.NewClient(u.Host); err != nil {
+		t.Fatal(err)
+	} else if _, err := client.Schema(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// HTTPS clients can connect.
+	client, err := pilosa.NewTLSClient(s.URL, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, err := client.Schema(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if len(a) != 1 || a[0].Name != "i" {
+		t.Fatalf("unexpected schema: %s", spew.Sdump(a))
+	}
+}
+
 // Test distributed TopN Row count across 3 nodes.
 func TestClient_MultiNode(t *testing.T) {
 	cluster := NewCluster(3)
@@ -204,6 +240,139 @@ func TestClient_Import(t *testing.T) {
 	}
 }
 
+// Ensure client can retrieve frame definitions, frame statistics and index summaries.
+// Ensure client can apply a schema definition.
+func TestClient_ApplySchema(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	hldr.MustCreateIndexIfNotExists("old", pilosa.IndexOptions{})
+
+	s := NewServer()
+	defer s.Close()
+	s.Handler.Holder = hldr.Holder
+	c := MustNewClient(s.Host())
+
+	def := &pilosa.SchemaDefinition{
+		Indexes: []pilosa.IndexDefinition{{
+			Name:    "i",
+			Options: pilosa.IndexOptions{ColumnLabel: "col"},
+			Frames:  []pilosa.FrameDefinition{{Name: "f", Options: pilosa.FrameOptions{CacheSize: 10}}},
+		}},
+	}
+
+	// Destructive changes are refused unless allowed.
+	if changes, err := c.ApplySchema(context.Background(), def, false, false); err != pilosa.ErrSchemaChangeDestructive {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(changes) != 3 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	} else if hldr.Frame("i", "f") != nil {
+		t.Fatal("expected no changes to be applied")
+	}
+
+	// Dry runs do not apply changes.
+	if _, err := c.ApplySchema(context.Background(), def, true, true); err != nil {
+		t.Fatal(err)
+	} else if hldr.Index("old") == nil {
+		t.Fatal("expected no changes to be applied")
+	}
+
+	// Apply all changes.
+	if _, err := c.ApplySchema(context.Background(), def, true, false); err != nil {
+		t.Fatal(err)
+	} else if hldr.Index("old") != nil {
+		t.Fatal("expected index to be deleted")
+	} else if hldr.Index("i").ColumnLabel() != "col" {
+		t.Fatal("expected column label to be updated")
+	} else if f := hldr.Frame("i", "f"); f == nil || f.CacheSize() != 10 {
+		t.Fatal("expected frame to be created")
+	}
+
+	// Reapplying the same schema is a no-op.
+	if changes, err := c.ApplySchema(context.Background(), def, false, false); err != nil {
+		t.Fatal(err)
+	} else if len(changes) != 0 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	// Enable inverse storage on an existing frame.
+	def.Indexes[0].Frames[0].Options.InverseEnabled = true
+	if _, err := c.ApplySchema(context.Background(), def, false, false); err != nil {
+		t.Fatal(err)
+	} else if !hldr.Frame("i", "f").InverseEnabled() {
+		t.Fatal("expected inverse to be enabled")
+	}
+
+	// A failed change returns the changes applied before it.
+	def.Indexes[0].Frames = append([]pilosa.FrameDefinition{{Name: "g"}}, def.Indexes[0].Frames...)
+	def.Indexes[0].Frames[1].Options.CacheType = "invalid"
+	if changes, err := c.ApplySchema(context.Background(), def, false, false); err == nil || !strings.Contains(err.Error(), "updateFrame i/f cacheType=invalid") {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(changes) != 1 || changes[0].Action != pilosa.SchemaActionCreateFrame || changes[0].Frame != "g" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	} else if hldr.Frame("i", "g") == nil {
+		t.Fatal("expected frame to be created")
+	}
+}
+
+func TestClient_FrameMetadata(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	idx := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{ColumnLabel: "col"})
+	if _, err := idx.CreateFrame("f", pilosa.FrameOptions{RowLabel: "row", CacheType: pilosa.CacheTypeRanked, CacheSize: 100}); err != nil {
+		t.Fatal(err)
+	} else if _, err := idx.CreateFrame("g", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).MustSetBits(1, 1, 2)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 2).MustSetBits(1, (SliceWidth*2)+1)
+
+	s := NewServer()
+	defer s.Close()
+	s.Handler.Holder = hldr.Holder
+	c := MustNewClient(s.Host())
+
+	// List frames.
+	frames, err := c.Frames(context.Background(), "i")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(frames) != 2 || frames[0].Name != "f" || frames[1].Name != "g" {
+		t.Fatalf("unexpected frames: %+v", frames)
+	} else if opt := frames[0].Options; opt.RowLabel != "row" || opt.CacheType != pilosa.CacheTypeRanked || opt.CacheSize != 100 {
+		t.Fatalf("unexpected options: %+v", opt)
+	}
+
+	// Retrieve a single frame.
+	if f, err := c.Frame(context.Background(), "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if f.Name != "f" || f.Options.RowLabel != "row" {
+		t.Fatalf("unexpected frame: %+v", f)
+	} else if _, err := c.Frame(context.Background(), "i", "x"); err != pilosa.ErrFrameNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Retrieve frame statistics.
+	stats, err := c.FrameStats(context.Background(), "i", "f")
+	if err != nil {
+		t.Fatal(err)
+	} else if stats.BitN != 3 || len(stats.Views) != 1 || stats.Views[0].Name != pilosa.ViewStandard {
+		t.Fatalf("unexpected stats: %+v", stats)
+	} else if slices := stats.Views[0].Slices; len(slices) != 2 || slices[0].Slice != 0 || slices[0].BitN != 2 || slices[1].Slice != 2 || slices[1].BitN != 1 {
+		t.Fatalf("unexpected slice stats: %+v", slices)
+	}
+
+	// Retrieve index summary.
+	if summary, err := c.IndexSummary(context.Background(), "i"); err != nil {
+		t.Fatal(err)
+	} else if summary.Name != "i" || summary.Options.ColumnLabel != "col" || summary.MaxSlice != 2 || summary.Stats == nil || summary.Stats.BitN != 3 || !reflect.DeepEqual(summary.Frames, []string{"f", "g"}) {
+		t.Fatalf("unexpected summary: %+v", summary)
+	} else if _, err := c.IndexSummary(context.Background(), "x"); err != pilosa.ErrIndexNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Ensure client can bulk import data to an inverse frame.
 func TestClient_ImportInverseEnabled(t *testing.T) {
 	hldr := MustOpenHolder()
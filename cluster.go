@@ -15,8 +15,13 @@
 package pilosa
 
 import (
+	"crypto/tls"
 	"encoding/binary"
 	"hash/fnv"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
 
 	"github.com/pilosa/pilosa/internal"
 )
@@ -27,6 +32,15 @@ const (
 
 	// DefaultReplicaN is the default number of replicas per partition.
 	DefaultReplicaN = 1
+
+	// DefaultHasher is the default hashing algorithm used to assign partitions.
+	DefaultHasher = HasherJump
+)
+
+// Hasher types.
+const (
+	HasherJump       = "jump"
+	HasherRendezvous = "rendezvous"
 )
 
 // NodeState represents node state returned in /status endpoint for a node in the cluster.
@@ -130,6 +144,23 @@ type Cluster struct {
 
 	// The number of replicas a partition has.
 	ReplicaN int
+
+	// Shared secret used to authenticate requests between nodes.
+	// Internal requests are not authenticated if this is blank.
+	Secret []byte
+
+	// TLS configuration used for requests between nodes.
+	// Nodes are accessed over HTTPS if this is set.
+	TLSConfig *tls.Config
+
+	// Returns the replica count for an index if it overrides ReplicaN.
+	// A return value of zero uses the cluster's replica count.
+	IndexReplicaN func(index string) int
+
+	// HTTP client shared by all requests between nodes so that
+	// connections are reused. Built on first use.
+	mu         sync.Mutex
+	httpClient *http.Client
 }
 
 // NewCluster returns a new instance of Cluster with defaults.
@@ -141,6 +172,40 @@ func NewCluster() *Cluster {
 	}
 }
 
+// Scheme returns the URL scheme used to communicate with nodes.
+func (c *Cluster) Scheme() string {
+	if c != nil && c.TLSConfig != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// NodeHTTPClient returns the HTTP client used for requests between nodes.
+// Requests are signed with Secret and use TLSConfig for HTTPS connections.
+func (c *Cluster) NodeHTTPClient() *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.httpClient == nil {
+		c.httpClient = NewNodeHTTPClient(c.Secret, c.TLSConfig)
+	}
+	return c.httpClient
+}
+
+// NodeClient returns a Client for a node which uses the cluster's HTTP client.
+func (c *Cluster) NodeClient(host string) (*Client, error) {
+	client, err := NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+	client.scheme = c.Scheme()
+	client.HTTPClient = c.NodeHTTPClient()
+	return client, nil
+}
+
 // NodeSetHosts returns the list of host strings for NodeSet members.
 func (c *Cluster) NodeSetHosts() []string {
 	if c.NodeSet == nil {
@@ -208,7 +273,17 @@ func (c *Cluster) Partition(index string, slice uint64) int {
 
 // FragmentNodes returns a list of nodes that own a fragment.
 func (c *Cluster) FragmentNodes(index string, slice uint64) []*Node {
-	return c.PartitionNodes(c.Partition(index, slice))
+	return c.partitionNodes(c.Partition(index, slice), c.indexReplicaN(index))
+}
+
+// indexReplicaN returns the replica count for an index.
+func (c *Cluster) indexReplicaN(index string) int {
+	if c.IndexReplicaN != nil {
+		if n := c.IndexReplicaN(index); n > 0 {
+			return n
+		}
+	}
+	return c.ReplicaN
 }
 
 // OwnsFragment returns true if a host owns a fragment.
@@ -218,20 +293,35 @@ func (c *Cluster) OwnsFragment(host string, index string, slice uint64) bool {
 
 // PartitionNodes returns a list of nodes that own a partition.
 func (c *Cluster) PartitionNodes(partitionID int) []*Node {
+	return c.partitionNodes(partitionID, c.ReplicaN)
+}
+
+// partitionNodes returns a list of replicaN nodes that own a partition.
+func (c *Cluster) partitionNodes(partitionID, replicaN int) []*Node {
 	// Default replica count to between one and the number of nodes.
 	// The replica count can be zero if there are no nodes.
-	replicaN := c.ReplicaN
 	if replicaN > len(c.Nodes) {
 		replicaN = len(c.Nodes)
 	} else if replicaN == 0 {
 		replicaN = 1
 	}
 
+	nodes := make([]*Node, replicaN)
+
+	// Use host ordering if the hasher supports it so that removing a node
+	// only moves the partitions which that node owned.
+	if h, ok := c.Hasher.(HostHasher); ok {
+		positions := h.HashHosts(uint64(partitionID), Nodes(c.Nodes).Hosts())
+		for i := range nodes {
+			nodes[i] = c.Nodes[positions[i]]
+		}
+		return nodes
+	}
+
 	// Determine primary owner node.
 	index := c.Hasher.Hash(uint64(partitionID), len(c.Nodes))
 
 	// Collect nodes around the ring.
-	nodes := make([]*Node, replicaN)
 	for i := 0; i < replicaN; i++ {
 		nodes[i] = c.Nodes[(index+i)%len(c.Nodes)]
 	}
@@ -245,8 +335,7 @@ func (c *Cluster) OwnsSlices(index string, maxSlice uint64, host string) []uint6
 	for i := uint64(0); i <= maxSlice; i++ {
 		p := c.Partition(index, i)
 		// Determine primary owner node.
-		nodeIndex := c.Hasher.Hash(uint64(p), len(c.Nodes))
-		if c.Nodes[nodeIndex].Host == host {
+		if c.PartitionNodes(p)[0].Host == host {
 			slices = append(slices, i)
 		}
 	}
@@ -259,9 +348,40 @@ type Hasher interface {
 	Hash(key uint64, n int) int
 }
 
+// HostHasher represents a hasher which assigns keys based on host identity
+// instead of position in the host list.
+type HostHasher interface {
+	// Returns the positions of hosts ordered by preference for the key.
+	HashHosts(key uint64, hosts []string) []int
+}
+
 // NewHasher returns a new instance of the default hasher.
 func NewHasher() Hasher { return &jmphasher{} }
 
+// NewHasherByType returns a new instance of the named hasher type.
+func NewHasherByType(typ string) (Hasher, error) {
+	switch typ {
+	case HasherJump, "":
+		return &jmphasher{}, nil
+	case HasherRendezvous:
+		return &rendezvousHasher{}, nil
+	default:
+		return nil, ErrInvalidHasher
+	}
+}
+
+// hasherType returns the type name of h. Returns blank for unknown hashers.
+func hasherType(h Hasher) string {
+	switch h.(type) {
+	case *jmphasher:
+		return HasherJump
+	case *rendezvousHasher:
+		return HasherRendezvous
+	default:
+		return ""
+	}
+}
+
 // jmphasher represents an implementation of jmphash. Implements Hasher.
 type jmphasher struct{}
 
@@ -275,3 +395,71 @@ func (h *jmphasher) Hash(key uint64, n int) int {
 	}
 	return int(b)
 }
+
+// rendezvousHasher represents an implementation of rendezvous (highest random
+// weight) hashing. Implements Hasher & HostHasher.
+type rendezvousHasher struct{}
+
+// Hash returns the bucket with the highest weight for the key.
+func (h *rendezvousHasher) Hash(key uint64, n int) int {
+	b, max := -1, uint64(0)
+	for i := 0; i < n; i++ {
+		if w := rendezvousWeight(key, strconv.Itoa(i)); b == -1 || w > max {
+			b, max = i, w
+		}
+	}
+	return b
+}
+
+// HashHosts returns the host positions ordered by descending weight for the key.
+func (h *rendezvousHasher) HashHosts(key uint64, hosts []string) []int {
+	a := make(rendezvousWeights, len(hosts))
+	for i, host := range hosts {
+		a[i] = rendezvousWeightPos{pos: i, host: host, weight: rendezvousWeight(key, host)}
+	}
+	sort.Sort(a)
+
+	positions := make([]int, len(a))
+	for i := range a {
+		positions[i] = a[i].pos
+	}
+	return positions
+}
+
+// rendezvousWeightPos represents the weight of a host at a given position.
+type rendezvousWeightPos struct {
+	pos    int
+	host   string
+	weight uint64
+}
+
+// rendezvousWeights represents a list of host weights sorted by descending weight.
+type rendezvousWeights []rendezvousWeightPos
+
+func (p rendezvousWeights) Len() int      { return len(p) }
+func (p rendezvousWeights) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p rendezvousWeights) Less(i, j int) bool {
+	if p[i].weight != p[j].weight {
+		return p[i].weight > p[j].weight
+	}
+	return p[i].host < p[j].host
+}
+
+// rendezvousWeight returns the weight of a key for a given bucket name.
+func rendezvousWeight(key uint64, name string) uint64 {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], key)
+
+	h := fnv.New64a()
+	h.Write(buf[:])
+	h.Write([]byte(name))
+
+	// Finalize to spread the bits of similar names.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -49,6 +49,36 @@ func TestCluster_Owners(t *testing.T) {
 	}
 }
 
+// Ensure the cluster uses an index's replica count when it is set.
+func TestCluster_FragmentNodes_IndexReplicaN(t *testing.T) {
+	c := pilosa.Cluster{
+		Nodes: []*pilosa.Node{
+			{Host: "serverA:1000"},
+			{Host: "serverB:1000"},
+			{Host: "serverC:1000"},
+		},
+		Hasher:     NewModHasher(),
+		PartitionN: pilosa.DefaultPartitionN,
+		ReplicaN:   1,
+		IndexReplicaN: func(index string) int {
+			if index == "critical" {
+				return 3
+			}
+			return 0
+		},
+	}
+
+	if a := c.FragmentNodes("critical", 0); len(a) != 3 {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	} else if a := c.FragmentNodes("other", 0); len(a) != 1 {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	}
+
+	if !c.OwnsFragment("serverA:1000", "critical", 0) || !c.OwnsFragment("serverC:1000", "critical", 0) {
+		t.Fatal("expected all nodes to own fragment")
+	}
+}
+
 // Ensure the partitioner can assign a fragment to a partition.
 func TestCluster_Partition(t *testing.T) {
 	if err := quick.Check(func(index string, slice uint64, partitionN int) bool {
@@ -92,6 +122,66 @@ func TestHasher(t *testing.T) {
 	}
 }
 
+// Ensure the rendezvous hasher only moves partitions owned by a removed node.
+func TestCluster_PartitionNodes_Rendezvous(t *testing.T) {
+	hasher, err := pilosa.NewHasherByType(pilosa.HasherRendezvous)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := pilosa.NewCluster()
+	c.Hasher = hasher
+	c.PartitionN = 256
+	c.Nodes = []*pilosa.Node{
+		{Host: "serverA:1000"},
+		{Host: "serverB:1000"},
+		{Host: "serverC:1000"},
+		{Host: "serverD:1000"},
+	}
+
+	owners := make([]string, c.PartitionN)
+	for i := range owners {
+		owners[i] = c.PartitionNodes(i)[0].Host
+	}
+
+	// Remove a node from the middle of the list.
+	c.Nodes = append(c.Nodes[:1:1], c.Nodes[2:]...)
+	for i := range owners {
+		host := c.PartitionNodes(i)[0].Host
+		if owners[i] != "serverB:1000" && host != owners[i] {
+			t.Fatalf("partition %d moved from %s to %s", i, owners[i], host)
+		} else if host == "serverB:1000" {
+			t.Fatalf("partition %d still owned by removed node", i)
+		}
+	}
+}
+
+// Ensure an invalid hasher type returns an error.
+func TestNewHasherByType_Invalid(t *testing.T) {
+	if _, err := pilosa.NewHasherByType("foo"); err != pilosa.ErrInvalidHasher {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure the cluster reuses one signed HTTP client for all node requests.
+func TestCluster_NodeClient(t *testing.T) {
+	c := pilosa.NewCluster()
+	c.Secret = []byte("secret")
+
+	client := c.NodeHTTPClient()
+	if _, ok := client.Transport.(*pilosa.NodeTransport); !ok {
+		t.Fatalf("unexpected transport: %T", client.Transport)
+	} else if c.NodeHTTPClient() != client {
+		t.Fatal("expected shared client")
+	}
+
+	if nc, err := c.NodeClient("localhost:10101"); err != nil {
+		t.Fatal(err)
+	} else if nc.HTTPClient != client {
+		t.Fatal("expected node client to use shared client")
+	}
+}
+
 // Ensure that an empty cluster returns a valid (empty) NodeSet
 func TestCluster_NodeSetHosts(t *testing.T) {
 
@@ -34,6 +34,9 @@ func NewCheckCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
 		Short: "Do a consistency check on a pilosa data file.",
 		Long: `
 Performs a consistency check on data files.
+
+Cache files are checked by comparing their row counts against the fragment
+data file of the same name.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -51,6 +51,7 @@ The file does not contain any headers.
 	flags := exportCmd.Flags()
 
 	flags.StringVarP(&Exporter.Host, "host", "", "localhost:10101", "host:port of Pilosa.")
+	flags.StringVarP(&Exporter.APIKey, "api-key", "", "", "API key used to authenticate with Pilosa.")
 	flags.StringVarP(&Exporter.Index, "index", "i", "", "Pilosa index to export into.")
 	flags.StringVarP(&Exporter.Frame, "frame", "f", "", "Frame to export into.")
 	flags.StringVarP(&Exporter.Path, "output-file", "o", "", "File to write export to - default stdout")
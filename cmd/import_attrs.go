@@ -0,0 +1,69 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pilosa/pilosa/ctl"
+)
+
+var AttrImporter *ctl.ImportAttrsCommand
+
+func NewImportAttrsCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	AttrImporter = ctl.NewImportAttrsCommand(stdin, stdout, stderr)
+	importAttrsCmd := &cobra.Command{
+		Use:   "import-attrs",
+		Short: "Bulk load attributes into pilosa.",
+		Long: `Bulk imports row attributes for a frame or, when no frame is given, column
+attributes for an index from one or more files.
+
+JSON files contain one object per id:
+
+	{"id": 1, "attrs": {"name": "foo", "active": true}}
+
+CSV files start with a header row of "id" followed by attribute names:
+
+	id,name,active
+	1,foo,true
+
+Empty CSV cells are skipped. Files ending in ".csv" are read as CSV unless
+--format is set.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			AttrImporter.Paths = args
+			if err := AttrImporter.Run(context.Background()); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	flags := importAttrsCmd.Flags()
+	flags.StringVarP(&AttrImporter.Host, "host", "", "localhost:10101", "host:port of Pilosa.")
+	flags.StringVarP(&AttrImporter.APIKey, "api-key", "", "", "API key used to authenticate with Pilosa.")
+	flags.StringVarP(&AttrImporter.Index, "index", "i", "", "Pilosa index to import into.")
+	flags.StringVarP(&AttrImporter.Frame, "frame", "f", "", "Frame to import row attributes into.")
+	flags.StringVarP(&AttrImporter.Format, "format", "", "", "Input format: json or csv.")
+	flags.IntVarP(&AttrImporter.BufferSize, "buffer-size", "s", 100000, "Number of ids to send per request.")
+
+	return importAttrsCmd
+}
+
+func init() {
+	subcommandFns["import-attrs"] = NewImportAttrsCommand
+}
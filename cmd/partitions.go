@@ -0,0 +1,55 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/ctl"
+)
+
+var Partitioner *ctl.PartitionsCommand
+
+func NewPartitionsCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	Partitioner = ctl.NewPartitionsCommand(stdin, stdout, stderr)
+	partitionsCmd := &cobra.Command{
+		Use:   "partitions",
+		Short: "Print the partition-to-node map.",
+		Long: `
+Prints the nodes which own each partition for a given cluster configuration.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := Partitioner.Run(context.Background()); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	flags := partitionsCmd.Flags()
+	flags.IntVarP(&Partitioner.Config.Cluster.ReplicaN, "cluster.replicas", "", pilosa.DefaultReplicaN, "Number of hosts each piece of data should be stored on.")
+	flags.IntVarP(&Partitioner.Config.Cluster.PartitionN, "cluster.partitions", "", pilosa.DefaultPartitionN, "Number of partitions in the cluster.")
+	flags.StringVarP(&Partitioner.Config.Cluster.Hasher, "cluster.hasher", "", pilosa.DefaultHasher, "Hashing algorithm used to assign partitions to hosts. Choose from [jump, rendezvous]")
+	flags.StringSliceVarP(&Partitioner.Config.Cluster.Hosts, "cluster.hosts", "", []string{}, "Comma separated list of hosts in cluster.")
+
+	return partitionsCmd
+}
+
+func init() {
+	subcommandFns["partitions"] = NewPartitionsCommand
+}
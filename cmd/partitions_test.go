@@ -0,0 +1,52 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartitionsHelp(t *testing.T) {
+	output, err := ExecNewRootCommand(t, "partitions", "--help")
+	if !strings.Contains(output, "Usage:") ||
+		!strings.Contains(output, "pilosa partitions") || err != nil {
+		t.Fatalf("Command 'partitions --help' not working, err: '%v', output: '%s'", err, output)
+	}
+}
+
+func TestPartitionsNoHosts(t *testing.T) {
+	output, err := ExecNewRootCommand(t, "partitions")
+	if err == nil || !strings.Contains(err.Error(), "host required") {
+		t.Fatalf("Command 'partitions' without hosts should error but: err: '%v', output: '%v'", err, output)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	output, err := ExecNewRootCommand(t, "partitions", "--cluster.hosts", "serverA:1000,serverB:1000", "--cluster.partitions", "4", "--cluster.replicas", "2", "--cluster.hasher", "rendezvous")
+	if err != nil {
+		t.Fatalf("Command 'partitions' error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	for _, line := range lines[1:] {
+		if !strings.Contains(line, "serverA:1000") || !strings.Contains(line, "serverB:1000") {
+			t.Fatalf("unexpected partition line: %s", line)
+		}
+	}
+}
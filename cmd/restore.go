@@ -48,6 +48,11 @@ Restores a view to the cluster from a backup file.
 	flags.StringVarP(&Restorer.Frame, "frame", "f", "", "Frame to restore into.")
 	flags.StringVarP(&Restorer.View, "view", "v", "", "View to restore into.")
 	flags.StringVarP(&Restorer.Path, "input-file", "d", "", "File to restore data from.")
+	flags.StringVarP(&Restorer.Secret, "cluster.secret", "", "", "Shared secret of the cluster.")
+	flags.StringVarP(&Restorer.TLS.CertificatePath, "tls.certificate", "", "", "TLS client certificate path. Enables HTTPS when set.")
+	flags.StringVarP(&Restorer.TLS.KeyPath, "tls.key", "", "", "TLS client certificate key path.")
+	flags.StringVarP(&Restorer.TLS.CACertificatePath, "tls.ca-certificate", "", "", "TLS certificate authority path used to verify the cluster. Enables HTTPS when set.")
+	flags.BoolVarP(&Restorer.TLS.SkipVerify, "tls.skip-verify", "", false, "Skip verification of certificates from the cluster. Enables HTTPS when set.")
 
 	return restoreCmd
 }
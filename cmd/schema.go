@@ -0,0 +1,80 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pilosa/pilosa/ctl"
+)
+
+var SchemaApplier *ctl.SchemaApplyCommand
+
+func NewSchemaCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Manage the pilosa schema.",
+	}
+	schemaCmd.AddCommand(newSchemaApplyCommand(stdin, stdout, stderr))
+	return schemaCmd
+}
+
+func newSchemaApplyCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	SchemaApplier = ctl.NewSchemaApplyCommand(stdin, stdout, stderr)
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Update the schema to match a schema file.",
+		Long: `
+Compares the indexes and frames in a TOML schema file with the server's
+schema, then creates any missing indexes and frames and updates changed
+options. Indexes and frames which are not in the file are only deleted
+when --destructive is set.
+
+The schema file has the format:
+
+	[[index]]
+	name = "repository"
+
+	[index.options]
+	columnLabel = "repo_id"
+
+	[[index.frame]]
+	name = "stargazer"
+
+	[index.frame.options]
+	cacheSize = 100000
+	timeQuantum = "YMD"
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return SchemaApplier.Run(context.Background())
+		},
+	}
+	flags := applyCmd.Flags()
+
+	flags.StringVarP(&SchemaApplier.Host, "host", "", "localhost:10101", "host:port of Pilosa.")
+	flags.StringVarP(&SchemaApplier.APIKey, "api-key", "", "", "API key used to authenticate with Pilosa.")
+	flags.StringVarP(&SchemaApplier.Path, "file", "f", "", "TOML schema file to apply.")
+	flags.BoolVarP(&SchemaApplier.Destructive, "destructive", "", false, "Delete indexes and frames which are not in the schema file.")
+	flags.BoolVarP(&SchemaApplier.Plan, "plan", "", false, "Print the changes without applying them.")
+
+	return applyCmd
+}
+
+func init() {
+	subcommandFns["schema"] = NewSchemaCommand
+}
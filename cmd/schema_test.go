@@ -0,0 +1,52 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa/cmd"
+)
+
+func TestSchemaApplyHelp(t *testing.T) {
+	output, err := ExecNewRootCommand(t, "schema", "apply", "--help")
+	if !strings.Contains(output, "Usage:") ||
+		!strings.Contains(output, "Flags:") ||
+		!strings.Contains(output, "pilosa schema apply") || err != nil {
+		t.Fatalf("Command 'schema apply --help' not working, err: '%v', output: '%s'", err, output)
+	}
+}
+
+func TestSchemaApplyConfig(t *testing.T) {
+	tests := []commandTest{
+		{
+			args: []string{"schema", "apply", "-f", "/schema.toml", "--destructive"},
+			env:  map[string]string{"PILOSA_HOST": "localhost:12345"},
+			cfgFileContent: `
+plan = true
+`,
+			validation: func() error {
+				v := validator{}
+				v.Check(cmd.SchemaApplier.Host, "localhost:12345")
+				v.Check(cmd.SchemaApplier.Path, "/schema.toml")
+				v.Check(cmd.SchemaApplier.Destructive, true)
+				v.Check(cmd.SchemaApplier.Plan, true)
+				return v.Error()
+			},
+		},
+	}
+	executeDry(t, tests)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/pilosa/pilosa"
 	"github.com/pilosa/pilosa/server"
 )
 
@@ -90,16 +91,38 @@ on the configured port.`,
 	flags.StringVarP(&Server.Config.DataDir, "data-dir", "d", "~/.pilosa", "Directory to store pilosa data files.")
 	flags.StringVarP(&Server.Config.Host, "bind", "b", ":10101", "Default URI on which pilosa should listen.")
 	flags.IntVarP(&Server.Config.Cluster.ReplicaN, "cluster.replicas", "", 1, "Number of hosts each piece of data should be stored on.")
+	flags.IntVarP(&Server.Config.Cluster.PartitionN, "cluster.partitions", "", pilosa.DefaultPartitionN, "Number of partitions in the cluster. Cannot be changed once data is written.")
+	flags.StringVarP(&Server.Config.Cluster.Hasher, "cluster.hasher", "", pilosa.DefaultHasher, "Hashing algorithm used to assign partitions to hosts. Choose from [jump, rendezvous]")
 	flags.StringSliceVarP(&Server.Config.Cluster.Hosts, "cluster.hosts", "", []string{}, "Comma separated list of hosts in cluster.")
 	flags.StringSliceVarP(&Server.Config.Cluster.InternalHosts, "cluster.internal-hosts", "", []string{}, "Comma separated list of hosts in cluster used for internal communication.")
 	flags.DurationVarP((*time.Duration)(&Server.Config.Cluster.PollingInterval), "cluster.poll-interval", "", time.Minute, "Polling interval for cluster.") // TODO what actually is this?
 	flags.StringVarP(&Server.Config.Plugins.Path, "plugins.path", "", "", "Path to plugin directory.")
 	flags.StringVar(&Server.Config.LogPath, "log-path", "", "Log path")
 	flags.DurationVarP((*time.Duration)(&Server.Config.AntiEntropy.Interval), "anti-entropy.interval", "", time.Minute*10, "Interval at which to run anti-entropy routine.")
+	flags.StringVarP(&Server.Config.TLS.CertificatePath, "tls.certificate", "", "", "TLS certificate path. Enables HTTPS when set.")
+	flags.StringVarP(&Server.Config.TLS.KeyPath, "tls.key", "", "", "TLS certificate key path.")
+	flags.StringVarP(&Server.Config.TLS.CACertificatePath, "tls.ca-certificate", "", "", "TLS certificate authority path used to verify other nodes.")
+	flags.BoolVarP(&Server.Config.TLS.SkipVerify, "tls.skip-verify", "", false, "Skip verification of certificates from other nodes.")
+	flags.StringVarP(&Server.Config.GRPC.Host, "grpc.bind", "", "", "Address on which pilosa should serve the gRPC API. Disabled if empty.")
+	flags.StringVarP(&Server.Config.Auth.CredentialsPath, "auth.credentials", "", "", "Path to a JSON file of API keys and index permissions. Enables access control when set.")
+	flags.StringVarP(&Server.Config.Audit.Path, "audit.path", "", "", "Path to write the audit log of schema changes and write queries. Disabled if empty.")
+	flags.IntVarP(&Server.Config.Audit.MaxSize, "audit.max-size", "", pilosa.DefaultAuditMaxSize/(1<<20), "Size in megabytes at which the audit log is rotated.")
+	flags.IntVarP(&Server.Config.Audit.MaxBackups, "audit.max-backups", "", pilosa.DefaultAuditMaxBackups, "Number of rotated audit logs to keep.")
+	flags.IntVarP(&Server.Config.Cache.RowCacheSize, "cache.row-cache-size", "", pilosa.DefaultRowCacheSize/(1<<20), "Size in megabytes of the rows cached across all fragments.")
+	flags.DurationVarP((*time.Duration)(&Server.Config.Cache.VerifyInterval), "cache.verify-interval", "", pilosa.DefaultCacheVerifyInterval, "Interval at which cached row counts are verified against storage. Disabled if zero.")
+	flags.IntVarP(&Server.Config.Query.ResultCacheSize, "query.result-cache-size", "", pilosa.DefaultResultCacheSize/(1<<20), "Size in megabytes of the cache of query sub-expression results. Disabled if zero.")
+	flags.DurationVarP((*time.Duration)(&Server.Config.Query.Timeout), "query.timeout", "", 0, "Default maximum execution time of queries without a timeout. Disabled if zero.")
+	flags.IntVarP(&Server.Config.Query.MaxConcurrent, "query.max-concurrent", "", 0, "Maximum number of queries executing at once. Disabled if zero.")
+	flags.Uint64VarP(&Server.Config.Query.MaxResultBits, "query.max-result-bits", "", 0, "Maximum number of bits in a query result. Disabled if zero.")
+	flags.IntVarP(&Server.Config.Query.Concurrency, "query.concurrency", "", 0, "Maximum number of queries executing at once per index. Additional queries wait in a queue. Disabled if zero. Requires a cluster secret with multiple hosts.")
+	flags.IntVarP(&Server.Config.Query.MaxQueueLength, "query.max-queue-length", "", 0, "Maximum number of queries waiting per index. Unbounded if zero.")
+	flags.IntVarP(&Server.Config.Query.Workers, "query.workers", "", 0, "Number of goroutines shared by all queries to execute local slices. Defaults to the number of CPUs if zero.")
+	flags.IntVarP(&Server.Config.Query.SliceBatchSize, "query.slice-batch-size", "", pilosa.DefaultSliceBatchSize, "Maximum number of contiguous local slices executed by a worker at once.")
 	flags.StringVarP(&Server.CPUProfile, "profile.cpu", "", "", "Where to store CPU profile.")
 	flags.DurationVarP(&Server.CPUTime, "profile.cpu-time", "", 30*time.Second, "CPU profile duration.")
 	flags.StringVarP(&Server.Config.Cluster.Type, "cluster.type", "", "static", "Determine how the cluster handles membership and state sharing. Choose from [static, http, gossip]")
 	flags.StringVarP(&Server.Config.Cluster.GossipSeed, "cluster.gossip-seed", "", "", "Host with which to seed the gossip membership.")
+	flags.StringVarP(&Server.Config.Cluster.Secret, "cluster.secret", "", "", "Shared secret used to authenticate communication between nodes.")
 	flags.StringVarP(&Server.Config.Cluster.InternalPort, "cluster.internal-port", "", "", "Port to which pilosa should bind for internal state sharing.")
 
 	return serveCmd
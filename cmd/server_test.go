@@ -79,12 +79,15 @@ data-dir = "` + actualDataDir + `"
    ]
 [plugins]
   path = "/var/sloth"
+[cache]
+  verify-interval = "0s"
 `,
 			validation: func() error {
 				v := validator{}
 				v.Check(cmd.Server.Config.Cluster.Hosts, []string{"example.com:1110", "example.com:1111"})
 				v.Check(cmd.Server.Config.Plugins.Path, "/var/sloth")
 				v.Check(cmd.Server.Config.AntiEntropy.Interval, pilosa.Duration(time.Minute*9))
+				v.Check(cmd.Server.Config.Cache.VerifyInterval, pilosa.Duration(0))
 				return v.Error()
 			},
 		},
@@ -14,7 +14,13 @@
 
 package pilosa
 
-import "time"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"time"
+)
 
 const (
 	// DefaultHost is the default hostname to use.
@@ -35,14 +41,21 @@ type Config struct {
 	DataDir string `toml:"data-dir"`
 	Host    string `toml:"host"`
 
+	GRPC struct {
+		Host string `toml:"bind"`
+	} `toml:"grpc"`
+
 	Cluster struct {
 		ReplicaN        int      `toml:"replicas"`
+		PartitionN      int      `toml:"partitions"`
+		Hasher          string   `toml:"hasher"`
 		Type            string   `toml:"type"`
 		Hosts           []string `toml:"hosts"`
 		InternalHosts   []string `toml:"internal-hosts"`
 		PollingInterval Duration `toml:"polling-interval"`
 		InternalPort    string   `toml:"internal-port"`
 		GossipSeed      string   `toml:"gossip-seed"`
+		Secret          string   `toml:"secret"`
 	} `toml:"cluster"`
 
 	Plugins struct {
@@ -53,6 +66,43 @@ type Config struct {
 		Interval Duration `toml:"interval"`
 	} `toml:"anti-entropy"`
 
+	TLS TLSOptions `toml:"tls"`
+
+	Auth struct {
+		CredentialsPath string `toml:"credentials"`
+	} `toml:"auth"`
+
+	Audit struct {
+		Path       string `toml:"path"`
+		MaxSize    int    `toml:"max-size"` // megabytes
+		MaxBackups int    `toml:"max-backups"`
+	} `toml:"audit"`
+
+	Cache struct {
+		RowCacheSize int `toml:"row-cache-size"` // megabytes
+
+		// Interval at which cached row counts are verified against storage.
+		// Disabled if zero.
+		VerifyInterval Duration `toml:"verify-interval"`
+	} `toml:"cache"`
+
+	Query struct {
+		ResultCacheSize int      `toml:"result-cache-size"` // megabytes
+		Timeout         Duration `toml:"timeout"`
+		MaxConcurrent   int      `toml:"max-concurrent"`
+		MaxResultBits   uint64   `toml:"max-result-bits"`
+
+		// Scheduling of client queries per index.
+		Concurrency      int            `toml:"concurrency"`
+		IndexConcurrency map[string]int `toml:"index-concurrency"`
+		MaxQueueLength   int            `toml:"max-queue-length"`
+
+		// Number of goroutines mapping local slices. Defaults to the number of CPUs.
+		// Each goroutine maps a contiguous run of at most SliceBatchSize slices.
+		Workers        int `toml:"workers"`
+		SliceBatchSize int `toml:"slice-batch-size"`
+	} `toml:"query"`
+
 	LogPath string `toml:"log-path"`
 }
 
@@ -62,14 +112,127 @@ func NewConfig() *Config {
 		Host: DefaultHost + ":" + DefaultPort,
 	}
 	c.Cluster.ReplicaN = DefaultReplicaN
+	c.Cluster.PartitionN = DefaultPartitionN
+	c.Cluster.Hasher = DefaultHasher
 	c.Cluster.Type = DefaultClusterType
 	c.Cluster.PollingInterval = Duration(DefaultPollingInterval)
 	c.Cluster.Hosts = []string{}
 	c.Cluster.InternalHosts = []string{}
 	c.AntiEntropy.Interval = Duration(DefaultAntiEntropyInterval)
+	c.Audit.MaxSize = DefaultAuditMaxSize / (1 << 20)
+	c.Audit.MaxBackups = DefaultAuditMaxBackups
+	c.Cache.RowCacheSize = DefaultRowCacheSize / (1 << 20)
+	c.Cache.VerifyInterval = Duration(DefaultCacheVerifyInterval)
+	c.Query.ResultCacheSize = DefaultResultCacheSize / (1 << 20)
+	c.Query.SliceBatchSize = DefaultSliceBatchSize
 	return c
 }
 
+// NewCluster returns a new instance of Cluster based on the cluster configuration.
+// The returned cluster does not have a NodeSet assigned.
+func (c *Config) NewCluster() (*Cluster, error) {
+	cluster := NewCluster()
+	cluster.ReplicaN = c.Cluster.ReplicaN
+	if c.Cluster.PartitionN > 0 {
+		cluster.PartitionN = c.Cluster.PartitionN
+	}
+
+	hasher, err := NewHasherByType(c.Cluster.Hasher)
+	if err != nil {
+		return nil, err
+	}
+	cluster.Hasher = hasher
+	cluster.Secret = []byte(c.Cluster.Secret)
+
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	cluster.TLSConfig = tlsConfig
+
+	for _, hostport := range c.Cluster.Hosts {
+		cluster.Nodes = append(cluster.Nodes, &Node{Host: hostport})
+	}
+	// TODO: if InternalHosts is not provided then pilosa.Node.InternalHost is empty.
+	// This will throw an error when trying to Broadcast messages over HTTP.
+	// One option may be to fall back to using host from hostport + config.InternalPort.
+	for i, internalhostport := range c.Cluster.InternalHosts {
+		cluster.Nodes[i].InternalHost = internalhostport
+	}
+
+	return cluster, nil
+}
+
+// TLSConfig returns the TLS configuration based on the TLS settings.
+// Returns nil if no certificate is configured.
+func (c *Config) TLSConfig() (*tls.Config, error) {
+	if c.TLS.CertificatePath == "" {
+		return nil, nil
+	}
+	return c.TLS.tlsConfig()
+}
+
+// TLSOptions represents the TLS settings of a server or client.
+type TLSOptions struct {
+	CertificatePath   string `toml:"certificate"`
+	KeyPath           string `toml:"key"`
+	CACertificatePath string `toml:"ca-certificate"`
+	SkipVerify        bool   `toml:"skip-verify"`
+}
+
+// ClientTLSConfig returns the TLS configuration used by command line clients.
+// Unlike servers, clients do not require a certificate.
+// Returns nil if no TLS settings are set.
+func (o *TLSOptions) ClientTLSConfig() (*tls.Config, error) {
+	if o.CertificatePath == "" && o.CACertificatePath == "" && !o.SkipVerify {
+		return nil, nil
+	}
+	return o.tlsConfig()
+}
+
+func (o *TLSOptions) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.SkipVerify}
+
+	if o.CertificatePath != "" {
+		if o.KeyPath == "" {
+			return nil, errors.New("tls key required")
+		}
+
+		cert, err := tls.LoadX509KeyPair(o.CertificatePath, o.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// Use a custom certificate authority, if specified.
+	if o.CACertificatePath != "" {
+		buf, err := ioutil.ReadFile(o.CACertificatePath)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, errors.New("invalid ca certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// AccessControl returns the access control based on the auth settings.
+// Returns nil if no credentials file is configured.
+func (c *Config) AccessControl() (*AccessControl, error) {
+	if c.Auth.CredentialsPath == "" {
+		return nil, nil
+	} else if len(c.Cluster.Hosts) > 1 && c.Cluster.Secret == "" {
+		return nil, errors.New("cluster secret required when auth is enabled")
+	}
+	return LoadAccessControl(c.Auth.CredentialsPath)
+}
+
 // Duration is a TOML wrapper type for time.Duration.
 type Duration time.Duration
 
@@ -28,6 +28,9 @@ type BackupCommand struct {
 	// Destination host and port.
 	Host string
 
+	// API key used to authenticate with the server.
+	APIKey string
+
 	// Name of the index, frame, view to backup.
 	Index string
 	Frame string
@@ -59,6 +62,9 @@ func (cmd *BackupCommand) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
 
 	// Open output file.
 	f, err := os.Create(cmd.Path)
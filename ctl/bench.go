@@ -30,6 +30,9 @@ type BenchCommand struct {
 	// Destination host and port.
 	Host string
 
+	// API key used to authenticate with the server.
+	APIKey string
+
 	// Name of the index & frame to execute against.
 	Index string
 	Frame string
@@ -56,6 +59,9 @@ func (cmd *BenchCommand) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
 
 	switch cmd.Op {
 	case "set-bit":
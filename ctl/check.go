@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/pilosa/pilosa"
@@ -68,31 +69,12 @@ func (cmd *CheckCommand) Run(ctx context.Context) error {
 
 // checkBitmapFile performs a consistency check on path for a roaring bitmap file.
 func (cmd *CheckCommand) checkBitmapFile(path string) error {
-	// Open file handle.
-	f, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	fi, err := f.Stat()
-	if err != nil {
-		return err
-	}
-
-	// Memory map the file.
-	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	bm, data, err := openBitmapFile(path)
 	if err != nil {
 		return err
 	}
 	defer syscall.Munmap(data)
 
-	// Attach the mmap file to the bitmap.
-	bm := roaring.NewBitmap()
-	if err := bm.UnmarshalBinary(data); err != nil {
-		return err
-	}
-
 	// Perform consistency check.
 	if err := bm.Check(); err != nil {
 		// Print returned errors.
@@ -112,9 +94,39 @@ func (cmd *CheckCommand) checkBitmapFile(path string) error {
 	return nil
 }
 
-// checkCacheFile performs a consistency check on path for a cache file.
+// checkCacheFile performs a consistency check on path for a cache file by
+// comparing its row counts against the fragment's bitmap file.
 func (cmd *CheckCommand) checkCacheFile(path string) error {
-	fmt.Fprintf(cmd.Stderr, "%s: ignoring cache file\n", path)
+	cf, err := pilosa.ReadCacheFile(path)
+	if err != nil {
+		return err
+	} else if !cf.HasCounts() {
+		fmt.Fprintf(cmd.Stderr, "%s: ignoring cache file without counts\n", path)
+		return nil
+	}
+
+	// Open the fragment's bitmap file.
+	bm, data, err := openBitmapFile(strings.TrimSuffix(path, pilosa.CacheExt))
+	if err != nil {
+		return err
+	}
+	defer syscall.Munmap(data)
+
+	// Print each row count which doesn't match storage.
+	drift, err := cf.Check(bm, data)
+	if err != nil {
+		fmt.Fprintf(cmd.Stdout, "%s: %s\n", path, err)
+		return nil
+	}
+	for _, d := range drift {
+		fmt.Fprintf(cmd.Stdout, "%s: row %d: cached count %d, storage count %d\n", path, d.ID, d.Cached, d.Count)
+	}
+
+	// Print success message if no errors were found.
+	if len(drift) == 0 {
+		fmt.Fprintf(cmd.Stdout, "%s: ok\n", path)
+	}
+
 	return nil
 }
 
@@ -123,3 +135,33 @@ func (cmd *CheckCommand) checkSnapshotFile(path string) error {
 	fmt.Fprintf(cmd.Stderr, "%s: ignoring snapshot file\n", path)
 	return nil
 }
+
+// openBitmapFile memory maps the roaring bitmap file at path and attaches it
+// to a bitmap. The caller must unmap the returned data when finished.
+func openBitmapFile(path string) (*roaring.Bitmap, []byte, error) {
+	// Open file handle.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Memory map the file.
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Attach the mmap file to the bitmap.
+	bm := roaring.NewBitmap()
+	if err := bm.UnmarshalBinary(data); err != nil {
+		syscall.Munmap(data)
+		return nil, nil, err
+	}
+	return bm, data, nil
+}
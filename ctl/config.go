@@ -41,9 +41,15 @@ func (cmd *ConfigCommand) Run(ctx context.Context) error {
 data-dir = "~/.pilosa"
 bind = "localhost:10101"
 
+[grpc]
+  bind = ""
+
 [cluster]
   poll-interval = "2m0s"
   replicas = 1
+  partitions = 16
+  hasher = "jump"
+  secret = ""
   hosts = [
     "localhost:10101",
   ]
@@ -55,6 +61,34 @@ bind = "localhost:10101"
   cpu = ""
   cpu-time = "30s"
 
+[tls]
+  certificate = ""
+  key = ""
+  ca-certificate = ""
+  skip-verify = false
+
+[auth]
+  credentials = ""
+
+[audit]
+  path = ""
+  max-size = 100
+  max-backups = 5
+
+[cache]
+  row-cache-size = 256
+  verify-interval = "1h0m0s"
+
+[query]
+  result-cache-size = 64
+  timeout = "0s"
+  max-concurrent = 0
+  max-result-bits = 0
+  concurrency = 0
+  max-queue-length = 0
+  workers = 0
+  slice-batch-size = 16
+
 [plugins]
   path = ""
 `)+"\n")
@@ -28,6 +28,9 @@ type ExportCommand struct {
 	// Remote host and port.
 	Host string
 
+	// API key used to authenticate with the server.
+	APIKey string
+
 	// Name of the index & frame to export from.
 	Index string
 	Frame string
@@ -75,6 +78,9 @@ func (cmd *ExportCommand) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
 
 	// Determine slice count.
 	maxSlices, err := client.MaxSliceByIndex(ctx)
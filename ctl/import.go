@@ -33,6 +33,9 @@ type ImportCommand struct {
 	// Destination host and port.
 	Host string `json:"host"`
 
+	// API key used to authenticate with the server.
+	APIKey string `json:"apiKey"`
+
 	// Name of the index & frame to import into.
 	Index string `json:"index"`
 	Frame string `json:"frame"`
@@ -81,6 +84,9 @@ func (cmd *ImportCommand) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
 	cmd.Client = client
 
 	// Import each path and import by slice.
@@ -0,0 +1,142 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pilosa/pilosa"
+)
+
+// ImportAttrsCommand represents a command for bulk importing attributes.
+type ImportAttrsCommand struct {
+	// Destination host and port.
+	Host string `json:"host"`
+
+	// API key used to authenticate with the server.
+	APIKey string `json:"apiKey"`
+
+	// Name of the index to import into. If a frame is set then row
+	// attributes are imported, otherwise column attributes are imported.
+	Index string `json:"index"`
+	Frame string `json:"frame"`
+
+	// Filenames to import from.
+	Paths []string `json:"paths"`
+
+	// Input format. Defaults to CSV for files ending in ".csv" and JSON otherwise.
+	Format string `json:"format"`
+
+	// Number of ids sent per request.
+	BufferSize int `json:"bufferSize"`
+
+	// Reusable client.
+	Client *pilosa.Client `json:"-"`
+
+	// Standard input/output
+	*pilosa.CmdIO
+}
+
+// NewImportAttrsCommand returns a new instance of ImportAttrsCommand.
+func NewImportAttrsCommand(stdin io.Reader, stdout, stderr io.Writer) *ImportAttrsCommand {
+	return &ImportAttrsCommand{
+		CmdIO: pilosa.NewCmdIO(stdin, stdout, stderr),
+
+		BufferSize: 100000,
+	}
+}
+
+func (cmd *ImportAttrsCommand) String() string {
+	return fmt.Sprint(*cmd)
+}
+
+// Run executes the main program execution.
+func (cmd *ImportAttrsCommand) Run(ctx context.Context) error {
+	logger := log.New(cmd.Stderr, "", log.LstdFlags)
+
+	// Validate arguments.
+	if cmd.Index == "" {
+		return pilosa.ErrIndexRequired
+	} else if len(cmd.Paths) == 0 {
+		return errors.New("path required")
+	}
+	switch cmd.Format {
+	case "", pilosa.AttrFormatJSON, pilosa.AttrFormatCSV:
+	default:
+		return fmt.Errorf("invalid format: %q", cmd.Format)
+	}
+
+	// Create a client to the server.
+	client, err := pilosa.NewClient(cmd.Host)
+	if err != nil {
+		return err
+	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
+	cmd.Client = client
+
+	for _, path := range cmd.Paths {
+		logger.Printf("parsing: %s", path)
+		if err := cmd.importPath(ctx, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importPath parses a path into attributes and imports them to the server.
+func (cmd *ImportAttrsCommand) importPath(ctx context.Context, path string) error {
+	logger := log.New(cmd.Stderr, "", log.LstdFlags)
+
+	format := cmd.Format
+	if format == "" {
+		format = pilosa.AttrFormatJSON
+		if filepath.Ext(path) == ".csv" {
+			format = pilosa.AttrFormatCSV
+		}
+	}
+
+	r := cmd.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	m, err := pilosa.ReadAttrs(r, format)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+
+	for _, batch := range pilosa.SplitAttrs(m, cmd.BufferSize) {
+		logger.Printf("importing attributes: n=%d", len(batch))
+		if err := cmd.Client.ImportAttrs(ctx, cmd.Index, cmd.Frame, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
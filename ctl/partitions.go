@@ -0,0 +1,59 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pilosa/pilosa"
+)
+
+// PartitionsCommand represents a command for printing the partition-to-node map.
+type PartitionsCommand struct {
+	// Cluster configuration used to build the map.
+	Config *pilosa.Config
+
+	*pilosa.CmdIO
+}
+
+// NewPartitionsCommand returns a new instance of PartitionsCommand.
+func NewPartitionsCommand(stdin io.Reader, stdout, stderr io.Writer) *PartitionsCommand {
+	return &PartitionsCommand{
+		Config: pilosa.NewConfig(),
+		CmdIO:  pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// Run prints the nodes which own each partition.
+func (cmd *PartitionsCommand) Run(ctx context.Context) error {
+	cluster, err := cmd.Config.NewCluster()
+	if err != nil {
+		return err
+	} else if len(cluster.Nodes) == 0 {
+		return errors.New("at least one cluster host required")
+	}
+
+	tw := tabwriter.NewWriter(cmd.Stdout, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "%s\t%s\n", "PARTITION", "NODES")
+	for i := 0; i < cluster.PartitionN; i++ {
+		fmt.Fprintf(tw, "%d\t%s\n", i, strings.Join(pilosa.Nodes(cluster.PartitionNodes(i)).Hosts(), ","))
+	}
+	return tw.Flush()
+}
@@ -36,6 +36,12 @@ type RestoreCommand struct {
 	// Import file to read from.
 	Path string
 
+	// Shared secret of the cluster, if any.
+	Secret string
+
+	// TLS settings used to connect to the cluster.
+	TLS pilosa.TLSOptions
+
 	// Standard input/output
 	*pilosa.CmdIO
 }
@@ -54,8 +60,13 @@ func (cmd *RestoreCommand) Run(ctx context.Context) error {
 		return errors.New("backup file required")
 	}
 
+	tlsConfig, err := cmd.TLS.ClientTLSConfig()
+	if err != nil {
+		return err
+	}
+
 	// Create a client to the server.
-	client, err := pilosa.NewClient(cmd.Host)
+	client, err := pilosa.NewNodeClient(cmd.Host, []byte(cmd.Secret), tlsConfig)
 	if err != nil {
 		return err
 	}
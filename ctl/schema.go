@@ -0,0 +1,104 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/pilosa/pilosa"
+)
+
+// SchemaApplyCommand represents a command for updating a server's schema
+// to match a schema file.
+type SchemaApplyCommand struct {
+	// Remote host and port.
+	Host string
+
+	// API key used to authenticate with the server.
+	APIKey string
+
+	// Path to the TOML schema file.
+	Path string
+
+	// Allow indexes and frames missing from the schema file to be deleted.
+	Destructive bool
+
+	// Only print the changes without applying them.
+	Plan bool
+
+	// Standard input/output
+	*pilosa.CmdIO
+}
+
+// NewSchemaApplyCommand returns a new instance of SchemaApplyCommand.
+func NewSchemaApplyCommand(stdin io.Reader, stdout, stderr io.Writer) *SchemaApplyCommand {
+	return &SchemaApplyCommand{
+		CmdIO: pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// Run applies the schema file to the server.
+func (cmd *SchemaApplyCommand) Run(ctx context.Context) error {
+	// Validate arguments.
+	if cmd.Path == "" {
+		return errors.New("schema file required")
+	}
+
+	// Read schema definition.
+	var def pilosa.SchemaDefinition
+	if _, err := toml.DecodeFile(cmd.Path, &def); err != nil {
+		return err
+	}
+
+	// Create a client to the server.
+	client, err := pilosa.NewClient(cmd.Host)
+	if err != nil {
+		return err
+	}
+	if cmd.APIKey != "" {
+		client.SetAPIKey(cmd.APIKey)
+	}
+
+	changes, err := client.ApplySchema(ctx, &def, cmd.Destructive, cmd.Plan)
+	if len(changes) > 0 {
+		if err := cmd.printChanges(changes); err != nil {
+			return err
+		}
+	}
+	if err == pilosa.ErrSchemaChangeDestructive {
+		return fmt.Errorf("%s, use --destructive to apply", err)
+	}
+	return err
+}
+
+// printChanges writes a table of schema changes to stdout.
+func (cmd *SchemaApplyCommand) printChanges(changes []pilosa.SchemaChange) error {
+	tw := tabwriter.NewWriter(cmd.Stdout, 0, 8, 0, '\t', 0)
+	fmt.Fprintln(tw, "ACTION\tINDEX\tFRAME\tOPTION\tVALUE\tNOTE")
+	for _, c := range changes {
+		var note string
+		if c.Destructive {
+			note = "destructive"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", c.Action, c.Index, c.Frame, c.Option, c.Value, note)
+	}
+	return tw.Flush()
+}
@@ -22,7 +22,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"runtime"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -49,26 +52,86 @@ type Executor struct {
 
 	// Client used for remote HTTP requests.
 	HTTPClient *http.Client
+
+	// Cache of slice results for bitmap sub-expressions.
+	// Results are not cached if nil.
+	ResultCache *ResultCache
+
+	// Pool of goroutines shared by all queries to map local slices.
+	// Every batch of slices runs on its own goroutine if nil.
+	Workers *WorkerPool
+
+	// Maximum number of contiguous slices mapped by a worker at once.
+	SliceBatchSize int
+
+	// Limits on queries from clients. Queries sent by other nodes are not
+	// limited. A zero value disables a limit.
+	//
+	// Timeout only applies to queries without a deadline.
+	Timeout              time.Duration
+	MaxConcurrentQueries int
+	MaxResultBits        uint64
+
+	// Number of client queries currently executing.
+	queryN int32
 }
 
 // NewExecutor returns a new instance of Executor.
 func NewExecutor() *Executor {
 	return &Executor{
 		HTTPClient: http.DefaultClient,
+		Workers:    NewWorkerPool(runtime.NumCPU()),
+
+		SliceBatchSize: DefaultSliceBatchSize,
 	}
 }
 
 // Execute executes a PQL query.
 func (e *Executor) Execute(ctx context.Context, index string, q *pql.Query, slices []uint64, opt *ExecOptions) ([]interface{}, error) {
+	// Default options.
+	if opt == nil {
+		opt = &ExecOptions{}
+	}
+	if !opt.limited() {
+		return e.execute(ctx, index, q, slices, opt)
+	}
+
+	// Reject the query if too many are already executing.
+	if e.MaxConcurrentQueries > 0 {
+		defer atomic.AddInt32(&e.queryN, -1)
+		if n := atomic.AddInt32(&e.queryN, 1); int(n) > e.MaxConcurrentQueries {
+			return nil, ErrTooManyQueries
+		}
+	}
+
+	// Apply the default timeout if the caller hasn't set a deadline.
+	if _, ok := ctx.Deadline(); !ok && e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	results, err := e.execute(ctx, index, q, slices, opt)
+	if err != nil {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			return nil, ErrQueryTimeout
+		case context.Canceled:
+			return nil, ErrQueryCanceled
+		}
+	}
+	return results, err
+}
+
+// execute executes a PQL query without applying limits.
+func (e *Executor) execute(ctx context.Context, index string, q *pql.Query, slices []uint64, opt *ExecOptions) ([]interface{}, error) {
 	// Verify that an index is set.
 	if index == "" {
 		return nil, ErrIndexRequired
 	}
 
-	// Default options.
-	if opt == nil {
-		opt = &ExecOptions{}
-	}
+	// Resolve aliases once so the whole query runs against a single index.
+	index = e.Holder.ResolveAlias(index)
 
 	// Don't bother calculating slices for query types that don't require it.
 	needsSlices := needsSlices(q.Calls)
@@ -109,7 +172,7 @@ func (e *Executor) Execute(ctx context.Context, index string, q *pql.Query, slic
 	}
 
 	// Optimize handling for bulk attribute insertion.
-	if hasOnlySetRowAttrs(q.Calls) {
+	if hasOnlySetRowAttrs(q.Calls) && !opt.Explain {
 		return e.executeBulkSetRowAttrs(ctx, index, q.Calls, opt)
 	}
 
@@ -151,11 +214,22 @@ func (e *Executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 		return nil, err
 	}
 
+	// Return the execution plan instead of the result, if requested.
+	if opt.Explain {
+		return e.explainCall(ctx, index, c, slices, opt)
+	}
+
+	// Share attribute lookups between slices.
+	ctx = context.WithValue(ctx, attrFilterCacheKey{}, &attrFilterCache{ids: make(map[*pql.Call][]uint64)})
+
 	// Special handling for mutation and top-n calls.
 	switch c.Name {
 	case "ClearBit":
 		return e.executeClearBit(ctx, index, c, opt)
 	case "Count":
+		if opt.Sample > 0 {
+			return e.executeSampledCount(ctx, index, c, slices, opt)
+		}
 		return e.executeCount(ctx, index, c, slices, opt)
 	case "SetBit":
 		return e.executeSetBit(ctx, index, c, opt)
@@ -164,6 +238,9 @@ func (e *Executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 	case "SetColumnAttrs":
 		return nil, e.executeSetColumnAttrs(ctx, index, c, opt)
 	case "TopN":
+		if opt.Sample > 0 && !opt.Remote {
+			return e.executeSampledTopN(ctx, index, c, slices, opt)
+		}
 		return e.executeTopN(ctx, index, c, slices, opt)
 	default:
 		return e.executeBitmapCall(ctx, index, c, slices, opt)
@@ -197,13 +274,17 @@ func (e *Executor) executeBitmapCall(ctx context.Context, index string, c *pql.C
 	}
 
 	// Merge returned results at coordinating node.
-	reduceFn := func(prev, v interface{}) interface{} {
+	// Results returned to clients stop being reduced once they exceed the limit.
+	reduceFn := func(prev, v interface{}) (interface{}, error) {
 		other, _ := prev.(*Bitmap)
 		if other == nil {
 			other = NewBitmap()
 		}
 		other.Merge(v.(*Bitmap))
-		return other
+		if opt.limited() && e.MaxResultBits > 0 && other.Count() > e.MaxResultBits {
+			return nil, ErrTooManyResultBits
+		}
+		return other, nil
 	}
 
 	other, err := e.mapReduce(ctx, index, slices, c, opt, mapFn, reduceFn)
@@ -250,10 +331,79 @@ func (e *Executor) executeBitmapCall(ctx context.Context, index string, c *pql.C
 }
 
 // executeBitmapCallSlice executes a bitmap call for a single slice.
+// Results of cacheable calls are reused from the result cache until one of
+// the fragments they were computed from changes.
 func (e *Executor) executeBitmapCallSlice(ctx context.Context, index string, c *pql.Call, slice uint64) (*Bitmap, error) {
+	if e.ResultCache == nil {
+		return e.executeBitmapCallSliceUncached(ctx, index, c, slice)
+	}
+
+	// Leaf calls only record their fragments with the enclosing call.
+	parent, _ := ctx.Value(resultDepsKey{}).(*resultDeps)
+	if !cacheableCall(c) {
+		return e.executeBitmapCallSliceUncached(ctx, index, c, slice)
+	}
+
+	key := fmt.Sprintf("%s/%d/%s", index, slice, c.String())
+	if bm, deps, ok := e.ResultCache.get(e.Holder, key); ok {
+		parent.add(deps...)
+		return bm, nil
+	}
+
+	deps := &resultDeps{}
+	bm, err := e.executeBitmapCallSliceUncached(context.WithValue(ctx, resultDepsKey{}, deps), index, c, slice)
+	if err != nil {
+		return nil, err
+	}
+	parent.add(deps.a...)
+	return e.ResultCache.add(key, bm, deps.a), nil
+}
+
+// cacheableCall returns true if the result of c can be held in the result cache.
+// Calls depending on column attributes are never cached since attribute
+// changes are not tracked by fragment versions.
+func cacheableCall(c *pql.Call) bool {
+	switch c.Name {
+	case "Difference", "Intersect", "Range", "Union":
+	default:
+		return false
+	}
+	for _, child := range c.Children {
+		if child.Name == "ColumnAttr" || (len(child.Children) > 0 && !cacheableCall(child)) {
+			return false
+		}
+	}
+	return true
+}
+
+// fragment returns a fragment from the holder and records it as a
+// dependency of the bitmap call being executed, if any.
+func (e *Executor) fragment(ctx context.Context, index, frame, view string, slice uint64) *Fragment {
+	f := e.Holder.Fragment(index, frame, view, slice)
+
+	deps, _ := ctx.Value(resultDepsKey{}).(*resultDeps)
+	if deps == nil {
+		return f
+	}
+
+	// Read the version before the fragment is used so a concurrent write
+	// invalidates the result instead of being missed.
+	dep := resultDep{index: index, frame: frame, view: view, slice: slice}
+	if f != nil {
+		dep.version = f.Version()
+	}
+	deps.add(dep)
+	return f
+}
+
+// executeBitmapCallSliceUncached executes a bitmap call for a single slice
+// without consulting the result cache.
+func (e *Executor) executeBitmapCallSliceUncached(ctx context.Context, index string, c *pql.Call, slice uint64) (*Bitmap, error) {
 	switch c.Name {
 	case "Bitmap":
 		return e.executeBitmapSlice(ctx, index, c, slice)
+	case "ColumnAttr":
+		return e.executeColumnAttrSlice(ctx, index, c, slice)
 	case "Difference":
 		return e.executeDifferenceSlice(ctx, index, c, slice)
 	case "Intersect":
@@ -262,6 +412,8 @@ func (e *Executor) executeBitmapCallSlice(ctx context.Context, index string, c *
 		return e.executeRangeSlice(ctx, index, c, slice)
 	case "Union":
 		return e.executeUnionSlice(ctx, index, c, slice)
+	case "RowAttr":
+		return nil, errors.New("RowAttr() can only be used within TopN()")
 	default:
 		return nil, fmt.Errorf("unknown call: %s", c.Name)
 	}
@@ -271,6 +423,10 @@ func (e *Executor) executeBitmapCallSlice(ctx context.Context, index string, c *
 // This first performs the TopN() to determine the top results and then
 // requeries to retrieve the full counts for each of the top results.
 func (e *Executor) executeTopN(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) ([]Pair, error) {
+	if exact, _ := c.Args["exact"].(bool); exact {
+		return e.executeTopNExact(ctx, index, c, slices, opt)
+	}
+
 	rowIDs, _, err := c.UintSliceArg("ids")
 	if err != nil {
 		return nil, fmt.Errorf("executeTopN: %v", err)
@@ -309,6 +465,129 @@ func (e *Executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	return trimmedList, nil
 }
 
+// executeTopNExact executes a TopN(exact=true) call.
+//
+// Counts are read from storage rather than the rank cache. To keep the
+// coordinator from holding a count for every row, candidates are found in
+// passes similar to the three-phase uniform threshold algorithm:
+//
+//  1. Each slice and each node returns only its top n rows. The exact totals
+//     of these candidates give a lower bound, tau, for the nth total.
+//  2. Any row with a total of at least tau has a count of at least tau/m in
+//     one of the m slices, so each slice returns only rows above that count.
+//  3. The totals of the new candidates are counted and the top n returned.
+//
+// The threshold applies to the totals.
+func (e *Executor) executeTopNExact(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) ([]Pair, error) {
+	n, _, err := c.UintArg("n")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNExact: %v", err)
+	}
+	threshold, _, err := c.UintArg("threshold")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNExact: %v", err)
+	}
+
+	// Remote nodes run a single pass and trim their merged results to n.
+	// The coordinator sets n and the per-slice threshold for each pass.
+	if opt.Remote {
+		pairs, err := e.executeTopNSlices(ctx, index, c, slices, opt)
+		if err != nil {
+			return nil, err
+		}
+		return Pairs(pairs).Top(int(n)), nil
+	}
+
+	// The threshold is applied to totals so it is removed from slice passes.
+	other := c.Clone()
+	delete(other.Args, "threshold")
+
+	// Without a limit every row is returned so no candidates can be skipped.
+	if n == 0 {
+		totals, err := e.executeTopNSlices(ctx, index, other, slices, opt)
+		if err != nil {
+			return nil, err
+		}
+		return topNThreshold(totals, threshold, 0), nil
+	}
+
+	// Find the top n rows of each slice and count their totals.
+	pairs, err := e.executeTopNSlices(ctx, index, other, slices, opt)
+	if err != nil {
+		return nil, err
+	} else if len(pairs) == 0 {
+		return nil, nil
+	}
+	totals, err := e.executeTopNExactIDs(ctx, index, other, slices, opt, Pairs(pairs).Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	// Fewer than n candidates means every slice returned all of its rows.
+	if len(totals) < int(n) {
+		return topNThreshold(totals, threshold, n), nil
+	}
+
+	// Rows ranked below the nth candidate or the threshold are not needed.
+	tau := Pairs(totals).Top(int(n))[n-1].Count
+	if threshold > tau {
+		tau = threshold
+	}
+	m := uint64(len(slices))
+	if m == 0 {
+		m = 1
+	}
+	delete(other.Args, "n")
+	other.Args["threshold"] = (tau + m - 1) / m
+
+	// Find rows which may reach tau and count those not already counted.
+	pairs, err = e.executeTopNSlices(ctx, index, other, slices, opt)
+	if err != nil {
+		return nil, err
+	}
+	delete(other.Args, "threshold")
+
+	counted := make(map[uint64]struct{}, len(totals))
+	for _, pair := range totals {
+		counted[pair.ID] = struct{}{}
+	}
+	var ids []uint64
+	for _, pair := range pairs {
+		if _, ok := counted[pair.ID]; !ok {
+			ids = append(ids, pair.ID)
+		}
+	}
+	if len(ids) > 0 {
+		more, err := e.executeTopNExactIDs(ctx, index, other, slices, opt, ids)
+		if err != nil {
+			return nil, err
+		}
+		totals = append(totals, more...)
+	}
+
+	return topNThreshold(totals, threshold, n), nil
+}
+
+// executeTopNExactIDs returns the exact totals of the rows in ids.
+func (e *Executor) executeTopNExactIDs(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions, ids []uint64) ([]Pair, error) {
+	other := c.Clone()
+	delete(other.Args, "n")
+	sort.Sort(uint64Slice(ids))
+	other.Args["ids"] = ids
+	return e.executeTopNSlices(ctx, index, other, slices, opt)
+}
+
+// topNThreshold returns the top n pairs with a count of at least threshold.
+func topNThreshold(pairs []Pair, threshold, n uint64) []Pair {
+	results := make(Pairs, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.Count >= threshold {
+			results = append(results, pair)
+		}
+	}
+	return results.Top(int(n))
+}
+
 func (e *Executor) executeTopNSlices(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) ([]Pair, error) {
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(slice uint64) (interface{}, error) {
@@ -316,9 +595,9 @@ func (e *Executor) executeTopNSlices(ctx context.Context, index string, c *pql.C
 	}
 
 	// Merge returned results at coordinating node.
-	reduceFn := func(prev, v interface{}) interface{} {
+	reduceFn := func(prev, v interface{}) (interface{}, error) {
 		other, _ := prev.([]Pair)
-		return Pairs(other).Add(v.([]Pair))
+		return Pairs(other).Add(v.([]Pair)), nil
 	}
 
 	other, err := e.mapReduce(ctx, index, slices, c, opt, mapFn, reduceFn)
@@ -354,29 +633,59 @@ func (e *Executor) executeTopNSlice(ctx context.Context, index string, c *pql.Ca
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNSlice: %v", err)
 	}
+	exact, _ := c.Args["exact"].(bool)
+
+	// Exact counts use the per-slice threshold set by the coordinator.
+	if exact && tanimotoThreshold > 0 {
+		return nil, errors.New("TopN() cannot use tanimotoThreshold with exact")
+	}
+
+	// Set default frame.
+	if frame == "" {
+		frame = DefaultFrame
+	}
+
+	// Separate row attribute filters from the input bitmap.
+	var inputs, rowFilters []*pql.Call
+	for _, child := range c.Children {
+		if child.Name == "RowAttr" {
+			rowFilters = append(rowFilters, child)
+		} else {
+			inputs = append(inputs, child)
+		}
+	}
 
 	// Retrieve bitmap used to intersect.
 	var src *Bitmap
-	if len(c.Children) == 1 {
-		bm, err := e.executeBitmapCallSlice(ctx, index, c.Children[0], slice)
+	if len(inputs) == 1 {
+		bm, err := e.executeBitmapCallSlice(ctx, index, inputs[0], slice)
 		if err != nil {
 			return nil, err
 		}
 		src = bm
-	} else if len(c.Children) > 1 {
+	} else if len(inputs) > 1 {
 		return nil, errors.New("TopN() can only have one input bitmap")
 	}
 
-	// Set default frame.
-	if frame == "" {
-		frame = DefaultFrame
-	}
-
 	f := e.Holder.Fragment(index, frame, ViewStandard, slice)
 	if f == nil {
 		return nil, nil
 	}
 
+	// Find the rows matching the row attribute filter.
+	var filterRowIDs []uint64
+	if len(rowFilters) == 1 {
+		ids, err := e.attrFilterIDs(ctx, f.RowAttrStore, rowFilters[0])
+		if err != nil {
+			return nil, err
+		} else if len(ids) == 0 {
+			return nil, nil
+		}
+		filterRowIDs = ids
+	} else if len(rowFilters) > 1 {
+		return nil, errors.New("TopN() can only have one RowAttr() filter")
+	}
+
 	if minThreshold <= 0 {
 		minThreshold = MinThreshold
 	}
@@ -390,6 +699,8 @@ func (e *Executor) executeTopNSlice(ctx context.Context, index string, c *pql.Ca
 		RowIDs:            rowIDs,
 		FilterField:       field,
 		FilterValues:      filters,
+		FilterRowIDs:      filterRowIDs,
+		Exact:             exact,
 		MinThreshold:      minThreshold,
 		TanimotoThreshold: tanimotoThreshold,
 	})
@@ -418,21 +729,35 @@ func (e *Executor) executeDifferenceSlice(ctx context.Context, index string, c *
 }
 
 func (e *Executor) executeBitmapSlice(ctx context.Context, index string, c *pql.Call, slice uint64) (*Bitmap, error) {
+	frame, view, id, err := e.bitmapCallRow(index, c)
+	if err != nil {
+		return nil, err
+	}
+
+	frag := e.fragment(ctx, index, frame, view, slice)
+	if frag == nil {
+		return NewBitmap(), nil
+	}
+	return frag.Row(id), nil
+}
+
+// bitmapCallRow returns the frame, view and row id read by a Bitmap() call.
+func (e *Executor) bitmapCallRow(index string, c *pql.Call) (frame, view string, id uint64, err error) {
 	// Fetch column label from index.
 	idx := e.Holder.Index(index)
 	if idx == nil {
-		return nil, ErrIndexNotFound
+		return "", "", 0, ErrIndexNotFound
 	}
 	columnLabel := idx.ColumnLabel()
 
 	// Fetch frame & row label based on argument.
-	frame, _ := c.Args["frame"].(string)
+	frame, _ = c.Args["frame"].(string)
 	if frame == "" {
 		frame = DefaultFrame
 	}
 	f := e.Holder.Frame(index, frame)
 	if f == nil {
-		return nil, ErrFrameNotFound
+		return "", "", 0, ErrFrameNotFound
 	}
 	rowLabel := f.RowLabel()
 
@@ -440,28 +765,79 @@ func (e *Executor) executeBitmapSlice(ctx context.Context, index string, c *pql.
 	rowID, rowOK, rowErr := c.UintArg(rowLabel)
 	columnID, columnOK, columnErr := c.UintArg(columnLabel)
 	if rowErr != nil || columnErr != nil {
-		return nil, fmt.Errorf("Bitmap() error with arg for col: %v or row: %v", columnErr, rowErr)
+		return "", "", 0, fmt.Errorf("Bitmap() error with arg for col: %v or row: %v", columnErr, rowErr)
 	}
 	if rowOK && columnOK {
-		return nil, fmt.Errorf("Bitmap() cannot specify both %s and %s values", rowLabel, columnLabel)
+		return "", "", 0, fmt.Errorf("Bitmap() cannot specify both %s and %s values", rowLabel, columnLabel)
 	} else if !rowOK && !columnOK {
-		return nil, fmt.Errorf("Bitmap() must specify either %s or %s values", rowLabel, columnLabel)
+		return "", "", 0, fmt.Errorf("Bitmap() must specify either %s or %s values", rowLabel, columnLabel)
 	}
 
 	// Determine row or column orientation.
-	view, id := ViewStandard, rowID
 	if columnOK {
-		view, id = ViewInverse, columnID
 		if !f.InverseEnabled() {
-			return nil, fmt.Errorf("Bitmap() cannot retrieve columns unless inverse storage enabled")
+			return "", "", 0, fmt.Errorf("Bitmap() cannot retrieve columns unless inverse storage enabled")
 		}
+		return frame, ViewInverse, columnID, nil
 	}
+	return frame, ViewStandard, rowID, nil
+}
 
-	frag := e.Holder.Fragment(index, frame, view, slice)
-	if frag == nil {
-		return NewBitmap(), nil
+// executeColumnAttrSlice executes a ColumnAttr() call for a local slice.
+// The bitmap contains the columns in the slice whose attributes match
+// every argument.
+func (e *Executor) executeColumnAttrSlice(ctx context.Context, index string, c *pql.Call, slice uint64) (*Bitmap, error) {
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return nil, ErrIndexNotFound
 	}
-	return frag.Row(id), nil
+
+	ids, err := e.attrFilterIDs(ctx, idx.ColumnAttrStore(), c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the matching ids within the slice.
+	bm := NewBitmap()
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= slice*SliceWidth })
+	for ; i < len(ids) && ids[i] < (slice+1)*SliceWidth; i++ {
+		bm.SetBit(ids[i])
+	}
+	return bm, nil
+}
+
+// attrFilterIDs returns the ids in store matching the arguments of c.
+// Results are shared by all slices of a call through ctx.
+func (e *Executor) attrFilterIDs(ctx context.Context, store *AttrStore, c *pql.Call) ([]uint64, error) {
+	if len(c.Args) == 0 {
+		return nil, fmt.Errorf("%s() requires at least one condition", c.Name)
+	}
+
+	cache, _ := ctx.Value(attrFilterCacheKey{}).(*attrFilterCache)
+	if cache == nil {
+		return store.Find(c.Args)
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if ids, ok := cache.ids[c]; ok {
+		return ids, nil
+	}
+	ids, err := store.Find(c.Args)
+	if err != nil {
+		return nil, err
+	}
+	cache.ids[c] = ids
+	return ids, nil
+}
+
+// attrFilterCacheKey is the context key for an attrFilterCache.
+type attrFilterCacheKey struct{}
+
+// attrFilterCache holds attribute filter results for the duration of a call.
+type attrFilterCache struct {
+	mu  sync.Mutex
+	ids map[*pql.Call][]uint64
 }
 
 // executeIntersectSlice executes a intersect() call for a local slice.
@@ -488,8 +864,28 @@ func (e *Executor) executeIntersectSlice(ctx context.Context, index string, c *p
 
 // executeRangeSlice executes a range() call for a local slice.
 func (e *Executor) executeRangeSlice(ctx context.Context, index string, c *pql.Call, slice uint64) (*Bitmap, error) {
+	frame, rowID, views, err := e.rangeCallViews(ctx, index, c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Union bitmaps across all time-based subframes.
+	bm := &Bitmap{}
+	for _, view := range views {
+		f := e.fragment(ctx, index, frame, view, slice)
+		if f == nil {
+			continue
+		}
+		bm = bm.Union(f.Row(rowID))
+	}
+	return bm, nil
+}
+
+// rangeCallViews returns the frame, row id and time views read by a Range() call.
+// No views are returned if the frame has no time quantum.
+func (e *Executor) rangeCallViews(ctx context.Context, index string, c *pql.Call) (frame string, rowID uint64, views []string, err error) {
 	// Parse frame, use default if unset.
-	frame, _ := c.Args["frame"].(string)
+	frame, _ = c.Args["frame"].(string)
 	if frame == "" {
 		frame = DefaultFrame
 	}
@@ -497,52 +893,48 @@ func (e *Executor) executeRangeSlice(ctx context.Context, index string, c *pql.C
 	// Retrieve base frame.
 	f := e.Holder.Frame(index, frame)
 	if f == nil {
-		return nil, ErrFrameNotFound
+		return "", 0, nil, ErrFrameNotFound
 	}
 	rowLabel := f.RowLabel()
 
 	// Read row id.
-	rowID, _, err := c.UintArg(rowLabel) // TODO: why are we ignoring missing rowID?
+	rowID, _, err = c.UintArg(rowLabel) // TODO: why are we ignoring missing rowID?
 	if err != nil {
-		return nil, fmt.Errorf("executeRangeSlice - reading row: %v", err)
+		return "", 0, nil, fmt.Errorf("executeRangeSlice - reading row: %v", err)
 	}
 
 	// Parse start time.
 	startTimeStr, ok := c.Args["start"].(string)
 	if !ok {
-		return nil, errors.New("Range() start time required")
+		return "", 0, nil, errors.New("Range() start time required")
 	}
 	startTime, err := time.Parse(TimeFormat, startTimeStr)
 	if err != nil {
-		return nil, errors.New("cannot parse Range() start time")
+		return "", 0, nil, errors.New("cannot parse Range() start time")
 	}
 
 	// Parse end time.
 	endTimeStr, _ := c.Args["end"].(string)
 	if !ok {
-		return nil, errors.New("Range() end time required")
+		return "", 0, nil, errors.New("Range() end time required")
 	}
 	endTime, err := time.Parse(TimeFormat, endTimeStr)
 	if err != nil {
-		return nil, errors.New("cannot parse Range() end time")
+		return "", 0, nil, errors.New("cannot parse Range() end time")
 	}
 
-	// If no quantum exists then return an empty bitmap.
+	// The views read depend on the quantum so cached results must be
+	// discarded if it changes.
 	q := f.TimeQuantum()
-	if q == "" {
-		return &Bitmap{}, nil
+	if deps, _ := ctx.Value(resultDepsKey{}).(*resultDeps); deps != nil {
+		deps.add(resultDep{index: index, frame: frame, quantum: q})
 	}
 
-	// Union bitmaps across all time-based subframes.
-	bm := &Bitmap{}
-	for _, view := range ViewsByTimeRange(ViewStandard, startTime, endTime, q) {
-		f := e.Holder.Fragment(index, frame, view, slice)
-		if f == nil {
-			continue
-		}
-		bm = bm.Union(f.Row(rowID))
+	// If no quantum exists then no views are read.
+	if q == "" {
+		return frame, rowID, nil, nil
 	}
-	return bm, nil
+	return frame, rowID, ViewsByTimeRange(ViewStandard, startTime, endTime, q), nil
 }
 
 // executeUnionSlice executes a union() call for a local slice.
@@ -582,9 +974,9 @@ func (e *Executor) executeCount(ctx context.Context, index string, c *pql.Call,
 	}
 
 	// Merge returned results at coordinating node.
-	reduceFn := func(prev, v interface{}) interface{} {
+	reduceFn := func(prev, v interface{}) (interface{}, error) {
 		other, _ := prev.(uint64)
-		return other + v.(uint64)
+		return other + v.(uint64), nil
 	}
 
 	result, err := e.mapReduce(ctx, index, slices, c, opt, mapFn, reduceFn)
@@ -1001,9 +1393,11 @@ func (e *Executor) executeSetColumnAttrs(ctx context.Context, index string, c *p
 func (e *Executor) exec(ctx context.Context, node *Node, index string, q *pql.Query, slices []uint64, opt *ExecOptions) (results []interface{}, err error) {
 	// Encode request object.
 	pbreq := &internal.QueryRequest{
-		Query:  q.String(),
-		Slices: slices,
-		Remote: true,
+		Query:   q.String(),
+		Slices:  slices,
+		Remote:  true,
+		Sample:  opt.Sample,
+		Explain: opt.Explain,
 	}
 	buf, err := proto.Marshal(pbreq)
 	if err != nil {
@@ -1012,7 +1406,7 @@ func (e *Executor) exec(ctx context.Context, node *Node, index string, q *pql.Qu
 
 	// Create HTTP request.
 	req, err := http.NewRequest("POST", (&url.URL{
-		Scheme: "http",
+		Scheme: e.Cluster.Scheme(),
 		Host:   node.Host,
 		Path:   fmt.Sprintf("/index/%s/query", index),
 	}).String(), bytes.NewReader(buf))
@@ -1020,6 +1414,9 @@ func (e *Executor) exec(ctx context.Context, node *Node, index string, q *pql.Qu
 		return nil, err
 	}
 
+	// Abort the request if the query is cancelled.
+	req = req.WithContext(ctx)
+
 	// Require protobuf encoding.
 	req.Header.Set("Accept", "application/x-protobuf")
 	req.Header.Set("Content-Type", "application/x-protobuf")
@@ -1053,33 +1450,45 @@ func (e *Executor) exec(ctx context.Context, node *Node, index string, q *pql.Qu
 		return nil, err
 	}
 
-	// Return appropriate data for the query.
-	results = make([]interface{}, len(q.Calls))
+	return decodeQueryResults(q, &pb), nil
+}
+
+// decodeQueryResults returns the results of pb based on the calls in q.
+func decodeQueryResults(q *pql.Query, pb *internal.QueryResponse) []interface{} {
+	results := make([]interface{}, len(q.Calls))
 	for i, call := range q.Calls {
 		var v interface{}
-		var err error
+
+		// Plans are returned in place of results when explaining a query.
+		if plan := pb.Results[i].GetPlan(); plan != nil {
+			results[i] = decodeQueryPlan(plan)
+			continue
+		}
 
 		switch call.Name {
 		case "TopN":
-			v, err = decodePairs(pb.Results[i].GetPairs()), nil
+			v = decodePairs(pb.Results[i].GetPairs())
 		case "Count":
-			v, err = pb.Results[i].N, nil
+			if counts := pb.Results[i].SliceCounts; len(counts) > 0 {
+				v = sliceCounts(counts)
+			} else if est := pb.Results[i].GetEstimate(); est != nil {
+				v = decodeEstimate(est)
+			} else {
+				v = pb.Results[i].N
+			}
 		case "SetBit":
-			v, err = pb.Results[i].Changed, nil
+			v = pb.Results[i].Changed
 		case "ClearBit":
-			v, err = pb.Results[i].Changed, nil
+			v = pb.Results[i].Changed
 		case "SetRowAttrs":
 		case "SetColumnAttrs":
 		default:
-			v, err = decodeBitmap(pb.Results[i].GetBitmap()), nil
-		}
-		if err != nil {
-			return nil, err
+			v = decodeBitmap(pb.Results[i].GetBitmap())
 		}
 
 		results[i] = v
 	}
-	return results, nil
+	return results
 }
 
 // slicesByNode returns a mapping of nodes to slices.
@@ -1135,6 +1544,11 @@ func (e *Executor) mapReduce(ctx context.Context, index string, slices []uint64,
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		case resp := <-ch:
+			// Stop if the results can't be reduced. Retrying won't help.
+			if err, ok := resp.err.(reduceError); ok {
+				return nil, err.err
+			}
+
 			// On error retry against remaining nodes. If an error returns then
 			// the context will cancel and cause all open goroutines to return.
 			if resp.err != nil {
@@ -1151,7 +1565,10 @@ func (e *Executor) mapReduce(ctx context.Context, index string, slices []uint64,
 			}
 
 			// Reduce value.
-			result = reduceFn(result, resp.result)
+			var err error
+			if result, err = reduceFn(result, resp.result); err != nil {
+				return nil, err
+			}
 
 			// If all slices have been processed then return.
 			maxSlice += len(resp.slices)
@@ -1170,6 +1587,7 @@ func (e *Executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Nod
 	}
 
 	// Execute each node in a separate goroutine.
+	timings, _ := ctx.Value(planTimingsKey{}).(*planTimings)
 	for n, nodeSlices := range m {
 		go func(n *Node, nodeSlices []uint64) {
 			resp := mapResponse{node: n, slices: nodeSlices}
@@ -1178,12 +1596,13 @@ func (e *Executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Nod
 			if n.Host == e.Host {
 				resp.result, resp.err = e.mapperLocal(ctx, nodeSlices, mapFn, reduceFn)
 			} else if !opt.Remote {
-
+				t := time.Now()
 				results, err := e.exec(ctx, n, index, &pql.Query{Calls: []*pql.Call{c}}, nodeSlices, opt)
 				if len(results) > 0 {
 					resp.result = results[0]
 				}
 				resp.err = err
+				timings.add(n.Host, nodeSlices, time.Since(t))
 			}
 
 			// Return response to the channel.
@@ -1198,20 +1617,50 @@ func (e *Executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Nod
 }
 
 // mapperLocal performs map & reduce entirely on the local node.
+//
+// Slices are mapped in batches of contiguous slices by the executor's worker
+// pool so that neighbouring fragments are read by the same goroutine. The
+// remaining batches are skipped once a slice or reduction fails.
 func (e *Executor) mapperLocal(ctx context.Context, slices []uint64, mapFn mapFunc, reduceFn reduceFunc) (interface{}, error) {
+	if len(slices) == 0 {
+		return nil, nil
+	}
+
+	// Cancel batches which haven't finished on exit.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffer every response so workers never block on sending.
 	ch := make(chan mapResponse, len(slices))
 
-	for _, slice := range slices {
-		go func(slice uint64) {
-			result, err := mapFn(slice)
+	// Submit batches in a separate goroutine so responses are reduced while
+	// waiting for workers.
+	timings, _ := ctx.Value(planTimingsKey{}).(*planTimings)
+	go func() {
+		for _, batch := range e.sliceBatches(slices) {
+			// Stop submitting batches once the query is cancelled.
+			if ctx.Err() != nil {
+				return
+			}
 
-			// Return response to the channel.
-			select {
-			case <-ctx.Done():
-			case ch <- mapResponse{result: result, err: err}:
+			batch := batch
+			if err := e.Workers.Go(ctx, func() {
+				for _, slice := range batch {
+					// Skip remaining slices once the query is cancelled.
+					if ctx.Err() != nil {
+						return
+					}
+
+					t := time.Now()
+					result, err := mapFn(slice)
+					timings.add(e.Host, []uint64{slice}, time.Since(t))
+					ch <- mapResponse{result: result, err: err}
+				}
+			}); err != nil {
+				return
 			}
-		}(slice)
-	}
+		}
+	}()
 
 	// Reduce results
 	var maxSlice int
@@ -1224,7 +1673,10 @@ func (e *Executor) mapperLocal(ctx context.Context, slices []uint64, mapFn mapFu
 			if resp.err != nil {
 				return nil, resp.err
 			}
-			result = reduceFn(result, resp.result)
+			var err error
+			if result, err = reduceFn(result, resp.result); err != nil {
+				return nil, reduceError{err}
+			}
 			maxSlice++
 		}
 
@@ -1235,12 +1687,92 @@ func (e *Executor) mapperLocal(ctx context.Context, slices []uint64, mapFn mapFu
 	}
 }
 
+// sliceBatches splits slices, in order, into fixed chunks which are each
+// mapped sequentially by one worker. Chunks are sized to spread the slices
+// evenly across the workers but hold at most SliceBatchSize slices.
+func (e *Executor) sliceBatches(slices []uint64) [][]uint64 {
+	size := len(slices)
+	if n := e.Workers.Size(); n > 0 {
+		size = (len(slices) + n - 1) / n
+	}
+	if e.SliceBatchSize > 0 && size > e.SliceBatchSize {
+		size = e.SliceBatchSize
+	}
+
+	batches := make([][]uint64, 0, (len(slices)+size-1)/size)
+	for len(slices) > 0 {
+		n := size
+		if n > len(slices) {
+			n = len(slices)
+		}
+		batches = append(batches, slices[:n])
+		slices = slices[n:]
+	}
+	return batches
+}
+
+// DefaultSliceBatchSize is the default maximum number of slices mapped by a
+// worker at once.
+const DefaultSliceBatchSize = 16
+
+// reduceError wraps an error returned while reducing results.
+type reduceError struct {
+	err error
+}
+
+func (e reduceError) Error() string { return e.err.Error() }
+
+// WorkerPool bounds the number of goroutines mapping slices across all queries.
+type WorkerPool struct {
+	sem chan struct{}
+}
+
+// NewWorkerPool returns a new instance of WorkerPool which runs at most n goroutines.
+func NewWorkerPool(n int) *WorkerPool {
+	return &WorkerPool{sem: make(chan struct{}, n)}
+}
+
+// Size returns the maximum number of goroutines run by the pool.
+// Returns zero for a nil pool.
+func (p *WorkerPool) Size() int {
+	if p == nil {
+		return 0
+	}
+	return cap(p.sem)
+}
+
+// Go runs fn on a new goroutine once a worker is available. Returns the
+// context's error if it is done first. A nil pool runs fn immediately.
+func (p *WorkerPool) Go(ctx context.Context, fn func()) error {
+	// Check the context first since select picks randomly between ready cases.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if p == nil {
+		go fn()
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.sem <- struct{}{}:
+	}
+
+	go func() {
+		defer func() { <-p.sem }()
+		fn()
+	}()
+	return nil
+}
+
 // errSliceUnavailable is a marker error if no nodes are available.
 var errSliceUnavailable = errors.New("slice unavailable")
 
 type mapFunc func(slice uint64) (interface{}, error)
 
-type reduceFunc func(prev, v interface{}) interface{}
+type reduceFunc func(prev, v interface{}) (interface{}, error)
 
 type mapResponse struct {
 	node   *Node
@@ -1253,6 +1785,24 @@ type mapResponse struct {
 // ExecOptions represents an execution context for a single Execute() call.
 type ExecOptions struct {
 	Remote bool
+
+	// Set if a remote query isn't signed by another node. Client limits
+	// still apply to such queries.
+	Unverified bool
+
+	// Return a QueryPlan for each call instead of its result.
+	// Calls are only executed if Analyze is also set.
+	Explain bool
+	Analyze bool
+
+	// Fraction of slices evaluated by Count() and TopN() calls. Results are
+	// scaled up to all slices. Sampling is disabled if zero.
+	Sample float64
+}
+
+// limited returns true if client query limits apply.
+func (opt *ExecOptions) limited() bool {
+	return !opt.Remote || opt.Unverified
 }
 
 // decodeError returns an error representation of s if s is non-blank.
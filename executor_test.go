@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pilosa/pilosa"
@@ -142,6 +143,241 @@ func TestExecutor_Execute_Intersect(t *testing.T) {
 	}
 }
 
+// Ensure cached intersect results are reused until a fragment changes.
+func TestExecutor_Execute_ResultCache(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(10, 1, 2)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 1).MustSetBits(10, SliceWidth+1)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(11, 1)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 1).MustSetBits(11, SliceWidth+1)
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	e.ResultCache = pilosa.NewResultCache(pilosa.DefaultResultCacheSize)
+
+	q := `Intersect(Bitmap(rowID=10), Bitmap(rowID=11))`
+	for i := 0; i < 2; i++ {
+		if res, err := e.Execute(context.Background(), "i", MustParse(q), nil, nil); err != nil {
+			t.Fatal(err)
+		} else if bits := res[0].(*pilosa.Bitmap).Bits(); !reflect.DeepEqual(bits, []uint64{1, SliceWidth + 1}) {
+			t.Fatalf("unexpected bits(%d): %+v", i, bits)
+		} else if n := e.ResultCache.Len(); n != 2 {
+			t.Fatalf("unexpected cache entries(%d): %d", i, n)
+		}
+	}
+
+	// Changing a contributing fragment invalidates its slice's result.
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(11, 2)
+	if res, err := e.Execute(context.Background(), "i", MustParse(q), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if bits := res[0].(*pilosa.Bitmap).Bits(); !reflect.DeepEqual(bits, []uint64{1, 2, SliceWidth + 1}) {
+		t.Fatalf("unexpected bits after update: %+v", bits)
+	} else {
+		// Changing a result does not change the cached copy.
+		res[0].(*pilosa.Bitmap).SetBit(3)
+	}
+	if res, err := e.Execute(context.Background(), "i", MustParse(q), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if bits := res[0].(*pilosa.Bitmap).Bits(); !reflect.DeepEqual(bits, []uint64{1, 2, SliceWidth + 1}) {
+		t.Fatalf("unexpected bits after changing result: %+v", bits)
+	}
+
+	// Recreated fragments invalidate results computed from the old ones.
+	if err := hldr.DeleteIndex("i"); err != nil {
+		t.Fatal(err)
+	}
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(10, 5)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(11, 5)
+	if res, err := e.Execute(context.Background(), "i", MustParse(q), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if bits := res[0].(*pilosa.Bitmap).Bits(); !reflect.DeepEqual(bits, []uint64{5}) {
+		t.Fatalf("unexpected bits after recreate: %+v", bits)
+	}
+}
+
+// Ensure a query plan estimates the fragments and containers read by each call.
+func TestExecutor_Explain(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(10, 1, 70000)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 1).MustSetBits(10, SliceWidth+1)
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(11, 1)
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	q := MustParse(`Count(Intersect(Bitmap(rowID=10), Bitmap(rowID=11)))`)
+	plans, err := e.Explain(context.Background(), "i", q, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(plans) != 1 {
+		t.Fatalf("unexpected plan count: %d", len(plans))
+	}
+
+	plan := plans[0]
+	if plan.Call != `Count(Intersect(Bitmap(rowID=10), Bitmap(rowID=11)))` {
+		t.Fatalf("unexpected call: %s", plan.Call)
+	} else if !reflect.DeepEqual(plan.Nodes, []*pilosa.PlanNode{{Host: e.Host, Slices: []uint64{0, 1}}}) {
+		t.Fatalf("unexpected nodes: %+v", plan.Nodes)
+	} else if plan.FragmentN != 4 || plan.ContainerN != 4 {
+		t.Fatalf("unexpected estimates: fragments=%d, containers=%d", plan.FragmentN, plan.ContainerN)
+	} else if row := plan.Children[0].Children[0]; row.FragmentN != 2 || row.ContainerN != 3 {
+		t.Fatalf("unexpected row estimates: fragments=%d, containers=%d", row.FragmentN, row.ContainerN)
+	} else if plan.Timings != nil {
+		t.Fatalf("unexpected timings: %+v", plan.Timings)
+	}
+
+	// Analyzing the query times each slice.
+	plans, err = e.Explain(context.Background(), "i", q, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	} else if timings := plans[0].Timings; len(timings) != 2 {
+		t.Fatalf("unexpected timings: %+v", timings)
+	} else if !reflect.DeepEqual(timings[0].Slices, []uint64{0}) || !reflect.DeepEqual(timings[1].Slices, []uint64{1}) {
+		t.Fatalf("unexpected timing slices: %+v", timings)
+	}
+}
+
+// Ensure client queries are limited by result size and execution time.
+func TestExecutor_Execute_Limits(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(10, 1, 2, 3)
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	e.MaxResultBits = 2
+	if _, err := e.Execute(context.Background(), "i", MustParse(`Bitmap(rowID=10)`), nil, nil); err != pilosa.ErrTooManyResultBits {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Counts and remote queries aren't limited by result size.
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if res[0] != uint64(3) {
+		t.Fatalf("unexpected count: %v", res[0])
+	} else if _, err := e.Execute(context.Background(), "i", MustParse(`Bitmap(rowID=10)`), []uint64{0}, &pilosa.ExecOptions{Remote: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queries running past their deadline return a timeout.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	e.MaxResultBits = 0
+	if _, err := e.Execute(ctx, "i", MustParse(`Bitmap(rowID=10)`), nil, nil); err != pilosa.ErrQueryTimeout {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Queries cancelled by the client are reported separately from timeouts.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := e.Execute(ctx, "i", MustParse(`Bitmap(rowID=10)`), nil, nil); err != pilosa.ErrQueryCanceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure slices are mapped in batches by a bounded worker pool.
+func TestExecutor_Execute_Workers(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	for slice := uint64(0); slice < 40; slice++ {
+		hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, slice).MustSetBits(10, slice*SliceWidth+1)
+	}
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	e.Workers = pilosa.NewWorkerPool(2)
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if res[0] != uint64(40) {
+		t.Fatalf("unexpected count: %v", res[0])
+	}
+
+	// Batches smaller than an even split across the workers map every slice.
+	e.SliceBatchSize = 3
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if res[0] != uint64(40) {
+		t.Fatalf("unexpected count: %v", res[0])
+	}
+
+	// Reductions stop early once they fail.
+	e.MaxResultBits = 10
+	if _, err := e.Execute(context.Background(), "i", MustParse(`Bitmap(rowID=10)`), nil, nil); err != pilosa.ErrTooManyResultBits {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure a worker pool stops waiting for a worker once the context is done.
+func TestWorkerPool_Go(t *testing.T) {
+	p := pilosa.NewWorkerPool(1)
+
+	release := make(chan struct{})
+	if err := p.Go(context.Background(), func() { <-release }); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Go(ctx, func() { t.Error("unexpected run") }); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Work is never started once the context is done, even with a free worker.
+	if err := (*pilosa.WorkerPool)(nil).Go(ctx, func() { t.Error("unexpected run") }); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(release)
+	done := make(chan struct{})
+	if err := p.Go(context.Background(), func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
+
+// Ensure sampled queries evaluate a subset of slices and scale the results.
+func TestExecutor_Execute_Sample(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	for slice := uint64(0); slice < 40; slice++ {
+		for i := uint64(0); i <= slice%4; i++ {
+			hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, slice).MustSetBits(10, slice*SliceWidth+i)
+		}
+		hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, slice).MustSetBits(20, slice*SliceWidth)
+	}
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+
+	// A full sample is exact.
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, &pilosa.ExecOptions{Sample: 1}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(res[0], &pilosa.Estimate{Value: 100, Lower: 100, Upper: 100, SliceN: 40, SampleN: 40}) {
+		t.Fatalf("unexpected estimate: %#v", res[0])
+	}
+
+	// A partial sample returns a confidence interval around the estimate.
+	res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, &pilosa.ExecOptions{Sample: 0.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	est := res[0].(*pilosa.Estimate)
+	if est.SliceN != 40 || est.SampleN != 10 {
+		t.Fatalf("unexpected sample size: %#v", est)
+	} else if !(est.Lower < est.Value && est.Value < est.Upper) || est.Lower > 100 || est.Upper < 100 {
+		t.Fatalf("unexpected estimate: %#v", est)
+	}
+
+	// The same slices are sampled by every query.
+	if other, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10))`), nil, &pilosa.ExecOptions{Sample: 0.25}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(other[0], est) {
+		t.Fatalf("unexpected estimate: %#v", other[0])
+	}
+
+	// TopN counts are scaled up to all slices.
+	if res, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame=general, ids=[20])`), nil, &pilosa.ExecOptions{Sample: 0.5}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(res[0], []pilosa.Pair{{ID: 20, Count: 40}}) {
+		t.Fatalf("unexpected pairs: %+v", res[0])
+	}
+}
+
 // Ensure an empty intersect query behaves properly.
 func TestExecutor_Execute_Empty_Intersect(t *testing.T) {
 	hldr := MustOpenHolder()
@@ -433,6 +669,217 @@ func TestExecutor_Execute_TopN_Attr_Src(t *testing.T) {
 
 }
 
+// Ensure TopN can count every row instead of relying on the rank cache.
+func TestExecutor_Execute_TopN_Exact(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	if _, err := index.CreateFrameIfNotExists("f", pilosa.FrameOptions{CacheType: pilosa.CacheTypeRanked, CacheSize: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Row 1 leads each slice but row 2 has the highest total.
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).MustSetBits(1, 0, 1, 2)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).MustSetBits(2, 0, 1)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 1).MustSetBits(2, SliceWidth, SliceWidth+1)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 2).MustSetBits(2, 2*SliceWidth, 2*SliceWidth+1)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 2).MustSetBits(3, 2*SliceWidth)
+	for slice := uint64(0); slice < 3; slice++ {
+		hldr.Fragment("i", "f", pilosa.ViewStandard, slice).RecalculateCache()
+	}
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame="f", n=2, exact=true)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 2, Count: 6},
+		{ID: 1, Count: 3},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	// The threshold applies to the totals.
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame="f", exact=true, threshold=4)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 2, Count: 6},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	// Counts are intersected with the source bitmap.
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(Bitmap(rowID=1, frame="f"), frame="f", n=5, exact=true)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 1, Count: 3},
+		{ID: 2, Count: 2},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+}
+
+// Ensure exact TopN finds rows which are not in the top of any slice.
+func TestExecutor_Execute_TopN_Exact_Candidates(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	if _, err := index.CreateFrameIfNotExists("f", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each slice is led by a different row with three bits while row 1 has
+	// two bits in every slice.
+	for slice := uint64(0); slice < 4; slice++ {
+		base := slice * SliceWidth
+		frag := hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, slice)
+		frag.MustSetBits(1, base, base+1)
+		frag.MustSetBits(10+slice, base, base+1, base+2)
+	}
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame="f", n=1, exact=true)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 1, Count: 8},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+}
+
+// Ensure TopN ranks rows within the window of a window cache.
+func TestExecutor_Execute_TopN_Window(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	if _, err := index.CreateFrameIfNotExists("f", pilosa.FrameOptions{
+		CacheType:   pilosa.CacheTypeWindow,
+		CacheWindow: pilosa.Duration(24 * time.Hour),
+		TimeQuantum: "YMDH",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Row 2 has the most bits but only row 1 has bits within the window.
+	now, old := time.Now().UTC(), time.Now().UTC().Add(-72*time.Hour)
+	f := hldr.Frame("i", "f")
+	for _, bit := range []struct {
+		rowID, columnID uint64
+		t               time.Time
+	}{
+		{1, 1, now},
+		{1, SliceWidth + 1, now},
+		{2, 1, old},
+		{2, 2, old},
+		{2, 3, old},
+	} {
+		if _, err := f.SetBit(pilosa.ViewStandard, bit.rowID, bit.columnID, &bit.t); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Window caches are recounted in the background.
+	hldr.RefreshWindowCaches(now)
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame="f", n=2)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 1, Count: 2},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	// Exact counts include every bit in storage.
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(frame="f", n=2, exact=true)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 2, Count: 3},
+		{ID: 1, Count: 2},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+}
+
+// Ensure TopN can filter rows by row attribute conditions.
+func TestExecutor_Execute_TopN_RowAttr(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(0, 0)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(0, 1)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(10, 2)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(10, 3)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 1).SetBit(20, SliceWidth)
+
+	if err := hldr.Frame("i", "f").RowAttrStore().SetBulkAttrs(map[uint64]map[string]interface{}{
+		0:  {"price": int64(5), "category": "a"},
+		10: {"price": int64(15), "category": "b"},
+		20: {"price": 20.5, "category": "ab"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(RowAttr(price>10), frame="f", n=5)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 10, Count: 2},
+		{ID: 20, Count: 1},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(Bitmap(rowID=0, frame="f"), RowAttr(category^="a"), frame="f", n=5)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result, []interface{}{[]pilosa.Pair{
+		{ID: 0, Count: 2},
+	}}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	if result, err := e.Execute(context.Background(), "i", MustParse(`TopN(RowAttr(category="z"), frame="f", n=5)`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if pairs := result[0].([]pilosa.Pair); len(pairs) != 0 {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+}
+
+// Ensure columns can be filtered by column attribute conditions.
+func TestExecutor_Execute_ColumnAttr(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(1, 3)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).SetBit(1, 4)
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 1).SetBit(1, SliceWidth+1)
+
+	if err := hldr.Index("i").ColumnAttrStore().SetBulkAttrs(map[uint64]map[string]interface{}{
+		3:              {"country": "US", "age": int64(20)},
+		4:              {"country": "CA", "age": int64(30)},
+		SliceWidth + 1: {"country": "US", "age": int64(40)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Intersect(Bitmap(rowID=1, frame="f"), ColumnAttr(country="US"))`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if bits := res[0].(*pilosa.Bitmap).Bits(); !reflect.DeepEqual(bits, []uint64{3, SliceWidth + 1}) {
+		t.Fatalf("unexpected bits: %+v", bits)
+	}
+
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(ColumnAttr(age>=30))`), nil, nil); err != nil {
+		t.Fatal(err)
+	} else if res[0] != uint64(2) {
+		t.Fatalf("unexpected count: %v", res[0])
+	}
+
+	if _, err := e.Execute(context.Background(), "i", MustParse(`ColumnAttr()`), nil, nil); err == nil || err.Error() != "ColumnAttr() requires at least one condition" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Ensure a range query can be executed.
 func TestExecutor_Execute_Range(t *testing.T) {
 	hldr := MustOpenHolder()
@@ -539,6 +986,87 @@ func TestExecutor_Execute_Remote_Count(t *testing.T) {
 	}
 }
 
+// Ensure a sampled count combines the slice counts of each node.
+func TestExecutor_Execute_Remote_Sample(t *testing.T) {
+	c := NewCluster(2)
+
+	// Create secondary server backed by its own executor.
+	s := NewServer()
+	defer s.Close()
+	c.Nodes[1].Host = s.Host()
+
+	hldr0, hldr1 := MustOpenHolder(), MustOpenHolder()
+	defer hldr0.Close()
+	defer hldr1.Close()
+	for slice := uint64(0); slice < 8; slice++ {
+		for _, hldr := range []*Holder{hldr0, hldr1} {
+			hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, slice).MustSetBits(10, slice*SliceWidth, slice*SliceWidth+1)
+		}
+	}
+
+	remote := NewExecutor(hldr1.Holder, c)
+	remote.Host = c.Nodes[1].Host
+	s.Handler.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if opt.Sample != 1 {
+			t.Errorf("unexpected sample: %v", opt.Sample)
+		}
+		return remote.Execute(ctx, index, query, slices, opt)
+	}
+
+	e := NewExecutor(hldr0.Holder, c)
+	if res, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10, frame=f))`), []uint64{0, 1, 2, 3, 4, 5, 6, 7}, &pilosa.ExecOptions{Sample: 1}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(res[0], &pilosa.Estimate{Value: 16, Lower: 16, Upper: 16, SliceN: 8, SampleN: 8}) {
+		t.Fatalf("unexpected estimate: %#v", res[0])
+	}
+
+	// Nodes which return a plain count can't be sampled.
+	s.Handler.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return []interface{}{uint64(4)}, nil
+	}
+	if _, err := e.Execute(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10, frame=f))`), []uint64{0, 1, 2, 3, 4, 5, 6, 7}, &pilosa.ExecOptions{Sample: 1}); err == nil || err.Error() != "remote node does not support sampling" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure a query plan includes estimates from the nodes that slices are mapped to.
+func TestExecutor_Explain_Remote(t *testing.T) {
+	c := NewCluster(2)
+
+	// Create secondary server backed by its own executor.
+	s := NewServer()
+	defer s.Close()
+	c.Nodes[1].Host = s.Host()
+
+	hldr0, hldr1 := MustOpenHolder(), MustOpenHolder()
+	defer hldr0.Close()
+	defer hldr1.Close()
+	for slice := uint64(0); slice < 8; slice++ {
+		for _, hldr := range []*Holder{hldr0, hldr1} {
+			hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, slice).MustSetBits(10, slice*SliceWidth)
+		}
+	}
+
+	remote := NewExecutor(hldr1.Holder, c)
+	remote.Host = c.Nodes[1].Host
+	s.Handler.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if !opt.Explain || !opt.Remote {
+			t.Errorf("unexpected options: %+v", opt)
+		}
+		return remote.Execute(ctx, index, query, slices, opt)
+	}
+
+	e := NewExecutor(hldr0.Holder, c)
+	plans, err := e.Explain(context.Background(), "i", MustParse(`Count(Bitmap(rowID=10, frame=f))`), []uint64{0, 1, 2, 3, 4, 5, 6, 7}, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if p := plans[0]; p.FragmentN != 8 || p.ContainerN != 8 || len(p.Nodes) != 2 {
+		t.Fatalf("unexpected plan: %+v", p)
+	} else if p := plans[0].Children[0]; p.FragmentN != 8 || p.ContainerN != 8 {
+		t.Fatalf("unexpected child plan: %+v", p)
+	}
+}
+
 // Ensure a remote query can set bits on multiple nodes.
 func TestExecutor_Execute_Remote_SetBit(t *testing.T) {
 	c := NewCluster(2)
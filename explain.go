@@ -0,0 +1,291 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// QueryPlan describes how a call is executed.
+type QueryPlan struct {
+	// The call being executed.
+	Call string `json:"call"`
+
+	// Slices mapped to each node. Only set on top-level calls.
+	Nodes []*PlanNode `json:"nodes,omitempty"`
+
+	// Number of fragments and storage containers read by the call and its
+	// children on every node the call is mapped to.
+	FragmentN  int `json:"fragments"`
+	ContainerN int `json:"containers"`
+
+	Children []*QueryPlan `json:"children,omitempty"`
+
+	// Execution time of the call and of each mapped set of slices.
+	// Only set on top-level calls when the query is analyzed.
+	Duration Duration     `json:"duration,omitempty"`
+	Timings  []PlanTiming `json:"timings,omitempty"`
+}
+
+// PlanNode represents the slices mapped to a node.
+type PlanNode struct {
+	Host   string   `json:"host"`
+	Slices []uint64 `json:"slices"`
+}
+
+// PlanTiming represents the time taken by a node to execute a set of slices.
+// Local slices are timed individually; remote nodes are timed per request.
+type PlanTiming struct {
+	Host     string   `json:"host"`
+	Slices   []uint64 `json:"slices"`
+	Duration Duration `json:"duration"`
+}
+
+// Explain returns the execution plan for each call in a PQL query.
+// If analyze is true then the calls are also executed and timed.
+func (e *Executor) Explain(ctx context.Context, index string, q *pql.Query, slices []uint64, analyze bool) ([]*QueryPlan, error) {
+	results, err := e.Execute(ctx, index, q, slices, &ExecOptions{Explain: true, Analyze: analyze})
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*QueryPlan, len(results))
+	for i, result := range results {
+		plans[i] = result.(*QueryPlan)
+	}
+	return plans, nil
+}
+
+// explainCall returns the plan for a top-level call. Estimates for slices
+// mapped to other nodes are requested from those nodes.
+func (e *Executor) explainCall(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) (*QueryPlan, error) {
+	// Write calls are sent to the owners of their column instead of being mapped.
+	if isWriteCall(c) {
+		return e.planCall(index, c, slices)
+	}
+
+	nodes := e.Cluster.Nodes
+	if opt.Remote {
+		nodes = []*Node{e.Cluster.NodeByHost(e.Host)}
+	}
+	m, err := e.slicesByNode(nodes, index, slices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only estimate the slices mapped to this node locally.
+	var localSlices []uint64
+	for node, nodeSlices := range m {
+		if node.Host == e.Host {
+			localSlices = nodeSlices
+		}
+	}
+	plan, err := e.planCall(index, c, localSlices)
+	if err != nil {
+		return nil, err
+	}
+
+	for node, nodeSlices := range m {
+		plan.Nodes = append(plan.Nodes, &PlanNode{Host: node.Host, Slices: nodeSlices})
+		if node.Host == e.Host {
+			continue
+		}
+
+		results, err := e.exec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nodeSlices, &ExecOptions{Remote: true, Explain: true})
+		if err != nil {
+			return nil, err
+		}
+		other, ok := results[0].(*QueryPlan)
+		if !ok {
+			return nil, fmt.Errorf("unexpected plan from %s: %T", node.Host, results[0])
+		}
+		plan.addEstimates(other)
+	}
+	sort.Sort(planNodes(plan.Nodes))
+
+	// Execute the call while recording the time taken by each map.
+	if opt.Analyze {
+		timings := &planTimings{}
+		t := time.Now()
+		if _, err := e.executeCall(context.WithValue(ctx, planTimingsKey{}, timings), index, c, slices, &ExecOptions{Remote: opt.Remote}); err != nil {
+			return nil, err
+		}
+		plan.Duration = Duration(time.Since(t))
+		plan.Timings = timings.sorted()
+	}
+
+	return plan, nil
+}
+
+// addEstimates adds the estimates of other, a plan of the same call from
+// another node, to p and its children.
+func (p *QueryPlan) addEstimates(other *QueryPlan) {
+	p.FragmentN += other.FragmentN
+	p.ContainerN += other.ContainerN
+	for i := range p.Children {
+		if i < len(other.Children) {
+			p.Children[i].addEstimates(other.Children[i])
+		}
+	}
+}
+
+// planCall returns the plan for c and its children with estimated reads.
+func (e *Executor) planCall(index string, c *pql.Call, slices []uint64) (*QueryPlan, error) {
+	plan := &QueryPlan{Call: c.String()}
+
+	switch c.Name {
+	case "Bitmap":
+		frame, view, id, err := e.bitmapCallRow(index, c)
+		if err != nil {
+			return nil, err
+		}
+		plan.addRow(e.Holder, index, frame, view, id, slices)
+
+	case "Range":
+		frame, rowID, views, err := e.rangeCallViews(context.Background(), index, c)
+		if err != nil {
+			return nil, err
+		}
+		for _, view := range views {
+			plan.addRow(e.Holder, index, frame, view, rowID, slices)
+		}
+
+	case "TopN":
+		frame, _ := c.Args["frame"].(string)
+		if frame == "" {
+			frame = DefaultFrame
+		}
+		for _, slice := range slices {
+			if f := e.Holder.Fragment(index, frame, ViewStandard, slice); f != nil {
+				plan.FragmentN++
+				plan.ContainerN += f.ContainerN()
+			}
+		}
+	}
+
+	for _, child := range c.Children {
+		p, err := e.planCall(index, child, slices)
+		if err != nil {
+			return nil, err
+		}
+		plan.Children = append(plan.Children, p)
+		plan.FragmentN += p.FragmentN
+		plan.ContainerN += p.ContainerN
+	}
+
+	return plan, nil
+}
+
+// addRow adds the fragments and containers holding a row to the estimates.
+func (p *QueryPlan) addRow(holder *Holder, index, frame, view string, rowID uint64, slices []uint64) {
+	for _, slice := range slices {
+		if f := holder.Fragment(index, frame, view, slice); f != nil {
+			p.FragmentN++
+			p.ContainerN += f.RowContainerN(rowID)
+		}
+	}
+}
+
+// planNodes sorts plan nodes by host.
+type planNodes []*PlanNode
+
+func (p planNodes) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p planNodes) Len() int           { return len(p) }
+func (p planNodes) Less(i, j int) bool { return p[i].Host < p[j].Host }
+
+// planTimingsKey is the context key for a planTimings.
+type planTimingsKey struct{}
+
+// planTimings collects the map timings of an analyzed call.
+type planTimings struct {
+	mu sync.Mutex
+	a  []PlanTiming
+}
+
+// add records the time taken by host to execute slices. Safe to call on a nil receiver.
+func (t *planTimings) add(host string, slices []uint64, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.a = append(t.a, PlanTiming{Host: host, Slices: slices, Duration: Duration(d)})
+}
+
+// sorted returns the timings ordered by host and first slice.
+func (t *planTimings) sorted() []PlanTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a := make(planTimingSlice, len(t.a))
+	copy(a, t.a)
+	sort.Sort(a)
+	return a
+}
+
+type planTimingSlice []PlanTiming
+
+func (p planTimingSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p planTimingSlice) Len() int      { return len(p) }
+func (p planTimingSlice) Less(i, j int) bool {
+	if p[i].Host != p[j].Host {
+		return p[i].Host < p[j].Host
+	}
+	return len(p[i].Slices) > 0 && len(p[j].Slices) > 0 && p[i].Slices[0] < p[j].Slices[0]
+}
+
+func encodeQueryPlan(p *QueryPlan) *internal.QueryPlan {
+	pb := &internal.QueryPlan{
+		Call:       p.Call,
+		FragmentN:  uint64(p.FragmentN),
+		ContainerN: uint64(p.ContainerN),
+		Duration:   int64(p.Duration),
+	}
+	for _, node := range p.Nodes {
+		pb.Nodes = append(pb.Nodes, &internal.PlanNode{Host: node.Host, Slices: node.Slices})
+	}
+	for _, child := range p.Children {
+		pb.Children = append(pb.Children, encodeQueryPlan(child))
+	}
+	for _, t := range p.Timings {
+		pb.Timings = append(pb.Timings, &internal.PlanTiming{Host: t.Host, Slices: t.Slices, Duration: int64(t.Duration)})
+	}
+	return pb
+}
+
+func decodeQueryPlan(pb *internal.QueryPlan) *QueryPlan {
+	p := &QueryPlan{
+		Call:       pb.Call,
+		FragmentN:  int(pb.FragmentN),
+		ContainerN: int(pb.ContainerN),
+		Duration:   Duration(pb.Duration),
+	}
+	for _, node := range pb.Nodes {
+		p.Nodes = append(p.Nodes, &PlanNode{Host: node.Host, Slices: node.Slices})
+	}
+	for _, child := range pb.Children {
+		p.Children = append(p.Children, decodeQueryPlan(child))
+	}
+	for _, t := range pb.Timings {
+		p.Timings = append(p.Timings, PlanTiming{Host: t.Host, Slices: t.Slices, Duration: Duration(t.Duration)})
+	}
+	return p
+}
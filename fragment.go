@@ -31,6 +31,7 @@ import (
 	"os"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"unsafe"
@@ -81,10 +82,15 @@ type Fragment struct {
 	storageData []byte
 	opN         int // number of ops since snapshot
 
+	// Assigned a new version whenever the contents of storage change.
+	version uint64
+
 	// Cache for row counts.
-	cacheType string // passed in by frame
-	cache     Cache
-	cacheSize uint32
+	cacheType     string // passed in by frame
+	cache         Cache
+	cacheSize     uint32
+	cacheInterval time.Duration
+	cacheHalfLife time.Duration
 
 	// Cache containing full rows (not just counts).
 	rowCache BitmapCache
@@ -104,6 +110,10 @@ type Fragment struct {
 	// This is set by the parent frame unless overridden for testing.
 	RowAttrStore *AttrStore
 
+	// Memory budget shared with the row caches of other fragments.
+	// This is set by the parent view unless overridden for testing.
+	RowCache *RowCache
+
 	stats StatsClient
 }
 
@@ -120,6 +130,7 @@ func NewFragment(path, index, frame, view string, slice uint64) *Fragment {
 
 		LogOutput: ioutil.Discard,
 		MaxOpN:    DefaultFragmentMaxOpN,
+		RowCache:  NewRowCache(DefaultRowCacheSize),
 
 		stats: NopStatsClient,
 	}
@@ -143,6 +154,62 @@ func (f *Fragment) View() string { return f.view }
 // Slice returns the slice the fragment was initialized with.
 func (f *Fragment) Slice() uint64 { return f.slice }
 
+// Stats returns the bit count and on-disk size of the fragment.
+func (f *Fragment) Stats() (FragmentStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := FragmentStats{Slice: f.slice, BitN: f.storage.Count()}
+	if fi, err := os.Stat(f.path); err == nil {
+		stats.Size = fi.Size()
+	} else if !os.IsNotExist(err) {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// FragmentStats represents statistics for a fragment on the local node.
+type FragmentStats struct {
+	Slice uint64 `json:"slice"`
+	BitN  uint64 `json:"bitCount"`
+	Size  int64  `json:"size"`
+}
+
+type fragmentStatsSlice []FragmentStats
+
+func (p fragmentStatsSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p fragmentStatsSlice) Len() int           { return len(p) }
+func (p fragmentStatsSlice) Less(i, j int) bool { return p[i].Slice < p[j].Slice }
+
+// fragmentVersion is the last version assigned to any fragment. Versions are
+// unique across fragments so a recreated fragment never reuses a version.
+var fragmentVersion uint64
+
+// nextFragmentVersion returns a new fragment version. Never returns zero.
+func nextFragmentVersion() uint64 { return atomic.AddUint64(&fragmentVersion, 1) }
+
+// Version returns a number that changes whenever the fragment's bits change.
+// Versions are never reused, even by other fragments.
+func (f *Fragment) Version() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.version
+}
+
+// ContainerN returns the number of storage containers in the fragment.
+func (f *Fragment) ContainerN() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.storage.ContainerCount()
+}
+
+// RowContainerN returns the number of storage containers holding a row.
+func (f *Fragment) RowContainerN(rowID uint64) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.storage.ContainerCountRange(rowID*SliceWidth, (rowID+1)*SliceWidth)
+}
+
 // Cache returns the fragment's cache.
 // This is not safe for concurrent use.
 func (f *Fragment) Cache() Cache { return f.cache }
@@ -227,52 +294,82 @@ func (f *Fragment) openStorage() error {
 
 	// Attach the file to the bitmap to act as a write-ahead log.
 	f.storage.OpWriter = f.file
-	f.rowCache = &SimpleCache{make(map[uint64]*Bitmap)}
+	f.rowCache = f.RowCache.NewBitmapCache()
+	f.version = nextFragmentVersion()
 
 	return nil
 
 }
 
-// openCache initializes the cache from row ids persisted to disk.
+// openCache initializes the cache from row counts persisted to disk.
+//
+// Rows changed by ops written after the cache was flushed are recounted from
+// storage. If the cache file is missing, unreadable or older than the last
+// snapshot then the cache is rebuilt from every row in storage.
 func (f *Fragment) openCache() error {
 	// Determine cache type from frame name.
-	switch f.cacheType {
-	case CacheTypeRanked:
-		f.cache = NewRankCache(f.cacheSize)
-	case CacheTypeLRU:
-		f.cache = NewLRUCache(f.cacheSize)
-	default:
-		return ErrInvalidCacheType
+	cache, err := newCache(f.cacheType, f.cacheSize, f.cacheInterval, f.cacheHalfLife)
+	if err != nil {
+		return err
 	}
+	f.cache = cache
 
 	// Read cache data from disk.
 	path := f.CachePath()
-	buf, err := ioutil.ReadFile(path)
+	cf, err := ReadCacheFile(path)
 	if os.IsNotExist(err) {
+		f.fillCache(f.cache)
 		return nil
 	} else if err != nil {
-		return fmt.Errorf("open cache: %s", err)
+		f.logger().Printf("error reading cache data, rebuilding: path=%s, err=%s", path, err)
+		f.fillCache(f.cache)
+		return nil
 	}
 
-	// Unmarshal cache data.
-	var pb internal.Cache
-	if err := proto.Unmarshal(buf, &pb); err != nil {
-		log.Printf("error unmarshaling cache data, skipping: path=%s, err=%s", path, err)
+	// Cache files written without counts only hold the row ids.
+	if !cf.HasCounts() {
+		for _, id := range cf.IDs {
+			n := f.row(id, true, true).Count()
+			f.cache.BulkAdd(id, n)
+		}
+		f.cache.Invalidate()
 		return nil
 	}
 
-	// Read in all rows by ID.
-	// This will cause them to be added to the cache.
-	for _, id := range pb.IDs {
-		//n := f.storage.CountRange(id*SliceWidth, (id+1)*SliceWidth)
-		n := f.row(id, true, true).Count()
-		f.cache.BulkAdd(id, n)
+	// Find the rows changed since the cache was flushed.
+	changed, err := cf.changedRows(f.storage, f.storageData)
+	if err != nil {
+		f.logger().Printf("cache is out of date, rebuilding: path=%s, err=%s", path, err)
+		f.fillCache(f.cache)
+		return nil
+	}
+
+	// Load persisted counts and then recount the changed rows.
+	for i, id := range cf.IDs {
+		if _, ok := changed[id]; !ok {
+			f.cache.BulkAdd(id, cf.Counts[i])
+		}
+	}
+	for id := range changed {
+		f.cache.BulkAdd(id, f.storage.CountRange(id*SliceWidth, (id+1)*SliceWidth))
 	}
 	f.cache.Invalidate()
 
 	return nil
 }
 
+// fillCache adds the count of every row in storage to cache.
+func (f *Fragment) fillCache(cache Cache) {
+	// Add each row in storage, skipping to the next row after the first bit.
+	itr := f.storage.Iterator()
+	for v, eof := itr.Next(); !eof; v, eof = itr.Next() {
+		rowID := v / SliceWidth
+		cache.BulkAdd(rowID, f.storage.CountRange(rowID*SliceWidth, (rowID+1)*SliceWidth))
+		itr.Seek((rowID + 1) * SliceWidth)
+	}
+	cache.Invalidate()
+}
+
 // Close flushes the underlying storage, closes the file and unlocks it.
 func (f *Fragment) Close() error {
 	f.mu.Lock()
@@ -301,6 +398,11 @@ func (f *Fragment) closeStorage() error {
 	// Clear the storage bitmap so it doesn't access the closed mmap.
 	f.storage = roaring.NewBitmap()
 
+	// Release cached rows from the shared budget.
+	if f.rowCache != nil {
+		f.rowCache.Clear()
+	}
+
 	// Unmap the file.
 	if f.storageData != nil {
 		if err := syscall.Munmap(f.storageData); err != nil {
@@ -395,6 +497,8 @@ func (f *Fragment) setBit(rowID, columnID uint64) (changed bool, err error) {
 	// Invalidate block checksum.
 	delete(f.checksums, int(rowID/HashBlockSize))
 
+	f.version = nextFragmentVersion()
+
 	// Increment number of operations until snapshot is required.
 	if err := f.incrementOpN(); err != nil {
 		return false, err
@@ -404,6 +508,9 @@ func (f *Fragment) setBit(rowID, columnID uint64) (changed bool, err error) {
 	bm := f.row(rowID, true, true)
 	bm.SetBit(columnID)
 
+	// Re-add the row so the cache accounts for its new size.
+	f.rowCache.Add(rowID, bm)
+
 	// Update the cache.
 	f.cache.Add(rowID, bm.Count())
 
@@ -441,6 +548,8 @@ func (f *Fragment) clearBit(rowID, columnID uint64) (changed bool, err error) {
 	// Invalidate block checksum.
 	delete(f.checksums, int(rowID/HashBlockSize))
 
+	f.version = nextFragmentVersion()
+
 	// Increment number of operations until snapshot is required.
 	if err := f.incrementOpN(); err != nil {
 		return false, err
@@ -450,6 +559,9 @@ func (f *Fragment) clearBit(rowID, columnID uint64) (changed bool, err error) {
 	bm := f.row(rowID, true, true)
 	bm.ClearBit(columnID)
 
+	// Re-add the row so the cache accounts for its new size.
+	f.rowCache.Add(rowID, bm)
+
 	// Update the cache.
 	f.cache.Add(rowID, bm.Count())
 
@@ -490,15 +602,33 @@ func (f *Fragment) ForEachBit(fn func(rowID, columnID uint64) error) error {
 // Top returns the top rows from the fragment.
 // If opt.Src is specified then only rows which intersect src are returned.
 // If opt.FilterValues exist then the row attribute specified by field is matched.
+//
+// If opt.Exact is set then every row in storage is considered instead of the
+// rows in the cache, and all rows with a non-zero count are returned.
+//
+// Window and decay caches return windowed or decayed counts. If opt.Src is
+// specified then those caches only select the candidate rows and the counts
+// returned are intersections with the full row.
 func (f *Fragment) Top(opt TopOptions) ([]Pair, error) {
 	// Retrieve pairs. If no row ids specified then return from cache.
-	pairs := f.topBitmapPairs(opt.RowIDs)
+	var pairs []BitmapPair
+	if opt.Exact {
+		pairs = f.storageBitmapPairs(opt.RowIDs)
+	} else {
+		pairs = f.topBitmapPairs(opt.RowIDs)
+	}
 
 	// If row ids are provided, we don't want to truncate the result set
 	if len(opt.RowIDs) > 0 {
 		opt.N = 0
 	}
 
+	// Windowed and decayed counts are not an upper bound of the intersection
+	// count so every cached row must be intersected with the source bitmap.
+	if opt.Src != nil && !opt.Exact && !countsRows(f.cache) {
+		opt.N = 0
+	}
+
 	// Create a fast lookup of filter values.
 	var filters map[interface{}]struct{}
 	if opt.FilterField != "" && len(opt.FilterValues) > 0 {
@@ -542,6 +672,13 @@ func (f *Fragment) Top(opt TopOptions) ([]Pair, error) {
 			}
 		}
 
+		// Apply row id filter, if set.
+		if opt.FilterRowIDs != nil {
+			if i := sort.Search(len(opt.FilterRowIDs), func(i int) bool { return opt.FilterRowIDs[i] >= rowID }); i == len(opt.FilterRowIDs) || opt.FilterRowIDs[i] != rowID {
+				continue
+			}
+		}
+
 		// Apply filter, if set.
 		if filters != nil {
 			attr, err := f.RowAttrStore.Attrs(rowID)
@@ -643,6 +780,9 @@ func (f *Fragment) topBitmapPairs(rowIDs []uint64) []BitmapPair {
 				Count: n,
 			})
 			continue
+		} else if !countsRows(f.cache) {
+			// Windowed and decayed counts are only available from the cache.
+			continue
 		}
 
 		bm := f.Row(rowID)
@@ -658,6 +798,32 @@ func (f *Fragment) topBitmapPairs(rowIDs []uint64) []BitmapPair {
 	return pairs
 }
 
+// storageBitmapPairs returns the counts of rowIDs, or of every row if rowIDs
+// is empty, read from storage rather than the cache.
+func (f *Fragment) storageBitmapPairs(rowIDs []uint64) []BitmapPair {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var pairs []BitmapPair
+	if len(rowIDs) > 0 {
+		for _, rowID := range rowIDs {
+			if n := f.storage.CountRange(rowID*SliceWidth, (rowID+1)*SliceWidth); n > 0 {
+				pairs = append(pairs, BitmapPair{ID: rowID, Count: n})
+			}
+		}
+	} else {
+		// Count each row in storage, skipping to the next row after the first bit.
+		itr := f.storage.Iterator()
+		for v, eof := itr.Next(); !eof; v, eof = itr.Next() {
+			rowID := v / SliceWidth
+			pairs = append(pairs, BitmapPair{ID: rowID, Count: f.storage.CountRange(rowID*SliceWidth, (rowID+1)*SliceWidth)})
+			itr.Seek((rowID + 1) * SliceWidth)
+		}
+	}
+	sort.Sort(BitmapPairs(pairs))
+	return pairs
+}
+
 // TopOptions represents options passed into the Top() function.
 type TopOptions struct {
 	// Number of rows to return.
@@ -674,6 +840,12 @@ type TopOptions struct {
 	FilterField       string
 	FilterValues      []interface{}
 	TanimotoThreshold uint64
+
+	// Sorted row ids allowed in the results, if set.
+	FilterRowIDs []uint64
+
+	// Scan all rows in storage instead of the cache.
+	Exact bool
 }
 
 // Checksum returns a checksum for the entire fragment.
@@ -800,6 +972,16 @@ func (f *Fragment) BlockData(id int) (rowIDs, columnIDs []uint64) {
 // cleared bit then the bit is considered cleared. The function returns the
 // diff per incoming block so that all can be in sync.
 func (f *Fragment) MergeBlock(id int, data []PairSet) (sets, clears []PairSet, err error) {
+	sets, clears, err = f.mergeBlock(id, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sets[1:], clears[1:], nil
+}
+
+// mergeBlock merges the block and returns the diffs for all blocks.
+// The first set & clear diff is the set applied to the local block.
+func (f *Fragment) mergeBlock(id int, data []PairSet) (sets, clears []PairSet, err error) {
 	// Ensure that all pair sets are of equal length.
 	for i := range data {
 		if len(data[i].RowIDs) != len(data[i].ColumnIDs) {
@@ -916,7 +1098,7 @@ func (f *Fragment) MergeBlock(id int, data []PairSet) (sets, clears []PairSet, e
 		}
 	}
 
-	return sets[1:], clears[1:], nil
+	return sets, clears, nil
 }
 
 // Import bulk imports a set of bits and then snapshots the storage.
@@ -1040,6 +1222,13 @@ func (f *Fragment) snapshot() error {
 		return fmt.Errorf("close storage: %s", err)
 	}
 
+	// Remove the cache file as its ops log position will no longer be valid.
+	// The fragment rebuilds its cache on open if a crash occurs before the
+	// cache is flushed again.
+	if err := os.Remove(f.CachePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove cache: %s", err)
+	}
+
 	// Move snapshot to data file location.
 	if err := os.Rename(snapshotPath, f.path); err != nil {
 		return fmt.Errorf("rename snapshot: %s", err)
@@ -1053,6 +1242,11 @@ func (f *Fragment) snapshot() error {
 	// Reset operation count.
 	f.opN = 0
 
+	// Flush the cache for the new ops log.
+	if err := f.flushCache(); err != nil {
+		return fmt.Errorf("flush cache: %s", err)
+	}
+
 	return nil
 }
 
@@ -1063,6 +1257,78 @@ func (f *Fragment) RecalculateCache() {
 	f.mu.Unlock()
 }
 
+// ResetCache replaces the row count cache with a new cache of the given
+// settings and fills it with the count of every row in storage.
+func (f *Fragment) ResetCache(cacheType string, cacheSize uint32, cacheInterval, cacheHalfLife time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cache, err := newCache(cacheType, cacheSize, cacheInterval, cacheHalfLife)
+	if err != nil {
+		return err
+	}
+	f.fillCache(cache)
+
+	f.cacheType, f.cacheSize, f.cache = cacheType, cacheSize, cache
+	f.cacheInterval, f.cacheHalfLife = cacheInterval, cacheHalfLife
+	return f.flushCache()
+}
+
+// VerifyCache recounts every row in storage and corrects any cached counts
+// which have drifted from storage. Rows above the cache threshold which are
+// missing from the cache are added. Returns the number of rows corrected.
+//
+// Only ranked caches are verified. LRU caches always hold the latest count of
+// their rows while window and decay caches don't hold row counts.
+func (f *Fragment) VerifyCache() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.cache.(*RankCache)
+	if !ok {
+		return 0
+	}
+
+	// Count each row in storage, skipping to the next row after the first bit.
+	counts := make(map[uint64]uint64)
+	itr := f.storage.Iterator()
+	for v, eof := itr.Next(); !eof; v, eof = itr.Next() {
+		rowID := v / SliceWidth
+		counts[rowID] = f.storage.CountRange(rowID*SliceWidth, (rowID+1)*SliceWidth)
+		itr.Seek((rowID + 1) * SliceWidth)
+	}
+
+	var n int
+	for _, id := range c.IDs() {
+		if cnt := counts[id]; c.Get(id) != cnt {
+			c.BulkAdd(id, cnt)
+			n++
+		}
+		delete(counts, id)
+	}
+	for id, cnt := range counts {
+		if cnt > c.threshold() {
+			c.BulkAdd(id, cnt)
+			n++
+		}
+	}
+
+	if n > 0 {
+		c.Recalculate()
+		f.stats.Count("cacheDrift", int64(n))
+	}
+	return n
+}
+
+// setWindowCounts replaces the counts of the fragment's window cache, if any.
+func (f *Fragment) setWindowCounts(pairs []BitmapPair) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.cache.(*WindowCache); ok {
+		c.SetCounts(pairs)
+	}
+}
+
 // FlushCache writes the cache data to disk.
 func (f *Fragment) FlushCache() error {
 	f.mu.Lock()
@@ -1070,22 +1336,47 @@ func (f *Fragment) FlushCache() error {
 	return f.flushCache()
 }
 
+// flushCache writes the row ids and counts of the cache to disk.
+//
+// The counts are written along with the position of the ops log they reflect
+// so that later ops can be replayed when the fragment is reopened. The file
+// is written to a temporary path and then renamed so a crash cannot leave a
+// partially written cache.
 func (f *Fragment) flushCache() error {
 	if f.cache == nil {
 		return nil
 	}
 
-	// Retrieve a list of row ids from the cache.
-	ids := f.cache.IDs()
+	// Retrieve a list of row ids and counts from the cache.
+	pb := &internal.Cache{
+		IDs: f.cache.IDs(),
+		OpN: uint64(f.storage.OpN()),
+	}
+	if countsRows(f.cache) {
+		pb.Counts = cacheCounts(f.cache, pb.IDs)
+	}
 
 	// Marshal cache data to bytes.
-	buf, err := proto.Marshal(&internal.Cache{IDs: ids})
+	buf, err := proto.Marshal(pb)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and move it into place.
+	path := f.CachePath() + SnapshotExt
+	file, err := os.Create(path)
 	if err != nil {
 		return err
 	}
+	defer file.Close()
 
-	// Write to disk.
-	if err := ioutil.WriteFile(f.CachePath(), buf, 0666); err != nil {
+	if _, err := file.Write(buf); err != nil {
+		return err
+	} else if err := file.Sync(); err != nil {
+		return err
+	} else if err := file.Close(); err != nil {
+		return err
+	} else if err := os.Rename(path, f.CachePath()); err != nil {
 		return err
 	}
 
@@ -1305,6 +1596,9 @@ type FragmentSyncer struct {
 	Cluster *Cluster
 
 	Closing <-chan struct{}
+
+	// Optional statistics which are incremented during the sync.
+	Stats *SyncStats
 }
 
 // isClosing returns true if the closing channel is closed.
@@ -1320,11 +1614,16 @@ func (s *FragmentSyncer) isClosing() bool {
 // SyncFragment compares checksums for the local and remote fragments and
 // then merges any blocks which have differences.
 func (s *FragmentSyncer) SyncFragment() error {
+	if s.Stats == nil {
+		s.Stats = &SyncStats{}
+	}
+
 	// Determine replica set.
 	nodes := s.Cluster.FragmentNodes(s.Fragment.Index(), s.Fragment.Slice())
 	if len(nodes) == 1 {
 		return nil
 	}
+	s.Stats.FragmentsChecked++
 
 	// Create a set of blocks.
 	blockSets := make([][]FragmentBlock, 0, len(nodes))
@@ -1337,7 +1636,7 @@ func (s *FragmentSyncer) SyncFragment() error {
 		}
 
 		// Retrieve remote blocks.
-		client, err := NewClient(node.Host)
+		client, err := s.Cluster.NodeClient(node.Host)
 		if err != nil {
 			return err
 		}
@@ -1390,6 +1689,7 @@ func (s *FragmentSyncer) SyncFragment() error {
 		}
 
 		// Synchronize block.
+		s.Stats.BlocksMismatched++
 		if err := s.syncBlock(blockID); err != nil {
 			return fmt.Errorf("sync block: id=%d, err=%s", blockID, err)
 		}
@@ -1416,7 +1716,7 @@ func (s *FragmentSyncer) syncBlock(id int) error {
 			return nil
 		}
 
-		client, err := NewClient(node.Host)
+		client, err := s.Cluster.NodeClient(node.Host)
 		if err != nil {
 			return err
 		}
@@ -1440,14 +1740,20 @@ func (s *FragmentSyncer) syncBlock(id int) error {
 	}
 
 	// Merge blocks together.
-	sets, clears, err := f.MergeBlock(id, pairSets)
+	sets, clears, err := f.mergeBlock(id, pairSets)
 	if err != nil {
 		return err
 	}
 
+	// Record the local and remote changes.
+	for i := range sets {
+		s.Stats.BitsSet += len(sets[i].ColumnIDs)
+		s.Stats.BitsCleared += len(clears[i].ColumnIDs)
+	}
+
 	// Write updates to remote blocks.
 	for i := 0; i < len(clients); i++ {
-		set, clear := sets[i], clears[i]
+		set, clear := sets[i+1], clears[i+1]
 
 		// Ignore if there are no differences.
 		if len(set.ColumnIDs) == 0 && len(clear.ColumnIDs) == 0 {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/roaring"
 )
 
 // Test flags
@@ -356,6 +357,26 @@ func TestFragment_TopN_CacheSize(t *testing.T) {
 	} else if !reflect.DeepEqual(pairs, p) {
 		t.Fatalf("Invalid TopN result set: %s", spew.Sdump(pairs))
 	}
+
+	// Exact results include rows outside of the cache.
+	if pairs, err := f.Top(pilosa.TopOptions{N: 5, Exact: true}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(pairs, append(p,
+		pilosa.Pair{ID: 101, Count: 4},
+		pilosa.Pair{ID: 100, Count: 3},
+	)) {
+		t.Fatalf("unexpected exact result set: %s", spew.Sdump(pairs))
+	}
+
+	// Exact results above a minimum count are not limited when N is zero.
+	if pairs, err := f.Top(pilosa.TopOptions{Exact: true, MinThreshold: 3}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(pairs, append(p,
+		pilosa.Pair{ID: 101, Count: 4},
+		pilosa.Pair{ID: 100, Count: 3},
+	)) {
+		t.Fatalf("unexpected exact result set: %s", spew.Sdump(pairs))
+	}
 }
 
 // Ensure fragment can return a checksum for its blocks.
@@ -518,6 +539,102 @@ func TestFragment_RankCache_Persistence(t *testing.T) {
 	}
 }
 
+// Ensure a fragment replays ops written after its cache was last flushed.
+func TestFragment_Cache_Replay(t *testing.T) {
+	f := MustOpenFragment("i", "f", pilosa.ViewStandard, 0)
+	defer f.Close()
+
+	f.MustSetBits(1, 1, 2)
+	if err := f.FlushCache(); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := ioutil.ReadFile(f.CachePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Change rows after the flush and restore the stale cache file to
+	// simulate a crash.
+	f.MustSetBits(2, 1, 2, 3)
+	f.MustClearBits(1, 1)
+	if err := f.Fragment.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(f.CachePath(), stale, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ops in the log are replayed into the cache on open.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := f.Cache().Get(1); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := f.Cache().Get(2); n != 3 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// The cache is rebuilt from storage if the cache file is lost after a snapshot.
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	f.MustSetBits(3, 1)
+	if err := f.Fragment.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := os.Remove(f.CachePath()); err != nil {
+		t.Fatal(err)
+	} else if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := f.Cache().Get(2); n != 3 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := f.Cache().Get(3); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+}
+
+// Ensure a fragment can correct cached counts which have drifted from storage.
+func TestFragment_VerifyCache(t *testing.T) {
+	f := MustOpenFragment("i", "f", pilosa.ViewStandard, 0)
+	defer f.Close()
+
+	if err := f.ResetCache(pilosa.CacheTypeRanked, 10, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	f.MustSetBits(1, 1, 2)
+	f.MustSetBits(2, 1)
+	if n := f.VerifyCache(); n != 0 {
+		t.Fatalf("unexpected drift: %d", n)
+	}
+
+	// Drifted counts are detected by checking the cache file.
+	f.Cache().BulkAdd(1, 100)
+	if err := f.FlushCache(); err != nil {
+		t.Fatal(err)
+	}
+	cf, err := pilosa.ReadCacheFile(f.CachePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(f.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm := roaring.NewBitmap()
+	if err := bm.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if drift, err := cf.Check(bm, data); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(drift, []pilosa.CacheDrift{{ID: 1, Cached: 100, Count: 2}}) {
+		t.Fatalf("unexpected drift: %+v", drift)
+	}
+
+	// Verification corrects the count.
+	if n := f.VerifyCache(); n != 1 {
+		t.Fatalf("unexpected drift: %d", n)
+	} else if n := f.Cache().Get(1); n != 2 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+}
+
 // Ensure a fragment can be copied to another fragment.
 func TestFragment_WriteTo_ReadFrom(t *testing.T) {
 	f0 := MustOpenFragment("i", "f", pilosa.ViewStandard, 0)
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,6 +44,7 @@ const (
 // Frame represents a container for views.
 type Frame struct {
 	mu          sync.Mutex
+	cacheMu     sync.Mutex // serializes cache setting changes
 	path        string
 	index       string
 	name        string
@@ -52,6 +55,9 @@ type Frame struct {
 	// Row attribute storage and cache
 	rowAttrStore *AttrStore
 
+	// Memory budget for cached rows, shared by the holder.
+	rowCache *RowCache
+
 	broadcaster Broadcaster
 	stats       StatsClient
 
@@ -63,6 +69,14 @@ type Frame struct {
 	// Cache size for ranked frames
 	cacheSize uint32
 
+	// Cache timing settings. A zero interval uses DefaultCacheInterval.
+	cacheInterval time.Duration
+	cacheWindow   time.Duration
+	cacheHalfLife time.Duration
+
+	// Last refresh of each slice's window cache.
+	windowRefreshed map[uint64]time.Time
+
 	LogOutput io.Writer
 }
 
@@ -78,8 +92,9 @@ func NewFrame(path, index, name string) (*Frame, error) {
 		index: index,
 		name:  name,
 
-		views:        make(map[string]*View),
-		rowAttrStore: NewAttrStore(filepath.Join(path, ".data")),
+		views:           make(map[string]*View),
+		rowAttrStore:    NewAttrStore(filepath.Join(path, ".data")),
+		windowRefreshed: make(map[uint64]time.Time),
 
 		broadcaster: NopBroadcaster,
 		stats:       NopStatsClient,
@@ -164,27 +179,240 @@ func (f *Frame) RowLabel() string {
 
 // CacheType returns the caching mode for the frame.
 func (f *Frame) CacheType() string {
-	return f.cacheType
+	f.mu.Lock()
+	v := f.cacheType
+	f.mu.Unlock()
+	return v
+}
+
+// SetCacheType sets the caching mode for the frame and rebuilds the caches
+// of existing fragments. Persists to meta file on update.
+func (f *Frame) SetCacheType(v string) error {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	f.mu.Lock()
+	opt := f.cacheOptions()
+
+	// Ignore if no change occurred.
+	if v == "" || opt.Type == v {
+		f.mu.Unlock()
+		return nil
+	}
+
+	// Validate input.
+	if !IsValidCacheType(v) {
+		f.mu.Unlock()
+		return ErrInvalidCacheType
+	} else if err := validateCacheOptions(v, f.cacheWindow, f.cacheHalfLife, f.timeQuantum); err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+
+	opt.Type = v
+	return f.resetCaches(opt)
+}
+
+// CacheInterval returns the minimum time between rankings of the frame's
+// caches. Returns zero if the default interval is used.
+func (f *Frame) CacheInterval() time.Duration {
+	f.mu.Lock()
+	v := f.cacheInterval
+	f.mu.Unlock()
+	return v
+}
+
+// SetCacheInterval sets the minimum time between rankings of the frame's
+// caches and rebuilds the caches of existing fragments. Persists to meta
+// file on update.
+func (f *Frame) SetCacheInterval(v time.Duration) error {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	f.mu.Lock()
+	opt := f.cacheOptions()
+	f.mu.Unlock()
+
+	// Ignore if no change occurred.
+	if v <= 0 || opt.Interval == v {
+		return nil
+	}
+
+	// Only rebuild caches which rank on an interval.
+	opt.Interval = v
+	if !usesCacheInterval(opt.Type) {
+		return f.saveCacheOptions(opt)
+	}
+	return f.resetCaches(opt)
+}
+
+// CacheWindow returns the length of time ranked by a window cache.
+func (f *Frame) CacheWindow() time.Duration {
+	f.mu.Lock()
+	v := f.cacheWindow
+	f.mu.Unlock()
+	return v
+}
+
+// SetCacheWindow sets the length of time ranked by a window cache.
+// Persists to meta file on update.
+func (f *Frame) SetCacheWindow(v time.Duration) error {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Ignore if no change occurred.
+	if v <= 0 || f.cacheWindow == v {
+		return nil
+	}
+
+	// Persist meta data to disk on change.
+	f.cacheWindow = v
+	if err := f.saveMeta(); err != nil {
+		return err
+	}
+
+	// Recount window caches on their next use.
+	f.windowRefreshed = make(map[uint64]time.Time)
+	return nil
+}
+
+// CacheHalfLife returns the half-life of scores in a decay cache.
+func (f *Frame) CacheHalfLife() time.Duration {
+	f.mu.Lock()
+	v := f.cacheHalfLife
+	f.mu.Unlock()
+	return v
+}
+
+// SetCacheHalfLife sets the half-life of scores in a decay cache and rebuilds
+// the caches of existing fragments. Persists to meta file on update.
+func (f *Frame) SetCacheHalfLife(v time.Duration) error {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	f.mu.Lock()
+	opt := f.cacheOptions()
+	f.mu.Unlock()
+
+	// Ignore if no change occurred.
+	if v <= 0 || opt.HalfLife == v {
+		return nil
+	}
+
+	// Only decay caches use the half-life.
+	opt.HalfLife = v
+	if opt.Type != CacheTypeDecay {
+		return f.saveCacheOptions(opt)
+	}
+	return f.resetCaches(opt)
+}
+
+// RefreshWindowCaches refreshes the window cache of each slice in the
+// standard view. This is a no-op unless the frame uses a window cache.
+func (f *Frame) RefreshWindowCaches(now time.Time) error {
+	if f.CacheType() != CacheTypeWindow {
+		return nil
+	}
+
+	view := f.View(ViewStandard)
+	if view == nil {
+		return nil
+	}
+	for _, frag := range view.Fragments() {
+		if err := f.RefreshWindowCache(frag.Slice(), now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshWindowCache recounts the window cache of the standard view's
+// fragment for slice from the time views within the frame's cache window
+// ending at now. Refreshes are limited to once per cache interval per slice.
+// This is a no-op unless the frame uses a window cache.
+//
+// A bit set in more than one time view within the window is counted once
+// for each view.
+func (f *Frame) RefreshWindowCache(slice uint64, now time.Time) error {
+	f.mu.Lock()
+	interval := f.cacheInterval
+	if interval <= 0 {
+		interval = DefaultCacheInterval
+	}
+	if f.cacheType != CacheTypeWindow {
+		f.mu.Unlock()
+		return nil
+	} else if t, ok := f.windowRefreshed[slice]; ok && now.Sub(t) < interval {
+		f.mu.Unlock()
+		return nil
+	}
+	f.windowRefreshed[slice] = now
+
+	// Find the views within the window.
+	standard := f.views[ViewStandard]
+	start, end := timeWindow(now, f.cacheWindow, f.timeQuantum)
+	var views []*View
+	for _, name := range ViewsByTimeRange(ViewStandard, start, end, f.timeQuantum) {
+		if view := f.views[name]; view != nil {
+			views = append(views, view)
+		}
+	}
+	f.mu.Unlock()
+
+	// Fragment locks must not be held while holding the frame lock.
+	if standard == nil {
+		return nil
+	}
+	frag := standard.Fragment(slice)
+	if frag == nil {
+		return nil
+	}
+
+	// Sum the row counts of each view.
+	counts := make(map[uint64]uint64)
+	for _, view := range views {
+		if other := view.Fragment(slice); other != nil {
+			for _, pair := range other.storageBitmapPairs(nil) {
+				counts[pair.ID] += pair.Count
+			}
+		}
+	}
+
+	pairs := make([]BitmapPair, 0, len(counts))
+	for id, n := range counts {
+		pairs = append(pairs, BitmapPair{ID: id, Count: n})
+	}
+	frag.setWindowCounts(pairs)
+
+	return nil
 }
 
 // InverseEnabled returns true if an inverse view is available.
 func (f *Frame) InverseEnabled() bool {
-	return f.inverseEnabled
+	f.mu.Lock()
+	v := f.inverseEnabled
+	f.mu.Unlock()
+	return v
 }
 
-// SetCacheSize sets the cache size for ranked fames. Persists to meta file on update.
-// defaults to DefaultCacheSize 50000
-func (f *Frame) SetCacheSize(v uint32) error {
+// EnableInverse enables inverse storage on the frame. Persists to meta file
+// on update. Existing data is not copied to the inverse views until
+// BackfillInverse is called.
+func (f *Frame) EnableInverse() error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	// Ignore if no change occurred.
-	if v == 0 || f.cacheSize == v {
+	if f.inverseEnabled {
 		return nil
 	}
 
 	// Persist meta data to disk on change.
-	f.cacheSize = v
+	f.inverseEnabled = true
 	if err := f.saveMeta(); err != nil {
 		return err
 	}
@@ -192,6 +420,187 @@ func (f *Frame) SetCacheSize(v uint32) error {
 	return nil
 }
 
+// BackfillInverse writes the bits of each standard view into the matching
+// inverse view. Bits are only added so writes which occur during the
+// backfill are preserved, however a bit cleared while its fragment is being
+// copied may reappear in the inverse view.
+func (f *Frame) BackfillInverse() error {
+	if !f.InverseEnabled() {
+		return ErrFrameInverseDisabled
+	}
+
+	for _, view := range f.Views() {
+		if IsInverseView(view.Name()) {
+			continue
+		}
+		name := ViewInverse + strings.TrimPrefix(view.Name(), ViewStandard)
+
+		for _, frag := range view.Fragments() {
+			// Reverse bits and split them by inverse slice.
+			dataBySlice := make(map[uint64]importData)
+			if err := frag.ForEachBit(func(rowID, columnID uint64) error {
+				data := dataBySlice[rowID/SliceWidth]
+				data.RowIDs = append(data.RowIDs, columnID)
+				data.ColumnIDs = append(data.ColumnIDs, rowID)
+				dataBySlice[rowID/SliceWidth] = data
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			// Import into each inverse fragment.
+			for slice, data := range dataBySlice {
+				sort.Sort(importBitSet{
+					rowIDs:    data.RowIDs,
+					columnIDs: data.ColumnIDs,
+				})
+
+				inverse, err := f.CreateViewIfNotExists(name)
+				if err != nil {
+					return err
+				}
+
+				other, err := inverse.CreateFragmentIfNotExists(slice)
+				if err != nil {
+					return err
+				}
+
+				if err := other.Import(data.RowIDs, data.ColumnIDs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// backfillInverse fills the inverse views of f in the background.
+func backfillInverse(f *Frame, logger *log.Logger) {
+	if f == nil {
+		return
+	}
+	go func() {
+		logger.Printf("inverse backfill started: index=%s, frame=%s", f.Index(), f.Name())
+		if err := f.BackfillInverse(); err != nil {
+			logger.Printf("inverse backfill error: index=%s, frame=%s, err=%s", f.Index(), f.Name(), err)
+			return
+		}
+		logger.Printf("inverse backfill complete: index=%s, frame=%s", f.Index(), f.Name())
+	}()
+}
+
+// SetCacheSize sets the cache size for ranked fames and rebuilds the caches
+// of existing fragments. Persists to meta file on update.
+// defaults to DefaultCacheSize 50000
+func (f *Frame) SetCacheSize(v uint32) error {
+	f.cacheMu.Lock()
+	defer f.cacheMu.Unlock()
+
+	f.mu.Lock()
+	opt := f.cacheOptions()
+	f.mu.Unlock()
+
+	// Ignore if no change occurred.
+	if v == 0 || opt.Size == v {
+		return nil
+	}
+
+	opt.Size = v
+	return f.resetCaches(opt)
+}
+
+// cacheOptions holds the settings used to build the caches of fragments.
+type cacheOptions struct {
+	Type     string
+	Size     uint32
+	Interval time.Duration
+	HalfLife time.Duration
+}
+
+// cacheOptions returns the frame's current cache settings.
+// Caller must hold f.mu.
+func (f *Frame) cacheOptions() cacheOptions {
+	return cacheOptions{
+		Type:     f.cacheType,
+		Size:     f.cacheSize,
+		Interval: f.cacheInterval,
+		HalfLife: f.cacheHalfLife,
+	}
+}
+
+// setCacheOptions updates the frame's cache settings. Caller must hold f.mu.
+func (f *Frame) setCacheOptions(opt cacheOptions) {
+	f.cacheType, f.cacheSize = opt.Type, opt.Size
+	f.cacheInterval, f.cacheHalfLife = opt.Interval, opt.HalfLife
+}
+
+// saveCacheOptions persists opt to the meta file without rebuilding caches.
+// Caller must hold f.cacheMu.
+func (f *Frame) saveCacheOptions(opt cacheOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prev := f.cacheOptions()
+	f.setCacheOptions(opt)
+	if err := f.saveMeta(); err != nil {
+		f.setCacheOptions(prev)
+		return err
+	}
+	return nil
+}
+
+// resetCaches rebuilds the caches of each view using opt and then persists
+// opt to the meta file. The frame lock is not held while fragments are
+// rebuilt so the frame remains accessible. If a rebuild or the save fails
+// then the views are reset to the previous settings, which are kept.
+// Caller must hold f.cacheMu.
+func (f *Frame) resetCaches(opt cacheOptions) error {
+	f.mu.Lock()
+	prev := f.cacheOptions()
+	views := make(map[*View]struct{}, len(f.views))
+	for _, view := range f.views {
+		views[view] = struct{}{}
+	}
+	f.mu.Unlock()
+
+	if err := resetViewCaches(views, opt); err != nil {
+		resetViewCaches(views, prev)
+		return err
+	}
+
+	f.mu.Lock()
+	f.setCacheOptions(opt)
+	if err := f.saveMeta(); err != nil {
+		f.setCacheOptions(prev)
+		f.mu.Unlock()
+		resetViewCaches(views, prev)
+		return err
+	}
+	f.windowRefreshed = make(map[uint64]time.Time)
+
+	// Views created during the rebuild used the previous settings.
+	created := make(map[*View]struct{})
+	for _, view := range f.views {
+		if _, ok := views[view]; !ok {
+			created[view] = struct{}{}
+		}
+	}
+	f.mu.Unlock()
+
+	return resetViewCaches(created, opt)
+}
+
+// resetViewCaches rebuilds the caches of each view using opt.
+func resetViewCaches(views map[*View]struct{}, opt cacheOptions) error {
+	for view := range views {
+		if err := view.ResetCache(opt.Type, opt.Size, opt.Interval, opt.HalfLife); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CacheSize returns the ranked frame cache size.
 func (f *Frame) CacheSize() uint32 {
 	f.mu.Lock()
@@ -208,6 +617,9 @@ func (f *Frame) Options() FrameOptions {
 		InverseEnabled: f.inverseEnabled,
 		CacheType:      f.cacheType,
 		CacheSize:      f.cacheSize,
+		CacheInterval:  Duration(f.cacheInterval),
+		CacheWindow:    Duration(f.cacheWindow),
+		CacheHalfLife:  Duration(f.cacheHalfLife),
 		TimeQuantum:    f.timeQuantum,
 	}
 	f.mu.Unlock()
@@ -289,6 +701,7 @@ func (f *Frame) loadMeta() error {
 		f.cacheType = DefaultCacheType
 		f.inverseEnabled = DefaultInverseEnabled
 		f.cacheSize = DefaultCacheSize
+		f.cacheInterval, f.cacheWindow, f.cacheHalfLife = 0, 0, 0
 		return nil
 	} else if err != nil {
 		return err
@@ -303,6 +716,9 @@ func (f *Frame) loadMeta() error {
 	f.rowLabel = pb.RowLabel
 	f.inverseEnabled = pb.InverseEnabled
 	f.cacheSize = pb.CacheSize
+	f.cacheInterval = time.Duration(pb.CacheInterval)
+	f.cacheWindow = time.Duration(pb.CacheWindow)
+	f.cacheHalfLife = time.Duration(pb.CacheHalfLife)
 
 	// Copy cache type.
 	f.cacheType = pb.CacheType
@@ -321,6 +737,9 @@ func (f *Frame) saveMeta() error {
 		InverseEnabled: f.inverseEnabled,
 		CacheType:      f.cacheType,
 		CacheSize:      f.cacheSize,
+		CacheInterval:  int64(f.cacheInterval),
+		CacheWindow:    int64(f.cacheWindow),
+		CacheHalfLife:  int64(f.cacheHalfLife),
 		TimeQuantum:    string(f.timeQuantum),
 	})
 	if err != nil {
@@ -373,6 +792,7 @@ func (f *Frame) SetTimeQuantum(q TimeQuantum) error {
 
 	// Update value on frame.
 	f.timeQuantum = q
+	f.windowRefreshed = make(map[uint64]time.Time)
 
 	// Persist meta data to disk.
 	if err := f.saveMeta(); err != nil {
@@ -435,9 +855,12 @@ func (f *Frame) CreateViewIfNotExists(name string) (*View, error) {
 func (f *Frame) newView(path, name string) *View {
 	view := NewView(path, f.index, f.name, name, f.cacheSize)
 	view.cacheType = f.cacheType
+	view.cacheInterval = f.cacheInterval
+	view.cacheHalfLife = f.cacheHalfLife
 	view.LogOutput = f.LogOutput
 	view.RowAttrStore = f.rowAttrStore
 	view.stats = f.stats.WithTags(fmt.Sprintf("slice:%s", name))
+	view.rowCache = f.rowCache
 	view.broadcaster = f.broadcaster
 	return view
 }
@@ -530,6 +953,7 @@ func (f *Frame) ClearBit(name string, rowID, colID uint64, t *time.Time) (change
 func (f *Frame) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time) error {
 	// Determine quantum if timestamps are set.
 	q := f.TimeQuantum()
+	inverseEnabled := f.InverseEnabled()
 	if hasTime(timestamps) && q == "" {
 		return errors.New("time quantum not set in either index or frame")
 	}
@@ -560,7 +984,7 @@ func (f *Frame) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time) erro
 			dataByFragment[key] = data
 		}
 
-		if f.inverseEnabled {
+		if inverseEnabled {
 			// Attach reversed bits to each inverse view.
 			for _, name := range inverse {
 				key := importKey{View: name, Slice: rowID / SliceWidth}
@@ -575,7 +999,7 @@ func (f *Frame) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time) erro
 	// Import into each fragment.
 	for key, data := range dataByFragment {
 		// Skip inverse data if inverse is not enabled.
-		if !f.inverseEnabled && IsInverseView(key.View) {
+		if !inverseEnabled && IsInverseView(key.View) {
 			continue
 		}
 
@@ -623,11 +1047,46 @@ func encodeFrame(f *Frame) *internal.Frame {
 			InverseEnabled: f.inverseEnabled,
 			CacheType:      f.cacheType,
 			CacheSize:      f.cacheSize,
+			CacheInterval:  int64(f.cacheInterval),
+			CacheWindow:    int64(f.cacheWindow),
+			CacheHalfLife:  int64(f.cacheHalfLife),
 			TimeQuantum:    string(f.timeQuantum),
 		},
 	}
 }
 
+// Stats returns statistics for each view in the frame on the local node.
+func (f *Frame) Stats() (FrameStats, error) {
+	stats := FrameStats{Name: f.Name(), Views: []ViewStats{}}
+
+	views := f.Views()
+	sort.Sort(viewSlice(views))
+	for _, view := range views {
+		vs, err := view.Stats()
+		if err != nil {
+			return stats, err
+		}
+		stats.BitN += vs.BitN
+		stats.Size += vs.Size
+		stats.Views = append(stats.Views, vs)
+	}
+	return stats, nil
+}
+
+// FrameStats represents statistics for a frame on the local node.
+type FrameStats struct {
+	Name  string      `json:"name"`
+	BitN  uint64      `json:"bitCount"`
+	Size  int64       `json:"size"`
+	Views []ViewStats `json:"views"`
+}
+
+// FrameDefinition represents a frame's name and options.
+type FrameDefinition struct {
+	Name    string       `json:"name" toml:"name"`
+	Options FrameOptions `json:"options" toml:"options"`
+}
+
 type frameSlice []*Frame
 
 func (p frameSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
@@ -652,6 +1111,9 @@ type FrameOptions struct {
 	InverseEnabled bool        `json:"inverseEnabled,omitempty"`
 	CacheType      string      `json:"cacheType,omitempty"`
 	CacheSize      uint32      `json:"cacheSize,omitempty"`
+	CacheInterval  Duration    `json:"cacheInterval,omitempty"`
+	CacheWindow    Duration    `json:"cacheWindow,omitempty"`
+	CacheHalfLife  Duration    `json:"cacheHalfLife,omitempty"`
 	TimeQuantum    TimeQuantum `json:"timeQuantum,omitempty"`
 }
 
@@ -662,10 +1124,30 @@ func (o *FrameOptions) Encode() *internal.FrameMeta {
 		InverseEnabled: o.InverseEnabled,
 		CacheType:      o.CacheType,
 		CacheSize:      o.CacheSize,
+		CacheInterval:  int64(o.CacheInterval),
+		CacheWindow:    int64(o.CacheWindow),
+		CacheHalfLife:  int64(o.CacheHalfLife),
 		TimeQuantum:    string(o.TimeQuantum),
 	}
 }
 
+// decodeFrameOptions converts pb into frame options.
+func decodeFrameOptions(pb *internal.FrameMeta) FrameOptions {
+	if pb == nil {
+		return FrameOptions{}
+	}
+	return FrameOptions{
+		RowLabel:       pb.RowLabel,
+		InverseEnabled: pb.InverseEnabled,
+		CacheType:      pb.CacheType,
+		CacheSize:      pb.CacheSize,
+		CacheInterval:  Duration(pb.CacheInterval),
+		CacheWindow:    Duration(pb.CacheWindow),
+		CacheHalfLife:  Duration(pb.CacheHalfLife),
+		TimeQuantum:    TimeQuantum(pb.TimeQuantum),
+	}
+}
+
 // importBitSet represents slices of row and column ids.
 // This is used to sort data during import.
 type importBitSet struct {
@@ -683,14 +1165,32 @@ func (p importBitSet) Less(i, j int) bool { return p.rowIDs[i] < p.rowIDs[j] }
 const (
 	CacheTypeLRU    = "lru"
 	CacheTypeRanked = "ranked"
+	CacheTypeWindow = "window"
+	CacheTypeDecay  = "decay"
 )
 
 // IsValidCacheType returns true if v is a valid cache type.
 func IsValidCacheType(v string) bool {
 	switch v {
-	case CacheTypeLRU, CacheTypeRanked:
+	case CacheTypeLRU, CacheTypeRanked, CacheTypeWindow, CacheTypeDecay:
 		return true
 	default:
 		return false
 	}
 }
+
+// validateCacheOptions returns an error if the settings required by the
+// cache type are missing.
+func validateCacheOptions(cacheType string, window, halfLife time.Duration, q TimeQuantum) error {
+	switch cacheType {
+	case CacheTypeWindow:
+		if window <= 0 || q == "" {
+			return ErrCacheWindowRequired
+		}
+	case CacheTypeDecay:
+		if halfLife <= 0 {
+			return ErrCacheHalfLifeRequired
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ package pilosa_test
 import (
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -243,3 +244,145 @@ func TestFrame_SetCacheSize(t *testing.T) {
 		t.Fatalf("unexpected frame cache size (reopen): %d", q)
 	}
 }
+
+// Ensure frame can change its cache type and rebuild existing caches.
+func TestFrame_SetCacheType(t *testing.T) {
+	f := MustOpenFrame()
+	defer f.Close()
+
+	f.MustSetBit(pilosa.ViewStandard, 1, 1, nil)
+	f.MustSetBit(pilosa.ViewStandard, 1, 2, nil)
+	f.MustSetBit(pilosa.ViewStandard, 2, 1, nil)
+	f.MustSetBit(pilosa.ViewStandard, 3, SliceWidth+1, nil)
+
+	if err := f.SetCacheType(pilosa.CacheTypeRanked); err != nil {
+		t.Fatal(err)
+	} else if v := f.CacheType(); v != pilosa.CacheTypeRanked {
+		t.Fatalf("unexpected cache type: %s", v)
+	}
+
+	// Verify existing fragment caches are rebuilt.
+	cache := f.View(pilosa.ViewStandard).Fragment(0).Cache()
+	if _, ok := cache.(*pilosa.RankCache); !ok {
+		t.Fatalf("unexpected cache: %T", cache)
+	} else if pairs := cache.Top(); !reflect.DeepEqual(pairs, []pilosa.BitmapPair{{ID: 1, Count: 2}, {ID: 2, Count: 1}}) {
+		t.Fatalf("unexpected pairs: %+v", pairs)
+	}
+	if cache := f.View(pilosa.ViewStandard).Fragment(1).Cache(); cache.Get(3) != 1 {
+		t.Fatalf("unexpected count: %d", cache.Get(3))
+	}
+
+	// Verify new fragments use the new cache type.
+	f.MustSetBit(pilosa.ViewStandard, 1, (2*SliceWidth)+1, nil)
+	if cache := f.View(pilosa.ViewStandard).Fragment(2).Cache(); cache == nil {
+		t.Fatal("expected cache")
+	} else if _, ok := cache.(*pilosa.RankCache); !ok {
+		t.Fatalf("unexpected cache: %T", cache)
+	}
+
+	// Invalid cache types are rejected.
+	if err := f.SetCacheType("foo"); err != pilosa.ErrInvalidCacheType {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Cache types are rejected if their settings are missing.
+	if err := f.SetCacheType(pilosa.CacheTypeWindow); err != pilosa.ErrCacheWindowRequired {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := f.SetCacheType(pilosa.CacheTypeDecay); err != pilosa.ErrCacheHalfLifeRequired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Decay caches are used once a half-life is set.
+	if err := f.SetCacheHalfLife(time.Hour); err != nil {
+		t.Fatal(err)
+	} else if err := f.SetCacheType(pilosa.CacheTypeDecay); err != nil {
+		t.Fatal(err)
+	} else if cache := f.View(pilosa.ViewStandard).Fragment(0).Cache(); cache.Get(1) != 2 {
+		t.Fatalf("unexpected score: %d", cache.Get(1))
+	} else if err := f.SetCacheType(pilosa.CacheTypeRanked); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reload frame and verify that it is persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if v := f.CacheType(); v != pilosa.CacheTypeRanked {
+		t.Fatalf("unexpected cache type (reopen): %s", v)
+	} else if v := f.CacheHalfLife(); v != time.Hour {
+		t.Fatalf("unexpected cache half-life (reopen): %s", v)
+	}
+}
+
+// Ensure frame only rebuilds caches for settings used by the cache type.
+func TestFrame_SetCacheOptions_Unused(t *testing.T) {
+	f := MustOpenFrame()
+	defer f.Close()
+
+	f.MustSetBit(pilosa.ViewStandard, 1, 1, nil)
+	if err := f.SetCacheType(pilosa.CacheTypeRanked); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ranked caches don't use a half-life.
+	cache := f.View(pilosa.ViewStandard).Fragment(0).Cache()
+	if err := f.SetCacheHalfLife(time.Hour); err != nil {
+		t.Fatal(err)
+	} else if v := f.CacheHalfLife(); v != time.Hour {
+		t.Fatalf("unexpected cache half-life: %s", v)
+	} else if f.View(pilosa.ViewStandard).Fragment(0).Cache() != cache {
+		t.Fatal("expected cache to be kept")
+	}
+
+	// LRU caches don't rank on an interval.
+	if err := f.SetCacheType(pilosa.CacheTypeLRU); err != nil {
+		t.Fatal(err)
+	}
+	cache = f.View(pilosa.ViewStandard).Fragment(0).Cache()
+	if err := f.SetCacheInterval(time.Second); err != nil {
+		t.Fatal(err)
+	} else if v := f.CacheInterval(); v != time.Second {
+		t.Fatalf("unexpected cache interval: %s", v)
+	} else if f.View(pilosa.ViewStandard).Fragment(0).Cache() != cache {
+		t.Fatal("expected cache to be kept")
+	}
+
+	// Settings are persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if v := f.CacheHalfLife(); v != time.Hour {
+		t.Fatalf("unexpected cache half-life (reopen): %s", v)
+	} else if v := f.CacheInterval(); v != time.Second {
+		t.Fatalf("unexpected cache interval (reopen): %s", v)
+	}
+}
+
+// Ensure frame can enable inverse storage and backfill existing data.
+func TestFrame_BackfillInverse(t *testing.T) {
+	f := MustOpenFrame()
+	defer f.Close()
+
+	f.MustSetBit(pilosa.ViewStandard, 1, 10, nil)
+	f.MustSetBit(pilosa.ViewStandard, 2, 10, nil)
+	f.MustSetBit(pilosa.ViewStandard, SliceWidth+3, SliceWidth+20, nil)
+
+	if err := f.BackfillInverse(); err != pilosa.ErrFrameInverseDisabled {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := f.EnableInverse(); err != nil {
+		t.Fatal(err)
+	} else if err := f.BackfillInverse(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bits := f.View(pilosa.ViewInverse).Fragment(0).Row(10).Bits(); !reflect.DeepEqual(bits, []uint64{1, 2}) {
+		t.Fatalf("unexpected bits: %+v", bits)
+	} else if bits := f.View(pilosa.ViewInverse).Fragment(1).Row(SliceWidth + 20).Bits(); !reflect.DeepEqual(bits, []uint64{SliceWidth + 3}) {
+		t.Fatalf("unexpected bits: %+v", bits)
+	}
+
+	// Reload frame and verify that it is persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if !f.InverseEnabled() {
+		t.Fatal("expected inverse enabled (reopen)")
+	}
+}
@@ -15,6 +15,7 @@
 package gossip
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"log"
@@ -120,6 +121,17 @@ func NewGossipNodeSet(name string, gossipHost string, gossipPort int, gossipSeed
 	return g
 }
 
+// SetSecret sets the shared secret used to encrypt gossip traffic.
+// The encryption key is derived from the secret. Must be called before Open().
+func (g *GossipNodeSet) SetSecret(secret []byte) {
+	if len(secret) == 0 {
+		g.config.memberlistConfig.SecretKey = nil
+		return
+	}
+	key := sha256.Sum256(secret)
+	g.config.memberlistConfig.SecretKey = key[:]
+}
+
 // SendSync implementation of the Broadcaster interface.
 func (g *GossipNodeSet) SendSync(pb proto.Message) error {
 	msg, err := pilosa.MarshalMessage(pb)
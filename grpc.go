@@ -0,0 +1,630 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// DefaultGRPCChunkSize is the number of bits sent per message when
+// streaming bitmap results.
+const DefaultGRPCChunkSize = 65536
+
+// grpcErrors are errors which are passed between the gRPC server and client
+// with a specific status code.
+var grpcErrors = map[error]codes.Code{
+	ErrUnauthenticated:  codes.Unauthenticated,
+	ErrPermissionDenied: codes.PermissionDenied,
+	ErrIndexRequired:    codes.InvalidArgument,
+	ErrIndexExists:      codes.AlreadyExists,
+	ErrIndexNotFound:    codes.NotFound,
+	ErrInvalidReplicaN:  codes.InvalidArgument,
+	ErrFrameRequired:    codes.InvalidArgument,
+	ErrFrameExists:      codes.AlreadyExists,
+	ErrFrameNotFound:    codes.NotFound,
+	ErrInvalidView:      codes.InvalidArgument,
+	ErrInvalidCacheType: codes.InvalidArgument,
+	ErrName:             codes.InvalidArgument,
+	ErrLabel:            codes.InvalidArgument,
+	ErrQueryRequired:    codes.InvalidArgument,
+
+	ErrCacheWindowRequired:   codes.InvalidArgument,
+	ErrCacheHalfLifeRequired: codes.InvalidArgument,
+
+	ErrQueryTimeout:      codes.DeadlineExceeded,
+	ErrQueryCanceled:     codes.Canceled,
+	ErrTooManyQueries:    codes.ResourceExhausted,
+	ErrTooManyResultBits: codes.ResourceExhausted,
+	ErrInvalidSample:     codes.InvalidArgument,
+}
+
+// encodeGRPCError converts err to a gRPC status error.
+func encodeGRPCError(err error) error {
+	if err == nil {
+		return nil
+	} else if code, ok := grpcErrors[err]; ok {
+		return status.Error(code, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// decodeGRPCError converts a gRPC status error back to a known error, if possible.
+func decodeGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for e, code := range grpcErrors {
+		if s.Code() == code && s.Message() == e.Error() {
+			return e
+		}
+	}
+	return errors.New(s.Message())
+}
+
+// gogoCodec is a gRPC codec which marshals messages with gogo protobuf.
+type gogoCodec struct{}
+
+func (gogoCodec) Marshal(v interface{}) ([]byte, error) { return proto.Marshal(v.(proto.Message)) }
+func (gogoCodec) Unmarshal(data []byte, v interface{}) error {
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+func (gogoCodec) String() string { return "proto" }
+
+// GRPCHandler implements the Pilosa gRPC service. It uses the same holder,
+// executor, broadcaster, access control and audit log as Handler.
+type GRPCHandler struct {
+	Handler *Handler
+
+	// Number of bits sent per message when streaming bitmaps.
+	ChunkSize int
+}
+
+// NewGRPCHandler returns a new instance of GRPCHandler backed by h.
+func NewGRPCHandler(h *Handler) *GRPCHandler {
+	return &GRPCHandler{
+		Handler:   h,
+		ChunkSize: DefaultGRPCChunkSize,
+	}
+}
+
+// NewServer returns a gRPC server with the handler registered.
+// The server uses TLS if tlsConfig is set.
+func (g *GRPCHandler) NewServer(tlsConfig *tls.Config) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.CustomCodec(gogoCodec{})}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s := grpc.NewServer(opts...)
+	internal.RegisterPilosaServer(s, g)
+	return s
+}
+
+// Query executes a PQL query and returns all results.
+func (g *GRPCHandler) Query(ctx context.Context, req *internal.QueryRequest) (*internal.QueryResponse, error) {
+	q, err := g.parseQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read-only principals cannot execute write calls.
+	perm := QueryPermission(q)
+	if err := g.authorize(ctx, req.Index, perm); err != nil {
+		if perm == PermissionWrite {
+			g.audit(ctx, "Query", req.Index, "", writeCallStrings(q), err)
+		}
+		return nil, encodeGRPCError(err)
+	}
+
+	release, err := g.admitQuery(ctx, req.Index)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	results, err := g.Handler.Executor.Execute(ctx, req.Index, q, req.Slices, &ExecOptions{Sample: req.Sample})
+	if perm == PermissionWrite {
+		g.audit(ctx, "Query", req.Index, "", writeCallStrings(q), err)
+	}
+	if err != nil {
+		return nil, encodeGRPCError(err)
+	}
+	resp := &QueryResponse{Results: results}
+
+	// Fill column attributes if requested.
+	if req.ColumnAttrs {
+		var columnIDs []uint64
+		for _, result := range results {
+			if bm, ok := result.(*Bitmap); ok {
+				columnIDs = uint64Slice(columnIDs).merge(bm.Bits())
+			}
+		}
+
+		columnAttrSets, err := g.Handler.readColumnAttrSets(g.Handler.Holder.Index(req.Index), columnIDs)
+		if err != nil {
+			return nil, encodeGRPCError(err)
+		}
+		resp.ColumnAttrSets = columnAttrSets
+	}
+
+	return encodeQueryResponse(resp), nil
+}
+
+// StreamBitmap executes a query with a single bitmap call and streams the
+// bits of the result in chunks. The first message includes the attributes.
+func (g *GRPCHandler) StreamBitmap(req *internal.QueryRequest, stream internal.Pilosa_StreamBitmapServer) error {
+	ctx := stream.Context()
+
+	q, err := g.parseQuery(req)
+	if err != nil {
+		return err
+	} else if len(q.Calls) != 1 {
+		return status.Error(codes.InvalidArgument, "exactly one call required")
+	} else if QueryPermission(q) != PermissionRead {
+		return status.Error(codes.InvalidArgument, "write calls cannot be streamed")
+	}
+
+	if err := g.authorize(ctx, req.Index, PermissionRead); err != nil {
+		return encodeGRPCError(err)
+	}
+
+	release, err := g.admitQuery(ctx, req.Index)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	results, err := g.Handler.Executor.Execute(ctx, req.Index, q, req.Slices, &ExecOptions{})
+	if err != nil {
+		return encodeGRPCError(err)
+	}
+	bm, ok := results[0].(*Bitmap)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "call does not return a bitmap")
+	}
+
+	chunkSize := g.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultGRPCChunkSize
+	}
+
+	bits := bm.Bits()
+	pb := &internal.Bitmap{Attrs: encodeAttrs(bm.Attrs)}
+	for i := 0; ; i += chunkSize {
+		j := i + chunkSize
+		if j > len(bits) {
+			j = len(bits)
+		}
+		pb.Bits = bits[i:j]
+
+		if err := stream.Send(pb); err != nil {
+			return err
+		} else if j == len(bits) {
+			return nil
+		}
+		pb = &internal.Bitmap{}
+	}
+}
+
+// Import imports bits into every node which owns the request's slice.
+func (g *GRPCHandler) Import(ctx context.Context, req *internal.ImportRequest) (*internal.ImportResponse, error) {
+	h := g.Handler
+	if req.Index == "" {
+		return nil, encodeGRPCError(ErrIndexRequired)
+	} else if req.Frame == "" {
+		return nil, encodeGRPCError(ErrFrameRequired)
+	}
+	req.Index = h.resolveAlias(req.Index)
+
+	if err := g.authorize(ctx, req.Index, PermissionWrite); err != nil {
+		return nil, encodeGRPCError(err)
+	}
+
+	// Find the frame.
+	index := h.Holder.Index(req.Index)
+	if index == nil {
+		return nil, encodeGRPCError(ErrIndexNotFound)
+	}
+	f := index.Frame(req.Frame)
+	if f == nil {
+		return nil, encodeGRPCError(ErrFrameNotFound)
+	}
+
+	// Import locally or forward the request to the owning nodes.
+	var buf []byte
+	for _, node := range h.Cluster.FragmentNodes(req.Index, req.Slice) {
+		if node.Host == h.Host {
+			if err := f.Import(req.RowIDs, req.ColumnIDs, decodeTimestamps(req.Timestamps)); err != nil {
+				return nil, encodeGRPCError(err)
+			}
+			continue
+		}
+
+		if buf == nil {
+			var err error
+			if buf, err = proto.Marshal(req); err != nil {
+				return nil, encodeGRPCError(err)
+			}
+		}
+
+		client, err := h.Cluster.NodeClient(node.Host)
+		if err != nil {
+			return nil, encodeGRPCError(err)
+		} else if err := client.importNode(ctx, node, buf); err != nil {
+			return nil, encodeGRPCError(fmt.Errorf("import node: host=%s, err=%s", node.Host, err))
+		}
+	}
+
+	return &internal.ImportResponse{}, nil
+}
+
+// Schema returns the indexes and frames readable by the caller.
+func (g *GRPCHandler) Schema(ctx context.Context, req *internal.SchemaRequest) (*internal.SchemaResponse, error) {
+	h := g.Handler
+	if err := g.authorize(ctx, "", PermissionNone); err != nil {
+		return nil, encodeGRPCError(err)
+	}
+
+	var p *Principal
+	if h.AccessControl != nil {
+		p = h.AccessControl.AuthenticateKey(apiKeyFromContext(ctx))
+	}
+
+	indexes := make([]*Index, 0)
+	for _, index := range h.Holder.Indexes() {
+		if p == nil || p.Allowed(index.Name(), PermissionRead) {
+			indexes = append(indexes, index)
+		}
+	}
+	return &internal.SchemaResponse{Indexes: encodeIndexes(indexes)}, nil
+}
+
+// CreateIndex creates an index and broadcasts it to the cluster.
+func (g *GRPCHandler) CreateIndex(ctx context.Context, req *internal.CreateIndexRequest) (*internal.CreateIndexResponse, error) {
+	h := g.Handler
+	if err := g.authorize(ctx, req.Index, PermissionAdmin); err != nil {
+		g.audit(ctx, "CreateIndex", req.Index, "", nil, err)
+		return nil, encodeGRPCError(err)
+	}
+
+	opt := decodeIndexOptions(req.Meta)
+	_, err := h.Holder.CreateIndex(req.Index, opt)
+	g.audit(ctx, "CreateIndex", req.Index, "", nil, err)
+	if err != nil {
+		return nil, encodeGRPCError(err)
+	}
+
+	// Send the create index message to all nodes.
+	if err := h.Broadcaster.SendSync(&internal.CreateIndexMessage{
+		Index: req.Index,
+		Meta:  opt.Encode(),
+	}); err != nil {
+		h.logger().Printf("problem sending CreateIndex message: %s", err)
+	}
+
+	return &internal.CreateIndexResponse{}, nil
+}
+
+// CreateFrame creates a frame and broadcasts it to the cluster.
+func (g *GRPCHandler) CreateFrame(ctx context.Context, req *internal.CreateFrameRequest) (*internal.CreateFrameResponse, error) {
+	h := g.Handler
+	req.Index = h.resolveAlias(req.Index)
+	if err := g.authorize(ctx, req.Index, PermissionAdmin); err != nil {
+		g.audit(ctx, "CreateFrame", req.Index, req.Frame, nil, err)
+		return nil, encodeGRPCError(err)
+	}
+
+	index := h.Holder.Index(req.Index)
+	if index == nil {
+		return nil, encodeGRPCError(ErrIndexNotFound)
+	}
+
+	opt := decodeFrameOptions(req.Meta)
+	_, err := index.CreateFrame(req.Frame, opt)
+	g.audit(ctx, "CreateFrame", req.Index, req.Frame, nil, err)
+	if err != nil {
+		return nil, encodeGRPCError(err)
+	}
+
+	// Send the create frame message to all nodes.
+	if err := h.Broadcaster.SendSync(&internal.CreateFrameMessage{
+		Index: req.Index,
+		Frame: req.Frame,
+		Meta:  opt.Encode(),
+	}); err != nil {
+		h.logger().Printf("problem sending CreateFrame message: %s", err)
+	}
+
+	return &internal.CreateFrameResponse{}, nil
+}
+
+// parseQuery validates req and parses its query string.
+func (g *GRPCHandler) parseQuery(req *internal.QueryRequest) (*pql.Query, error) {
+	if req.Index == "" {
+		return nil, encodeGRPCError(ErrIndexRequired)
+	} else if req.Remote {
+		return nil, status.Error(codes.InvalidArgument, "remote queries are not supported")
+	} else if err := validateSample(req.Sample); err != nil {
+		return nil, encodeGRPCError(err)
+	}
+
+	req.Index = g.Handler.resolveAlias(req.Index)
+
+	q, err := pql.ParseString(req.Query)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return q, nil
+}
+
+// admitQuery waits until the scheduler allows a query against index to start,
+// using the priority class set in the request metadata.
+func (g *GRPCHandler) admitQuery(ctx context.Context, index string) (release func(), err error) {
+	priority, err := ParseQueryPriority(metadataValue(ctx, grpcPriorityKey))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	release, err = g.Handler.admitQuery(ctx, index, priority)
+	if err != nil {
+		return nil, encodeGRPCError(err)
+	}
+	return release, nil
+}
+
+// authorize returns an error if the caller's API key does not have perm on index.
+func (g *GRPCHandler) authorize(ctx context.Context, index string, perm Permission) error {
+	if g.Handler.AccessControl == nil {
+		return nil
+	}
+	return g.Handler.AccessControl.AuthorizeKey(apiKeyFromContext(ctx), index, perm)
+}
+
+// audit records a gRPC call in the audit log.
+func (g *GRPCHandler) audit(ctx context.Context, method, index, frame string, calls []string, err error) {
+	entry := &AuditEntry{
+		Source:   AuditSourceGRPC,
+		Endpoint: method,
+		Index:    index,
+		Frame:    frame,
+		Calls:    calls,
+	}
+	if a := g.Handler.AccessControl; a != nil {
+		if p := a.AuthenticateKey(apiKeyFromContext(ctx)); p != nil {
+			entry.Principal = p.Name
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.RemoteAddr = p.Addr.String()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	g.Handler.audit(entry)
+}
+
+// apiKeyFromContext returns the API key in the incoming gRPC metadata.
+func apiKeyFromContext(ctx context.Context) string {
+	return metadataValue(ctx, grpcAuthorizationKey)
+}
+
+// metadataValue returns the first value for key in the incoming gRPC metadata.
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	} else if a := md[key]; len(a) > 0 {
+		return a[0]
+	}
+	return ""
+}
+
+// gRPC metadata keys used to pass an API key and a query priority class.
+// Metadata keys are lowercase.
+const (
+	grpcAuthorizationKey = "authorization"
+	grpcPriorityKey      = "x-pilosa-priority"
+)
+
+// GRPCClient represents a gRPC client to a Pilosa server.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client internal.PilosaClient
+	apiKey string
+
+	priority QueryPriority
+}
+
+// NewGRPCClient returns a new instance of GRPCClient connected to host.
+// The connection uses TLS if tlsConfig is set.
+func NewGRPCClient(host string, tlsConfig *tls.Config) (*GRPCClient, error) {
+	if host == "" {
+		return nil, ErrHostRequired
+	}
+
+	opts := []grpc.DialOption{grpc.WithCodec(gogoCodec{})}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCClient{
+		conn:   conn,
+		client: internal.NewPilosaClient(conn),
+	}, nil
+}
+
+// Close closes the connection to the server.
+func (c *GRPCClient) Close() error { return c.conn.Close() }
+
+// SetAPIKey configures the client to send key with each request.
+func (c *GRPCClient) SetAPIKey(key string) { c.apiKey = key }
+
+// SetPriority configures the client to send queries with priority class p.
+func (c *GRPCClient) SetPriority(p QueryPriority) { c.priority = p }
+
+// context returns ctx with the API key and priority class attached, if set.
+func (c *GRPCClient) context(ctx context.Context) context.Context {
+	var kv []string
+	if c.apiKey != "" {
+		kv = append(kv, grpcAuthorizationKey, "Bearer "+c.apiKey)
+	}
+	if c.priority != PriorityInteractive {
+		kv = append(kv, grpcPriorityKey, c.priority.String())
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs(kv...))
+}
+
+// Query executes query against index and returns a result for each call.
+// Slices are optional and default to all slices.
+func (c *GRPCClient) Query(ctx context.Context, index, query string, slices []uint64) ([]interface{}, error) {
+	if index == "" {
+		return nil, ErrIndexRequired
+	} else if query == "" {
+		return nil, ErrQueryRequired
+	}
+
+	// Parse locally so results can be decoded by call type.
+	q, err := pql.ParseString(query)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Query(c.context(ctx), &internal.QueryRequest{
+		Index:  index,
+		Query:  query,
+		Slices: slices,
+	})
+	if err != nil {
+		return nil, decodeGRPCError(err)
+	} else if err := decodeError(resp.Err); err != nil {
+		return nil, err
+	}
+	return decodeQueryResults(q, resp), nil
+}
+
+// StreamBitmap executes a query with a single bitmap call against index and
+// calls fn with each chunk of bits as they are received.
+func (c *GRPCClient) StreamBitmap(ctx context.Context, index, query string, fn func(bits []uint64) error) error {
+	if index == "" {
+		return ErrIndexRequired
+	} else if query == "" {
+		return ErrQueryRequired
+	}
+
+	stream, err := c.client.StreamBitmap(c.context(ctx), &internal.QueryRequest{
+		Index: index,
+		Query: query,
+	})
+	if err != nil {
+		return decodeGRPCError(err)
+	}
+
+	for {
+		pb, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return decodeGRPCError(err)
+		}
+
+		if err := fn(pb.Bits); err != nil {
+			return err
+		}
+	}
+}
+
+// Import imports bits for a single slice into a frame.
+func (c *GRPCClient) Import(ctx context.Context, index, frame string, slice uint64, bits []Bit) error {
+	if index == "" {
+		return ErrIndexRequired
+	} else if frame == "" {
+		return ErrFrameRequired
+	}
+
+	_, err := c.client.Import(c.context(ctx), &internal.ImportRequest{
+		Index:      index,
+		Frame:      frame,
+		Slice:      slice,
+		RowIDs:     Bits(bits).RowIDs(),
+		ColumnIDs:  Bits(bits).ColumnIDs(),
+		Timestamps: Bits(bits).Timestamps(),
+	})
+	return decodeGRPCError(err)
+}
+
+// Schema returns the indexes and frames on the server.
+func (c *GRPCClient) Schema(ctx context.Context) ([]*IndexInfo, error) {
+	resp, err := c.client.Schema(c.context(ctx), &internal.SchemaRequest{})
+	if err != nil {
+		return nil, decodeGRPCError(err)
+	}
+
+	a := make([]*IndexInfo, len(resp.Indexes))
+	for i, index := range resp.Indexes {
+		info := &IndexInfo{Name: index.Name}
+		for _, f := range index.Frames {
+			info.Frames = append(info.Frames, &FrameInfo{Name: f.Name})
+		}
+		a[i] = info
+	}
+	return a, nil
+}
+
+// CreateIndex creates an index with the given options.
+func (c *GRPCClient) CreateIndex(ctx context.Context, index string, opt IndexOptions) error {
+	_, err := c.client.CreateIndex(c.context(ctx), &internal.CreateIndexRequest{
+		Index: index,
+		Meta:  opt.Encode(),
+	})
+	return decodeGRPCError(err)
+}
+
+// CreateFrame creates a frame with the given options.
+func (c *GRPCClient) CreateFrame(ctx context.Context, index, frame string, opt FrameOptions) error {
+	_, err := c.client.CreateFrame(c.context(ctx), &internal.CreateFrameRequest{
+		Index: index,
+		Frame: frame,
+		Meta:  opt.Encode(),
+	})
+	return decodeGRPCError(err)
+}
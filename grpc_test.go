@@ -0,0 +1,205 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// Ensure the gRPC client and server can manage the schema, import and query.
+func TestGRPC(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Cluster = NewCluster(1)
+	h.Host = h.Cluster.Nodes[0].Host
+
+	g := pilosa.NewGRPCHandler(h.Handler)
+	g.ChunkSize = 2
+	c, closeFn := MustOpenGRPC(t, g)
+	defer closeFn()
+
+	// Create schema.
+	if err := c.CreateIndex(context.Background(), "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if err := c.CreateIndex(context.Background(), "i", pilosa.IndexOptions{}); err != pilosa.ErrIndexExists {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := c.CreateFrame(context.Background(), "i", "f", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	} else if err := c.CreateFrame(context.Background(), "x", "f", pilosa.FrameOptions{}); err != pilosa.ErrIndexNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if schema, err := c.Schema(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if len(schema) != 1 || schema[0].Name != "i" || len(schema[0].Frames) != 1 || schema[0].Frames[0].Name != "f" {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+
+	// Import into the local fragment.
+	if err := c.Import(context.Background(), "i", "f", 0, []pilosa.Bit{
+		{RowID: 1, ColumnID: 1},
+		{RowID: 1, ColumnID: 3},
+		{RowID: 1, ColumnID: 5},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if bits := hldr.Fragment("i", "f", pilosa.ViewStandard, 0).Row(1).Bits(); !reflect.DeepEqual(bits, []uint64{1, 3, 5}) {
+		t.Fatalf("unexpected bits: %v", bits)
+	}
+
+	// Query and stream results.
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if index != "i" {
+			t.Fatalf("unexpected index: %s", index)
+		}
+		if query.Calls[0].Name == "Count" {
+			return []interface{}{uint64(3)}, nil
+		}
+		return []interface{}{hldr.Fragment("i", "f", pilosa.ViewStandard, 0).Row(1)}, nil
+	}
+
+	if results, err := c.Query(context.Background(), "i", `Count(Bitmap(frame=f, rowID=1))`, nil); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(results, []interface{}{uint64(3)}) {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+
+	var chunks [][]uint64
+	if err := c.StreamBitmap(context.Background(), "i", `Bitmap(frame=f, rowID=1)`, func(bits []uint64) error {
+		chunks = append(chunks, bits)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(chunks, [][]uint64{{1, 3}, {5}}) {
+		t.Fatalf("unexpected chunks: %v", chunks)
+	}
+}
+
+// Ensure the gRPC server enforces access control.
+func TestGRPC_AccessControl(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+
+	a, err := pilosa.NewAccessControl([]*pilosa.Principal{
+		{Name: "reader", Key: "r", Indexes: map[string]pilosa.Permission{"i": pilosa.PermissionRead}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Cluster = NewCluster(1)
+	h.AccessControl = a
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return []interface{}{uint64(0)}, nil
+	}
+
+	c, closeFn := MustOpenGRPC(t, pilosa.NewGRPCHandler(h.Handler))
+	defer closeFn()
+
+	if _, err := c.Query(context.Background(), "i", `Count(Bitmap(frame=f, rowID=1))`, nil); err != pilosa.ErrUnauthenticated {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c.SetAPIKey("r")
+	if _, err := c.Query(context.Background(), "i", `Count(Bitmap(frame=f, rowID=1))`, nil); err != nil {
+		t.Fatal(err)
+	} else if _, err := c.Query(context.Background(), "i", `SetBit(frame=f, rowID=1, columnID=1)`, nil); err != pilosa.ErrPermissionDenied {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := c.CreateIndex(context.Background(), "j", pilosa.IndexOptions{}); err != pilosa.ErrPermissionDenied {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure the gRPC server schedules queries using the priority in the request metadata.
+func TestGRPC_Priority(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Cluster = NewCluster(1)
+	h.Scheduler = pilosa.NewQueryScheduler(1)
+	started := make(chan string, 2)
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		started <- query.String()
+		return []interface{}{uint64(0)}, nil
+	}
+
+	batch, closeBatch := MustOpenGRPC(t, pilosa.NewGRPCHandler(h.Handler))
+	defer closeBatch()
+	batch.SetPriority(pilosa.PriorityBatch)
+	interactive, closeInteractive := MustOpenGRPC(t, pilosa.NewGRPCHandler(h.Handler))
+	defer closeInteractive()
+
+	// Hold the only slot while queueing a batch query then an interactive query.
+	release, err := h.Scheduler.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	query := func(c *pilosa.GRPCClient, q string) {
+		defer wg.Done()
+		if _, err := c.Query(context.Background(), "i", q, nil); err != nil {
+			t.Error(err)
+		}
+	}
+	wg.Add(2)
+	go query(batch, `Count(Bitmap(frame=f, rowID=1))`)
+	time.Sleep(50 * time.Millisecond)
+	go query(interactive, `Count(Bitmap(frame=f, rowID=2))`)
+	time.Sleep(50 * time.Millisecond)
+
+	release()
+	if q := <-started; q != `Count(Bitmap(frame="f", rowID=2))` {
+		t.Fatalf("unexpected first query: %s", q)
+	} else if q := <-started; q != `Count(Bitmap(frame="f", rowID=1))` {
+		t.Fatalf("unexpected second query: %s", q)
+	}
+	wg.Wait()
+}
+
+// MustOpenGRPC serves g on a random port and returns a connected client.
+func MustOpenGRPC(t *testing.T, g *pilosa.GRPCHandler) (*pilosa.GRPCClient, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := g.NewServer(nil)
+	go s.Serve(ln)
+
+	c, err := pilosa.NewGRPCClient(ln.Addr().String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close()
+		s.Stop()
+	}
+}
@@ -50,6 +50,9 @@ type Handler struct {
 	Broadcaster   Broadcaster
 	StatusHandler StatusHandler
 
+	// Reports recent anti-entropy runs.
+	AntiEntropyHandler AntiEntropyHandler
+
 	// Local hostname & cluster configuration.
 	Host    string
 	Cluster *Cluster
@@ -61,6 +64,15 @@ type Handler struct {
 		Execute(context context.Context, index string, query *pql.Query, slices []uint64, opt *ExecOptions) ([]interface{}, error)
 	}
 
+	// Authorizes client requests. Access is not restricted if nil.
+	AccessControl *AccessControl
+
+	// Queues client queries per index. Queries start immediately if nil.
+	Scheduler *QueryScheduler
+
+	// Records schema changes and write queries.
+	AuditLogger AuditLogger
+
 	// The version to report on the /version endpoint.
 	Version string
 
@@ -71,7 +83,8 @@ type Handler struct {
 // NewHandler returns a new instance of Handler with a default logger.
 func NewHandler() *Handler {
 	handler := &Handler{
-		LogOutput: os.Stderr,
+		AuditLogger: NopAuditLogger,
+		LogOutput:   os.Stderr,
 	}
 	handler.Router = NewRouter(handler)
 	return handler
@@ -82,33 +95,44 @@ func NewRouter(handler *Handler) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/", handler.handleWebUI).Methods("GET")
 	router.HandleFunc("/assets/{file}", handler.handleWebUI).Methods("GET")
-	router.HandleFunc("/index", handler.handleGetIndexes).Methods("GET")
-	router.HandleFunc("/index/{index}", handler.handleGetIndex).Methods("GET")
-	router.HandleFunc("/index/{index}", handler.handlePostIndex).Methods("POST")
-	router.HandleFunc("/index/{index}", handler.handleDeleteIndex).Methods("DELETE")
-	router.HandleFunc("/index/{index}/attr/diff", handler.handlePostIndexAttrDiff).Methods("POST")
-	//router.HandleFunc("/index/{index}/frame", handler.handleGetFrames).Methods("GET") // Not implemented.
-	router.HandleFunc("/index/{index}/frame/{frame}", handler.handlePostFrame).Methods("POST")
-	router.HandleFunc("/index/{index}/frame/{frame}", handler.handleDeleteFrame).Methods("DELETE")
+	router.HandleFunc("/alias", handler.requirePermission(PermissionNone, handler.handleGetAliases)).Methods("GET")
+	router.HandleFunc("/alias/{alias}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePutAlias))).Methods("PUT")
+	router.HandleFunc("/alias/{alias}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handleDeleteAlias))).Methods("DELETE")
+	router.HandleFunc("/index", handler.requirePermission(PermissionNone, handler.handleGetIndexes)).Methods("GET")
+	router.HandleFunc("/index/{index}", handler.requirePermission(PermissionRead, handler.handleGetIndex)).Methods("GET")
+	router.HandleFunc("/index/{index}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePostIndex))).Methods("POST")
+	router.HandleFunc("/index/{index}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handleDeleteIndex))).Methods("DELETE")
+	router.HandleFunc("/index/{index}/attr/diff", handler.requirePermission(PermissionRead, handler.handlePostIndexAttrDiff)).Methods("POST")
+	router.HandleFunc("/index/{index}/attr/import", handler.audited(handler.requirePermission(PermissionWrite, handler.handlePostIndexAttrImport))).Methods("POST")
+	router.HandleFunc("/index/{index}/frame", handler.requirePermission(PermissionRead, handler.handleGetFrames)).Methods("GET")
+	router.HandleFunc("/index/{index}/frame/{frame}", handler.requirePermission(PermissionRead, handler.handleGetFrame)).Methods("GET")
+	router.HandleFunc("/index/{index}/frame/{frame}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePostFrame))).Methods("POST")
+	router.HandleFunc("/index/{index}/frame/{frame}", handler.audited(handler.requirePermission(PermissionAdmin, handler.handleDeleteFrame))).Methods("DELETE")
 	router.HandleFunc("/index/{index}/query", handler.handlePostQuery).Methods("POST")
-	router.HandleFunc("/index/{index}/frame/{frame}/attr/diff", handler.handlePostFrameAttrDiff).Methods("POST")
-	router.HandleFunc("/index/{index}/frame/{frame}/restore", handler.handlePostFrameRestore).Methods("POST")
-	router.HandleFunc("/index/{index}/frame/{frame}/time-quantum", handler.handlePatchFrameTimeQuantum).Methods("PATCH")
-	router.HandleFunc("/index/{index}/frame/{frame}/views", handler.handleGetFrameViews).Methods("GET")
-	router.HandleFunc("/index/{index}/time-quantum", handler.handlePatchIndexTimeQuantum).Methods("PATCH")
-	router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux).Methods("GET")
-	router.HandleFunc("/debug/vars", handler.handleExpvar).Methods("GET")
-	router.HandleFunc("/export", handler.handleGetExport).Methods("GET")
-	router.HandleFunc("/fragment/block/data", handler.handleGetFragmentBlockData).Methods("GET")
-	router.HandleFunc("/fragment/blocks", handler.handleGetFragmentBlocks).Methods("GET")
-	router.HandleFunc("/fragment/data", handler.handleGetFragmentData).Methods("GET")
-	router.HandleFunc("/fragment/data", handler.handlePostFragmentData).Methods("POST")
-	router.HandleFunc("/fragment/nodes", handler.handleGetFragmentNodes).Methods("GET")
+	router.HandleFunc("/index/{index}/frame/{frame}/attr/diff", handler.requirePermission(PermissionRead, handler.handlePostFrameAttrDiff)).Methods("POST")
+	router.HandleFunc("/index/{index}/frame/{frame}/attr/import", handler.audited(handler.requirePermission(PermissionWrite, handler.handlePostFrameAttrImport))).Methods("POST")
+	router.HandleFunc("/index/{index}/frame/{frame}/restore", handler.audited(handler.requirePermission(PermissionAdmin, handler.requireNodeAuth(handler.handlePostFrameRestore)))).Methods("POST")
+	router.HandleFunc("/index/{index}/frame/{frame}/cache", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePatchFrameCache))).Methods("PATCH")
+	router.HandleFunc("/index/{index}/frame/{frame}/inverse", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePatchFrameInverse))).Methods("PATCH")
+	router.HandleFunc("/index/{index}/frame/{frame}/time-quantum", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePatchFrameTimeQuantum))).Methods("PATCH")
+	router.HandleFunc("/index/{index}/frame/{frame}/stats", handler.requirePermission(PermissionRead, handler.handleGetFrameStats)).Methods("GET")
+	router.HandleFunc("/index/{index}/frame/{frame}/views", handler.requirePermission(PermissionRead, handler.handleGetFrameViews)).Methods("GET")
+	router.HandleFunc("/index/{index}/time-quantum", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePatchIndexTimeQuantum))).Methods("PATCH")
+	router.HandleFunc("/debug/anti-entropy", handler.requirePermission(PermissionAdmin, handler.handleGetAntiEntropy)).Methods("GET")
+	router.PathPrefix("/debug/pprof/").Handler(handler.requirePermission(PermissionAdmin, http.DefaultServeMux.ServeHTTP)).Methods("GET")
+	router.HandleFunc("/debug/vars", handler.requirePermission(PermissionAdmin, handler.handleExpvar)).Methods("GET")
+	router.HandleFunc("/export", handler.requirePermission(PermissionRead, handler.handleGetExport)).Methods("GET")
+	router.HandleFunc("/fragment/block/data", handler.requirePermission(PermissionAdmin, handler.requireNodeAuth(handler.handleGetFragmentBlockData))).Methods("GET")
+	router.HandleFunc("/fragment/blocks", handler.requirePermission(PermissionRead, handler.requireNodeAuth(handler.handleGetFragmentBlocks))).Methods("GET")
+	router.HandleFunc("/fragment/data", handler.requirePermission(PermissionRead, handler.handleGetFragmentData)).Methods("GET")
+	router.HandleFunc("/fragment/data", handler.requirePermission(PermissionAdmin, handler.requireNodeAuth(handler.handlePostFragmentData))).Methods("POST")
+	router.HandleFunc("/fragment/nodes", handler.requirePermission(PermissionNone, handler.handleGetFragmentNodes)).Methods("GET")
 	router.HandleFunc("/import", handler.handlePostImport).Methods("POST")
-	router.HandleFunc("/hosts", handler.handleGetHosts).Methods("GET")
-	router.HandleFunc("/schema", handler.handleGetSchema).Methods("GET")
-	router.HandleFunc("/slices/max", handler.handleGetSliceMax).Methods("GET")
-	router.HandleFunc("/status", handler.handleGetStatus).Methods("GET")
+	router.HandleFunc("/hosts", handler.requirePermission(PermissionNone, handler.handleGetHosts)).Methods("GET")
+	router.HandleFunc("/schema", handler.requirePermission(PermissionNone, handler.handleGetSchema)).Methods("GET")
+	router.HandleFunc("/schema", handler.audited(handler.requirePermission(PermissionAdmin, handler.handlePutSchema))).Methods("PUT")
+	router.HandleFunc("/slices/max", handler.requirePermission(PermissionNone, handler.handleGetSliceMax)).Methods("GET")
+	router.HandleFunc("/status", handler.requirePermission(PermissionNone, handler.handleGetStatus)).Methods("GET")
 	router.HandleFunc("/version", handler.handleGetVersion).Methods("GET")
 
 	// TODO: Apply MethodNotAllowed statuses to all endpoints.
@@ -124,6 +148,159 @@ func (h *Handler) methodNotAllowedHandler(w http.ResponseWriter, r *http.Request
 	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 }
 
+// requireNodeAuth wraps fn so that it can only be called by other nodes
+// when the cluster has a shared secret configured.
+func (h *Handler) requireNodeAuth(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.verifyNodeRequest(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		fn(w, r)
+	}
+}
+
+// requirePermission wraps fn so that it can only be called by principals
+// with at least perm on the request's index. The index is read from the
+// route or the "index" query parameter. Requests without an index
+// require perm on all indexes, except for PermissionNone which only
+// requires a valid API key.
+func (h *Handler) requirePermission(perm Permission, fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.authorize(r, h.isNodeRequest(r), h.indexName(r), perm); err != nil {
+			http.Error(w, err.Error(), authErrorStatus(err))
+			return
+		}
+		fn(w, r)
+	}
+}
+
+// indexName returns the index name from the route or the "index" query
+// parameter with aliases resolved.
+func (h *Handler) indexName(r *http.Request) string {
+	index := mux.Vars(r)["index"]
+	if index == "" {
+		index = r.URL.Query().Get("index")
+	}
+	return h.resolveAlias(index)
+}
+
+// literalIndexName returns the index name from the route without resolving
+// aliases. Returns an error if the name is an alias so that creating or
+// deleting an index never acts on the index behind an alias.
+func (h *Handler) literalIndexName(r *http.Request) (string, error) {
+	name := mux.Vars(r)["index"]
+	if h.resolveAlias(name) != name {
+		return "", fmt.Errorf("%s is an alias, use DELETE /alias/%s to remove it", name, name)
+	}
+	return name, nil
+}
+
+// resolveAlias returns the index that name refers to.
+func (h *Handler) resolveAlias(name string) string {
+	if h.Holder == nil {
+		return name
+	}
+	return h.Holder.ResolveAlias(name)
+}
+
+// authorize returns an error if the request is not allowed perm on index.
+// Requests from other nodes are always allowed.
+func (h *Handler) authorize(r *http.Request, fromNode bool, index string, perm Permission) error {
+	if h.AccessControl == nil || fromNode {
+		return nil
+	}
+	return h.AccessControl.Authorize(r, index, perm)
+}
+
+// audited wraps fn so that each request is recorded in the audit log.
+func (h *Handler) audited(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal := h.principalName(r, h.isNodeRequest(r))
+
+		sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		fn(sw, r)
+
+		vars := mux.Vars(r)
+		h.audit(&AuditEntry{
+			Source:     AuditSourceHTTP,
+			Principal:  principal,
+			RemoteAddr: r.RemoteAddr,
+			Method:     r.Method,
+			Endpoint:   r.URL.Path,
+			Index:      vars["index"],
+			Frame:      vars["frame"],
+			Alias:      vars["alias"],
+			Status:     sw.status,
+		})
+	}
+}
+
+// audit writes entry to the audit log.
+func (h *Handler) audit(entry *AuditEntry) {
+	if err := h.AuditLogger.Log(entry); err != nil {
+		h.logger().Printf("audit log error: %s", err)
+	}
+}
+
+// principalName returns the name of the caller for the audit log.
+func (h *Handler) principalName(r *http.Request, fromNode bool) string {
+	if fromNode {
+		return "node"
+	} else if h.AccessControl == nil {
+		return ""
+	} else if p := h.AccessControl.Authenticate(r); p != nil {
+		return p.Name
+	}
+	return ""
+}
+
+// statusResponseWriter records the status code written to a response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code and writes it to the response.
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// isNodeRequest returns true if the request is signed by another node.
+func (h *Handler) isNodeRequest(r *http.Request) bool {
+	if len(h.secret()) == 0 || r.Header.Get(HeaderNodeSignature) == "" {
+		return false
+	}
+	return h.verifyNodeRequest(r) == nil
+}
+
+// authErrorStatus returns the HTTP status code for an authorization error.
+func authErrorStatus(err error) int {
+	if err == ErrPermissionDenied {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+// verifyNodeRequest returns an error if the cluster has a shared secret
+// and the request is not signed with it.
+func (h *Handler) verifyNodeRequest(r *http.Request) error {
+	secret := h.secret()
+	if len(secret) == 0 {
+		return nil
+	}
+	return VerifyRequest(r, secret, time.Now())
+}
+
+// secret returns the cluster's shared secret, if any.
+func (h *Handler) secret() []byte {
+	if h.Cluster == nil {
+		return nil
+	}
+	return h.Cluster.Secret
+}
+
 // ServeHTTP handles an HTTP request.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.Router.ServeHTTP(w, r)
@@ -147,12 +324,117 @@ func (h *Handler) handleWebUI(w http.ResponseWriter, r *http.Request) {
 // handleGetSchema handles GET /schema requests.
 func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 	if err := json.NewEncoder(w).Encode(getSchemaResponse{
-		Indexes: h.Holder.Schema(),
+		Indexes: h.readableIndexes(r, h.Holder.Schema()),
 	}); err != nil {
 		h.logger().Printf("write schema response error: %s", err)
 	}
 }
 
+// handlePutSchema handles PUT /schema requests.
+//
+// The request body contains the full desired schema. Missing indexes and
+// frames are created and changed options are updated. Indexes and frames
+// which are not in the schema are deleted only if "destructive" is set.
+// If "dryRun" is set then the changes are returned but not applied.
+func (h *Handler) handlePutSchema(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	destructive, _ := strconv.ParseBool(q.Get("destructive"))
+	dryRun, _ := strconv.ParseBool(q.Get("dryRun"))
+
+	// Decode request.
+	var def SchemaDefinition
+	if err := json.NewDecoder(r.Body).Decode(&def); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Determine the changes required to match the requested schema.
+	changes, err := h.Holder.DiffSchema(&def)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := putSchemaResponse{Changes: changes}
+
+	// Refuse the whole schema if it deletes data without permission.
+	status := http.StatusOK
+	for _, c := range changes {
+		if c.Destructive && !destructive {
+			resp.Error, status = ErrSchemaChangeDestructive.Error(), http.StatusConflict
+			break
+		}
+	}
+
+	// Apply changes in order and notify the rest of the cluster. If a change
+	// fails, the response contains the changes already applied and the
+	// change which failed.
+	if status == http.StatusOK && !dryRun {
+		for i, c := range changes {
+			if err := h.Holder.ApplySchemaChange(c); err != nil {
+				failed := c
+				resp = putSchemaResponse{Changes: changes[:i], Failed: &failed}
+				resp.Error, status = fmt.Sprintf("%s: %s", c, err), http.StatusInternalServerError
+				break
+			}
+			if c.Action == SchemaActionDeleteIndex {
+				h.Scheduler.DeleteIndex(c.Index)
+			}
+			h.broadcastSchemaChange(c)
+
+			if c.Action == SchemaActionUpdateFrame && c.Option == "inverseEnabled" {
+				backfillInverse(h.Holder.Frame(c.Index, c.Frame), h.logger())
+			}
+		}
+	}
+
+	// Encode response.
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+// broadcastSchemaChange sends the message for a schema change to all nodes.
+func (h *Handler) broadcastSchemaChange(c SchemaChange) {
+	var msg proto.Message
+	switch c.Action {
+	case SchemaActionCreateIndex:
+		msg = &internal.CreateIndexMessage{Index: c.Index, Meta: c.IndexOptions.Encode()}
+	case SchemaActionDeleteIndex:
+		msg = &internal.DeleteIndexMessage{Index: c.Index}
+	case SchemaActionCreateFrame:
+		msg = &internal.CreateFrameMessage{Index: c.Index, Frame: c.Frame, Meta: c.FrameOptions.Encode()}
+	case SchemaActionDeleteFrame:
+		msg = &internal.DeleteFrameMessage{Index: c.Index, Frame: c.Frame}
+	case SchemaActionUpdateIndex:
+		msg = &internal.UpdateIndexMessage{Index: c.Index, Option: c.Option, Value: c.Value}
+	case SchemaActionUpdateFrame:
+		msg = &internal.UpdateFrameMessage{Index: c.Index, Frame: c.Frame, Option: c.Option, Value: c.Value}
+	default:
+		return
+	}
+
+	if err := h.Broadcaster.SendSync(msg); err != nil {
+		h.logger().Printf("problem sending %s message: %s", c.Action, err)
+	}
+}
+
+// readableIndexes filters indexes to those the request has read access to.
+func (h *Handler) readableIndexes(r *http.Request, indexes []*IndexInfo) []*IndexInfo {
+	if h.AccessControl == nil || h.isNodeRequest(r) {
+		return indexes
+	}
+
+	p := h.AccessControl.Authenticate(r)
+	other := make([]*IndexInfo, 0, len(indexes))
+	for _, index := range indexes {
+		if p != nil && p.Allowed(index.Name, PermissionRead) {
+			other = append(other, index)
+		}
+	}
+	return other
+}
+
 // handleGetStatus handles GET /status requests.
 func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 	status, err := h.StatusHandler.ClusterStatus()
@@ -167,29 +449,71 @@ func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetAntiEntropy handles GET /debug/anti-entropy requests.
+func (h *Handler) handleGetAntiEntropy(w http.ResponseWriter, r *http.Request) {
+	runs := []SyncStats{}
+	if h.AntiEntropyHandler != nil {
+		runs = h.AntiEntropyHandler.AntiEntropyStats()
+	}
+	if err := json.NewEncoder(w).Encode(getAntiEntropyResponse{
+		Runs: runs,
+	}); err != nil {
+		h.logger().Printf("write anti-entropy response error: %s", err)
+	}
+}
+
+type getAntiEntropyResponse struct {
+	Runs []SyncStats `json:"runs"`
+}
+
 type getSchemaResponse struct {
 	Indexes []*IndexInfo `json:"indexes"`
 }
 
+type putSchemaResponse struct {
+	Changes []SchemaChange `json:"changes"`
+	Failed  *SchemaChange  `json:"failed,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
 type getStatusResponse struct {
 	Status proto.Message `json:"status"`
 }
 
 // handlePostQuery handles /query requests.
 func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
+
+	// Verify signature before the body is read.
+	authErr := h.verifyNodeRequest(r)
+	fromNode := authErr == nil && len(h.secret()) > 0
 
 	// Parse incoming request.
 	req, err := h.readQueryRequest(r)
+	if err == nil {
+		err = validateSample(req.Sample)
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		h.writeQueryResponse(w, r, &QueryResponse{Err: err})
 		return
 	}
 
-	// Build execution options.
+	// Remote queries can only be sent by other nodes.
+	if req.Remote && authErr != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		h.writeQueryResponse(w, r, &QueryResponse{Err: authErr})
+		return
+	}
+
+	// Build execution options. Client limits still apply to remote queries
+	// which aren't signed by another node.
 	opt := &ExecOptions{
-		Remote: req.Remote,
+		Remote:     req.Remote,
+		Unverified: req.Remote && !fromNode,
+		Explain:    req.Explain,
+		Analyze:    req.Analyze,
+		Sample:     req.Sample,
 	}
 
 	// Parse query string.
@@ -200,8 +524,58 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Read-only principals cannot execute write calls.
+	// Explaining a query without analyzing it doesn't execute any calls.
+	perm := QueryPermission(q)
+	if req.Explain && !req.Analyze {
+		perm = PermissionRead
+	}
+	if err := h.authorize(r, fromNode, indexName, perm); err != nil {
+		if perm == PermissionWrite {
+			h.auditQuery(r, fromNode, indexName, q, authErrorStatus(err), err)
+		}
+		w.WriteHeader(authErrorStatus(err))
+		h.writeQueryResponse(w, r, &QueryResponse{Err: err})
+		return
+	}
+
+	// Limit the execution time if requested.
+	ctx := r.Context()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	// Wait for a slot in the index's queue. Queries sent by other nodes are
+	// part of a query which has already been admitted.
+	if opt.limited() {
+		priority, err := ParseQueryPriority(r.Header.Get(HeaderQueryPriority))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			h.writeQueryResponse(w, r, &QueryResponse{Err: err})
+			return
+		}
+		release, err := h.admitQuery(ctx, indexName, priority)
+		if err != nil {
+			w.WriteHeader(queryErrorStatus(err))
+			h.writeQueryResponse(w, r, &QueryResponse{Err: err})
+			return
+		}
+		defer release()
+	}
+
 	// Execute the query.
-	results, err := h.Executor.Execute(r.Context(), indexName, q, req.Slices, opt)
+	results, err := h.Executor.Execute(ctx, indexName, q, req.Slices, opt)
+
+	// Record write queries. Remote queries are recorded by the originating node.
+	if perm == PermissionWrite && !req.Remote {
+		status := http.StatusOK
+		if err != nil {
+			status = queryErrorStatus(err)
+		}
+		h.auditQuery(r, fromNode, indexName, q, status, err)
+	}
 	resp := &QueryResponse{Results: results, Err: err}
 
 	// Fill column attributes if requested.
@@ -228,7 +602,7 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 
 	// Set appropriate status code, if there is an error.
 	if resp.Err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(queryErrorStatus(resp.Err))
 	}
 
 	// Write response back to client.
@@ -263,6 +637,117 @@ type sliceMaxResponse struct {
 	MaxSlices map[string]uint64 `json:"maxSlices"`
 }
 
+// auditQuery records the write calls in q to the audit log.
+func (h *Handler) auditQuery(r *http.Request, fromNode bool, index string, q *pql.Query, status int, err error) {
+	entry := &AuditEntry{
+		Source:     AuditSourceHTTP,
+		Principal:  h.principalName(r, fromNode),
+		RemoteAddr: r.RemoteAddr,
+		Method:     r.Method,
+		Endpoint:   r.URL.Path,
+		Index:      index,
+		Calls:      writeCallStrings(q),
+		Status:     status,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	h.audit(entry)
+}
+
+// handleGetAliases handles GET /alias requests.
+func (h *Handler) handleGetAliases(w http.ResponseWriter, r *http.Request) {
+	if err := json.NewEncoder(w).Encode(getAliasesResponse{
+		Aliases: h.Holder.Aliases(),
+	}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type getAliasesResponse struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+// handlePutAlias handles PUT /alias/<alias> requests.
+//
+// Creates the alias or atomically points an existing alias at a new index.
+func (h *Handler) handlePutAlias(w http.ResponseWriter, r *http.Request) {
+	alias := mux.Vars(r)["alias"]
+
+	// Decode request.
+	var req putAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Point alias at index.
+	if err := h.Holder.SetAlias(alias, req.Index); err == ErrIndexNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err == ErrIndexExists {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	} else if err == ErrName {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the set alias message to all nodes.
+	err := h.Broadcaster.SendSync(
+		&internal.SetAliasMessage{
+			Alias: alias,
+			Index: req.Index,
+		})
+	if err != nil {
+		h.logger().Printf("problem sending SetAlias message: %s", err)
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(putAliasResponse{}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type putAliasRequest struct {
+	Index string `json:"index"`
+}
+
+type putAliasResponse struct{}
+
+// handleDeleteAlias handles DELETE /alias/<alias> requests.
+func (h *Handler) handleDeleteAlias(w http.ResponseWriter, r *http.Request) {
+	alias := mux.Vars(r)["alias"]
+
+	// Remove alias from the holder.
+	if err := h.Holder.DeleteAlias(alias); err == ErrAliasNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Send the delete alias message to all nodes.
+	err := h.Broadcaster.SendSync(
+		&internal.DeleteAliasMessage{
+			Alias: alias,
+		})
+	if err != nil {
+		h.logger().Printf("problem sending DeleteAlias message: %s", err)
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(deleteAliasResponse{}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type deleteAliasResponse struct{}
+
 // handleGetIndexes handles GET /index request.
 func (h *Handler) handleGetIndexes(w http.ResponseWriter, r *http.Request) {
 	h.handleGetSchema(w, r)
@@ -270,29 +755,35 @@ func (h *Handler) handleGetIndexes(w http.ResponseWriter, r *http.Request) {
 
 // handleGetIndex handles GET /index/<indexname> requests.
 func (h *Handler) handleGetIndex(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	index := h.Holder.Index(indexName)
 	if index == nil {
 		http.Error(w, ErrIndexNotFound.Error(), http.StatusNotFound)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(getIndexResponse{
-		map[string]string{"name": index.Name()},
-	}); err != nil {
+	// Statistics require a scan of every fragment so they are only
+	// included when requested.
+	summary, err := index.Summary(r.URL.Query().Get("summary") == "true")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(getIndexResponse{summary}); err != nil {
 		h.logger().Printf("write response error: %s", err)
 	}
 }
 
 type getIndexResponse struct {
-	Index map[string]string `json:"index"`
+	Index IndexSummary `json:"index"`
 }
 
 type postIndexRequest struct {
 	Options IndexOptions `json:"options"`
 }
 
-//_postIndexRequest is necessary to avoid recursion while decoding.
+// _postIndexRequest is necessary to avoid recursion while decoding.
 type _postIndexRequest postIndexRequest
 
 // Custom Unmarshal JSON to validate request body when creating a new index.
@@ -354,16 +845,21 @@ type postIndexResponse struct{}
 
 // handleDeleteIndex handles DELETE /index request.
 func (h *Handler) handleDeleteIndex(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName, err := h.literalIndexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Delete index from the holder.
 	if err := h.Holder.DeleteIndex(indexName); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.Scheduler.DeleteIndex(indexName)
 
 	// Send the delete index message to all nodes.
-	err := h.Broadcaster.SendSync(
+	err = h.Broadcaster.SendSync(
 		&internal.DeleteIndexMessage{
 			Index: indexName,
 		})
@@ -381,11 +877,15 @@ type deleteIndexResponse struct{}
 
 // handlePostIndex handles POST /index request.
 func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName, err := h.literalIndexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Decode request.
 	var req postIndexRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err = json.NewDecoder(r.Body).Decode(&req)
 	if err == io.EOF {
 		// If no data was provided (EOF), we still create the index
 		// with default values.
@@ -399,6 +899,9 @@ func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
 	if err == ErrIndexExists {
 		http.Error(w, err.Error(), http.StatusConflict)
 		return
+	} else if err == ErrInvalidReplicaN {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	} else if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -422,7 +925,7 @@ func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
 
 // handlePatchIndexTimeQuantum handles PATCH /index/time_quantum request.
 func (h *Handler) handlePatchIndexTimeQuantum(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 
 	// Decode request.
 	var req patchIndexTimeQuantumRequest
@@ -465,7 +968,7 @@ type patchIndexTimeQuantumResponse struct{}
 
 // handlePostIndexAttrDiff handles POST /index/attr/diff requests.
 func (h *Handler) handlePostIndexAttrDiff(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 
 	// Decode request.
 	var req postIndexAttrDiffRequest
@@ -522,7 +1025,7 @@ type postIndexAttrDiffResponse struct {
 
 // handlePostFrame handles POST /frame request.
 func (h *Handler) handlePostFrame(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	// Decode request.
@@ -548,6 +1051,9 @@ func (h *Handler) handlePostFrame(w http.ResponseWriter, r *http.Request) {
 	if err == ErrFrameExists {
 		http.Error(w, err.Error(), http.StatusConflict)
 		return
+	} else if err == ErrCacheWindowRequired || err == ErrCacheHalfLifeRequired {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	} else if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -617,7 +1123,7 @@ type postFrameResponse struct{}
 
 // handleDeleteFrame handles DELETE /frame request.
 func (h *Handler) handleDeleteFrame(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	// Find index.
@@ -655,7 +1161,7 @@ type deleteFrameResponse struct{}
 
 // handlePatchFrameTimeQuantum handles PATCH /frame/time_quantum request.
 func (h *Handler) handlePatchFrameTimeQuantum(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	// Decode request.
@@ -697,9 +1203,203 @@ type patchFrameTimeQuantumRequest struct {
 
 type patchFrameTimeQuantumResponse struct{}
 
+// handlePatchFrameCache handles PATCH /index/<indexname>/frame/<framename>/cache requests.
+func (h *Handler) handlePatchFrameCache(w http.ResponseWriter, r *http.Request) {
+	indexName := h.indexName(r)
+	frameName := mux.Vars(r)["frame"]
+
+	// Decode request.
+	var req patchFrameCacheRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Validate cache type.
+	if req.CacheType != "" && !IsValidCacheType(req.CacheType) {
+		http.Error(w, ErrInvalidCacheType.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Retrieve frame by name.
+	f := h.Holder.Frame(indexName, frameName)
+	if f == nil {
+		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	// Validate the resulting settings before any are applied.
+	opt := f.Options()
+	if req.CacheType != "" {
+		opt.CacheType = req.CacheType
+	}
+	if req.CacheWindow > 0 {
+		opt.CacheWindow = req.CacheWindow
+	}
+	if req.CacheHalfLife > 0 {
+		opt.CacheHalfLife = req.CacheHalfLife
+	}
+	if err := validateCacheOptions(opt.CacheType, time.Duration(opt.CacheWindow), time.Duration(opt.CacheHalfLife), opt.TimeQuantum); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Update cache settings and notify the rest of the cluster. Settings
+	// required by the cache type are applied first.
+	for _, c := range diffFrameOptions(indexName, f, FrameOptions{
+		CacheType:     req.CacheType,
+		CacheSize:     req.CacheSize,
+		CacheInterval: req.CacheInterval,
+		CacheWindow:   req.CacheWindow,
+		CacheHalfLife: req.CacheHalfLife,
+	}) {
+		if err := h.Holder.ApplySchemaChange(c); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.broadcastSchemaChange(c)
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(patchFrameCacheResponse{}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type patchFrameCacheRequest struct {
+	CacheType     string   `json:"cacheType"`
+	CacheSize     uint32   `json:"cacheSize"`
+	CacheInterval Duration `json:"cacheInterval"`
+	CacheWindow   Duration `json:"cacheWindow"`
+	CacheHalfLife Duration `json:"cacheHalfLife"`
+}
+
+type patchFrameCacheResponse struct{}
+
+// handlePatchFrameInverse handles PATCH /index/<indexname>/frame/<framename>/inverse requests.
+//
+// Enabling inverse storage returns immediately while the inverse views are
+// filled from the standard views in the background.
+func (h *Handler) handlePatchFrameInverse(w http.ResponseWriter, r *http.Request) {
+	indexName := h.indexName(r)
+	frameName := mux.Vars(r)["frame"]
+
+	// Decode request.
+	var req patchFrameInverseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Retrieve frame by name.
+	f := h.Holder.Frame(indexName, frameName)
+	if f == nil {
+		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	// Inverse storage cannot be removed once enabled.
+	if !req.InverseEnabled {
+		if f.InverseEnabled() {
+			http.Error(w, ErrFrameInverseEnabled.Error(), http.StatusBadRequest)
+			return
+		}
+	} else if !f.InverseEnabled() {
+		if err := f.EnableInverse(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.broadcastSchemaChange(SchemaChange{
+			Action: SchemaActionUpdateFrame,
+			Index:  indexName,
+			Frame:  frameName,
+			Option: "inverseEnabled",
+			Value:  "true",
+		})
+		backfillInverse(f, h.logger())
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(patchFrameInverseResponse{}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type patchFrameInverseRequest struct {
+	InverseEnabled bool `json:"inverseEnabled"`
+}
+
+type patchFrameInverseResponse struct{}
+
+// handleGetFrames handles GET /index/<indexname>/frame requests.
+func (h *Handler) handleGetFrames(w http.ResponseWriter, r *http.Request) {
+	index := h.Holder.Index(h.indexName(r))
+	if index == nil {
+		http.Error(w, ErrIndexNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	frames := index.Frames()
+	defs := make([]FrameDefinition, len(frames))
+	for i, f := range frames {
+		defs[i] = FrameDefinition{Name: f.Name(), Options: f.Options()}
+	}
+
+	if err := json.NewEncoder(w).Encode(getFramesResponse{Frames: defs}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type getFramesResponse struct {
+	Frames []FrameDefinition `json:"frames"`
+}
+
+// handleGetFrame handles GET /index/<indexname>/frame/<framename> requests.
+func (h *Handler) handleGetFrame(w http.ResponseWriter, r *http.Request) {
+	f := h.Holder.Frame(h.indexName(r), mux.Vars(r)["frame"])
+	if f == nil {
+		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(getFrameResponse{
+		Frame: FrameDefinition{Name: f.Name(), Options: f.Options()},
+	}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type getFrameResponse struct {
+	Frame FrameDefinition `json:"frame"`
+}
+
+// handleGetFrameStats handles GET /index/<indexname>/frame/<framename>/stats requests.
+// Statistics only include fragments stored on this node.
+func (h *Handler) handleGetFrameStats(w http.ResponseWriter, r *http.Request) {
+	f := h.Holder.Frame(h.indexName(r), mux.Vars(r)["frame"])
+	if f == nil {
+		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	stats, err := f.Stats()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(getFrameStatsResponse{Stats: stats}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type getFrameStatsResponse struct {
+	Stats FrameStats `json:"stats"`
+}
+
 // handleGetFrameViews handles GET /frame/views request.
 func (h *Handler) handleGetFrameViews(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	// Retrieve views.
@@ -728,7 +1428,7 @@ type getFrameViewsResponse struct {
 
 // handlePostFrameAttrDiff handles POST /frame/attr/diff requests.
 func (h *Handler) handlePostFrameAttrDiff(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	// Decode request.
@@ -817,6 +1517,42 @@ func (h *Handler) readQueryRequest(r *http.Request) (*QueryRequest, error) {
 	}
 }
 
+// admitQuery waits until the scheduler allows a query against index to start.
+// The returned function must be called once the query finishes.
+func (h *Handler) admitQuery(ctx context.Context, index string, priority QueryPriority) (release func(), err error) {
+	if h.Scheduler == nil {
+		return func() {}, nil
+	}
+	release, err = h.Scheduler.Acquire(ctx, index, priority)
+	switch err {
+	case context.DeadlineExceeded:
+		return nil, ErrQueryTimeout
+	case context.Canceled:
+		return nil, ErrQueryCanceled
+	}
+	return release, err
+}
+
+// StatusClientClosedRequest is the non-standard status code returned when the
+// client cancels a query before it finishes.
+const StatusClientClosedRequest = 499
+
+// queryErrorStatus returns the HTTP status code for a query execution error.
+func queryErrorStatus(err error) int {
+	switch err {
+	case ErrTooManyQueries:
+		return http.StatusTooManyRequests
+	case ErrQueryTimeout:
+		return http.StatusGatewayTimeout
+	case ErrQueryCanceled:
+		return StatusClientClosedRequest
+	case ErrTooManyResultBits:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // readProtobufQueryRequest parses query parameters in protobuf from r.
 func (h *Handler) readProtobufQueryRequest(r *http.Request) (*QueryRequest, error) {
 	// Slurp the body.
@@ -861,11 +1597,38 @@ func (h *Handler) readURLQueryRequest(r *http.Request) (*QueryRequest, error) {
 		quantum = v
 	}
 
+	// Parse execution timeout.
+	var timeout time.Duration
+	if s := q.Get("timeout"); s != "" {
+		v, err := time.ParseDuration(s)
+		if err != nil || v < 0 {
+			return nil, errors.New("invalid timeout")
+		}
+		timeout = v
+	}
+
+	// Parse the fraction of slices to sample.
+	var sample float64
+	if s := q.Get("sample"); s != "" {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || validateSample(v) != nil || v == 0 {
+			return nil, ErrInvalidSample
+		}
+		sample = v
+	}
+
+	// Analyzing a query implies explaining it.
+	analyze := q.Get("analyze") == "true"
+
 	return &QueryRequest{
 		Query:       query,
 		Slices:      slices,
 		ColumnAttrs: q.Get("columnAttrs") == "true",
 		Quantum:     quantum,
+		Explain:     analyze || q.Get("explain") == "true",
+		Analyze:     analyze,
+		Timeout:     timeout,
+		Sample:      sample,
 	}, nil
 }
 
@@ -892,8 +1655,97 @@ func (h *Handler) writeJSONQueryResponse(w http.ResponseWriter, resp *QueryRespo
 	return json.NewEncoder(w).Encode(resp)
 }
 
+// handlePostIndexAttrImport handles POST /index/<indexname>/attr/import requests.
+func (h *Handler) handlePostIndexAttrImport(w http.ResponseWriter, r *http.Request) {
+	index := h.Holder.Index(h.indexName(r))
+	if index == nil {
+		http.Error(w, ErrIndexNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	h.importAttrs(w, r, index.ColumnAttrStore(), "")
+}
+
+// handlePostFrameAttrImport handles POST /index/<indexname>/frame/<framename>/attr/import requests.
+func (h *Handler) handlePostFrameAttrImport(w http.ResponseWriter, r *http.Request) {
+	f := h.Holder.Frame(h.indexName(r), mux.Vars(r)["frame"])
+	if f == nil {
+		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	h.importAttrs(w, r, f.RowAttrStore(), f.Name())
+}
+
+// importAttrs reads attributes from the request body and writes them to
+// store in batches. Unless the request is marked as remote, the attributes
+// are then forwarded to every other node in the cluster.
+func (h *Handler) importAttrs(w http.ResponseWriter, r *http.Request, store *AttrStore, frame string) {
+	format := AttrFormatJSON
+	if r.Header.Get("Content-Type") == "text/csv" {
+		format = AttrFormatCSV
+	}
+
+	m, err := ReadAttrs(r.Body, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Write attributes in large transactions.
+	for _, batch := range SplitAttrs(m, AttrImportBatchSize) {
+		if err := store.SetBulkAttrs(batch); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if r.URL.Query().Get("remote") != "true" {
+		if err := h.forwardAttrs(r.Context(), h.indexName(r), frame, m); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(postAttrImportResponse{}); err != nil {
+		h.logger().Printf("response encoding error: %s", err)
+	}
+}
+
+type postAttrImportResponse struct{}
+
+// forwardAttrs sends imported attributes to the other nodes in parallel.
+func (h *Handler) forwardAttrs(ctx context.Context, index, frame string, m map[uint64]map[string]interface{}) error {
+	if h.Cluster == nil {
+		return nil
+	}
+
+	nodes := Nodes(h.Cluster.Nodes).FilterHost(h.Host)
+	errs := make(chan error, len(nodes))
+	for _, node := range nodes {
+		go func(node *Node) {
+			client, err := h.Cluster.NodeClient(node.Host)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- client.importAttrs(ctx, index, frame, m, true)
+		}(node)
+	}
+
+	// Return first error.
+	for range nodes {
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // handlePostImport handles /import requests.
 func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
+	// Verify signature before the body is read.
+	fromNode := h.isNodeRequest(r)
+
 	// Verify that request is only communicating over protobufs.
 	if r.Header.Get("Content-Type") != "application/x-protobuf" {
 		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
@@ -916,17 +1768,16 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	req.Index = h.resolveAlias(req.Index)
 
-	// Convert timestamps to time.Time.
-	timestamps := make([]*time.Time, len(req.Timestamps))
-	for i, ts := range req.Timestamps {
-		if ts == 0 {
-			continue
-		}
-		t := time.Unix(0, ts)
-		timestamps[i] = &t
+	if err := h.authorize(r, fromNode, req.Index, PermissionWrite); err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
 	}
 
+	// Convert timestamps to time.Time.
+	timestamps := decodeTimestamps(req.Timestamps)
+
 	// Validate that this handler owns the slice.
 	if !h.Cluster.OwnsFragment(h.Host, req.Index, req.Slice) {
 		mesg := fmt.Sprintf("host does not own slice %s-%s slice:%d", h.Host, req.Index, req.Slice)
@@ -972,6 +1823,20 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	w.Write(buf)
 }
 
+// decodeTimestamps converts unix nanosecond timestamps to time.Time.
+// Zero timestamps are returned as nil.
+func decodeTimestamps(a []int64) []*time.Time {
+	timestamps := make([]*time.Time, len(a))
+	for i, ts := range a {
+		if ts == 0 {
+			continue
+		}
+		t := time.Unix(0, ts)
+		timestamps[i] = &t
+	}
+	return timestamps
+}
+
 // handleGetExport handles /export requests.
 func (h *Handler) handleGetExport(w http.ResponseWriter, r *http.Request) {
 	switch r.Header.Get("Accept") {
@@ -985,7 +1850,7 @@ func (h *Handler) handleGetExport(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleGetExportCSV(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters.
 	q := r.URL.Query()
-	index, frame, view := q.Get("index"), q.Get("frame"), q.Get("view")
+	index, frame, view := h.indexName(r), q.Get("frame"), q.Get("view")
 
 	slice, err := strconv.ParseUint(q.Get("slice"), 10, 64)
 	if err != nil {
@@ -1027,7 +1892,7 @@ func (h *Handler) handleGetExportCSV(w http.ResponseWriter, r *http.Request) {
 // handleGetFragmentNodes handles /fragment/nodes requests.
 func (h *Handler) handleGetFragmentNodes(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
-	index := q.Get("index")
+	index := h.indexName(r)
 
 	// Read slice parameter.
 	slice, err := strconv.ParseUint(q.Get("slice"), 10, 64)
@@ -1056,7 +1921,7 @@ func (h *Handler) handleGetFragmentData(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Retrieve fragment from holder.
-	f := h.Holder.Fragment(q.Get("index"), q.Get("frame"), q.Get("view"), slice)
+	f := h.Holder.Fragment(h.indexName(r), q.Get("frame"), q.Get("view"), slice)
 	if f == nil {
 		http.Error(w, "fragment not found", http.StatusNotFound)
 		return
@@ -1079,7 +1944,7 @@ func (h *Handler) handlePostFragmentData(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Retrieve frame.
-	f := h.Holder.Frame(q.Get("index"), q.Get("frame"))
+	f := h.Holder.Frame(h.indexName(r), q.Get("frame"))
 	if f == nil {
 		http.Error(w, ErrFrameNotFound.Error(), http.StatusNotFound)
 		return
@@ -1155,7 +2020,7 @@ func (h *Handler) handleGetFragmentBlocks(w http.ResponseWriter, r *http.Request
 	}
 
 	// Retrieve fragment from holder.
-	f := h.Holder.Fragment(q.Get("index"), q.Get("frame"), q.Get("view"), slice)
+	f := h.Holder.Fragment(h.indexName(r), q.Get("frame"), q.Get("view"), slice)
 	if f == nil {
 		http.Error(w, "fragment not found", http.StatusNotFound)
 		return
@@ -1178,7 +2043,7 @@ type getFragmentBlocksResponse struct {
 
 // handlePostFrameRestore handles POST /frame/restore requests.
 func (h *Handler) handlePostFrameRestore(w http.ResponseWriter, r *http.Request) {
-	indexName := mux.Vars(r)["index"]
+	indexName := h.indexName(r)
 	frameName := mux.Vars(r)["frame"]
 
 	q := r.URL.Query()
@@ -1191,7 +2056,7 @@ func (h *Handler) handlePostFrameRestore(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Create a client for the remote cluster.
-	client, err := NewClient(host)
+	client, err := h.Cluster.NodeClient(host)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1325,6 +2190,18 @@ type QueryRequest struct {
 	// If true, indicates that query is part of a larger distributed query.
 	// If false, this request is on the originating node.
 	Remote bool
+
+	// Return the execution plan of each call instead of its result.
+	// Calls are executed and timed if Analyze is also set.
+	Explain bool
+	Analyze bool
+
+	// Maximum execution time of the query. The server default is used if zero.
+	Timeout time.Duration
+
+	// Fraction of slices evaluated by Count() and TopN() calls.
+	// Count() returns an Estimate when sampled. Sampling is disabled if zero.
+	Sample float64
 }
 
 func decodeQueryRequest(pb *internal.QueryRequest) *QueryRequest {
@@ -1334,6 +2211,8 @@ func decodeQueryRequest(pb *internal.QueryRequest) *QueryRequest {
 		ColumnAttrs: pb.ColumnAttrs,
 		Quantum:     TimeQuantum(pb.Quantum),
 		Remote:      pb.Remote,
+		Sample:      pb.Sample,
+		Explain:     pb.Explain,
 	}
 
 	return req
@@ -1342,7 +2221,7 @@ func decodeQueryRequest(pb *internal.QueryRequest) *QueryRequest {
 // QueryResponse represent a response from a processed query.
 type QueryResponse struct {
 	// Result for each top-level query call.
-	// Can be a Bitmap, Pairs, or uint64.
+	// Can be a Bitmap, Pairs, uint64 or Estimate.
 	Results []interface{}
 
 	// Set of column attribute objects matching IDs returned in Result.
@@ -1384,6 +2263,13 @@ func encodeQueryResponse(resp *QueryResponse) *internal.QueryResponse {
 			pb.Results[i].Pairs = encodePairs(result)
 		case uint64:
 			pb.Results[i].N = result
+		case sliceCounts:
+			pb.Results[i].SliceCounts = result
+		case *Estimate:
+			pb.Results[i].N = result.Value
+			pb.Results[i].Estimate = encodeEstimate(result)
+		case *QueryPlan:
+			pb.Results[i].Plan = encodeQueryPlan(result)
 		case bool:
 			pb.Results[i].Changed = result
 		}
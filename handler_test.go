@@ -26,7 +26,9 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa"
@@ -165,6 +167,170 @@ func TestHandler_Query_Args_URL(t *testing.T) {
 	}
 }
 
+// Ensure the handler passes explain and analyze options to the executor.
+func TestHandler_Query_Explain(t *testing.T) {
+	h := NewHandler()
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if !opt.Explain || !opt.Analyze {
+			t.Fatalf("unexpected options: %+v", opt)
+		}
+		return []interface{}{&pilosa.QueryPlan{Call: query.Calls[0].String(), FragmentN: 1, ContainerN: 2}}, nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query?analyze=true", strings.NewReader("Bitmap(id=100)")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); body != `{"results":[{"call":"Bitmap(id=100)","fragments":1,"containers":2}]}`+"\n" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+}
+
+// Ensure the handler can sample queries and return estimates.
+func TestHandler_Query_Sample(t *testing.T) {
+	h := NewHandler()
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if opt.Sample != 0.1 {
+			t.Fatalf("unexpected sample: %v", opt.Sample)
+		}
+		return []interface{}{&pilosa.Estimate{Value: 100, Lower: 80, Upper: 120, SliceN: 10, SampleN: 1}}, nil
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query?sample=0.1", strings.NewReader("Count(Bitmap(id=100))")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); body != `{"results":[{"value":100,"lower":80,"upper":120,"slices":10,"sampled":1}]}`+"\n" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+
+	// Sample rates outside of (0, 1] are rejected.
+	for _, sample := range []string{"0", "1.5", "-0.1", "x"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query?sample="+sample, strings.NewReader("Count(Bitmap(id=100))")))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status code for %q: %d", sample, w.Code)
+		}
+	}
+}
+
+// Ensure the handler applies the request timeout and reports query limit errors.
+func TestHandler_Query_Limits(t *testing.T) {
+	h := NewHandler()
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("expected deadline")
+		}
+		return nil, pilosa.ErrTooManyQueries
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query?timeout=10s", strings.NewReader("Bitmap(id=100)")))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); body != `{"error":"too many concurrent queries"}`+"\n" {
+		t.Fatalf("unexpected body: %q", body)
+	}
+
+	// Cancelled queries are reported as closed by the client.
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return nil, pilosa.ErrQueryCanceled
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query", strings.NewReader("Bitmap(id=100)")))
+	if w.Code != pilosa.StatusClientClosedRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	// Invalid timeouts are rejected.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query?timeout=x", strings.NewReader("Bitmap(id=100)")))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	// Unknown priority classes are rejected.
+	r := MustNewHTTPRequest("POST", "/index/idx0/query", strings.NewReader("Bitmap(id=100)"))
+	r.Header.Set(pilosa.HeaderQueryPriority, "urgent")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+}
+
+// Ensure queries cancelled while waiting for the scheduler are reported as closed by the client.
+func TestHandler_Query_Scheduler_Canceled(t *testing.T) {
+	h := NewHandler()
+	h.Scheduler = pilosa.NewQueryScheduler(1)
+	release, err := h.Scheduler.Acquire(context.Background(), "idx0", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("POST", "/index/idx0/query", strings.NewReader("Bitmap(id=100)")).WithContext(ctx))
+	if w.Code != pilosa.StatusClientClosedRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+}
+
+// Ensure clients can't skip query limits by marking a query as remote.
+func TestHandler_Query_Remote_Limits(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "general", pilosa.ViewStandard, 0).MustSetBits(10, 1, 2, 3)
+
+	e := NewExecutor(hldr.Holder, NewCluster(1))
+	e.MaxResultBits = 2
+
+	h := NewHandler()
+	h.Scheduler = pilosa.NewQueryScheduler(1)
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		if !opt.Remote || !opt.Unverified {
+			t.Errorf("unexpected options: %+v", opt)
+		}
+		return e.Execute(ctx, index, query, slices, opt)
+	}
+
+	reqBody, err := proto.Marshal(&internal.QueryRequest{
+		Query:  "Bitmap(rowID=10)",
+		Slices: []uint64{0},
+		Remote: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := func(ctx context.Context) int {
+		r := MustNewHTTPRequest("POST", "/index/i/query", bytes.NewReader(reqBody)).WithContext(ctx)
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		r.Header.Set("Accept", "application/x-protobuf")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// The query waits for the scheduler.
+	release, err := h.Scheduler.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if code := query(ctx); code != pilosa.StatusClientClosedRequest {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	release()
+
+	// The result size is limited.
+	if code := query(context.Background()); code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+}
+
 // Ensure the handler can accept arguments via protobufs.
 func TestHandler_Query_Args_Protobuf(t *testing.T) {
 	h := NewHandler()
@@ -443,6 +609,33 @@ func TestHandler_Query_Pairs_Protobuf(t *testing.T) {
 	}
 }
 
+// Ensure the handler can return a query plan as protobuf.
+func TestHandler_Query_Explain_Protobuf(t *testing.T) {
+	h := NewHandler()
+	h.Executor.ExecuteFn = func(ctx context.Context, index string, query *pql.Query, slices []uint64, opt *pilosa.ExecOptions) ([]interface{}, error) {
+		return []interface{}{&pilosa.QueryPlan{
+			Call:      query.Calls[0].String(),
+			FragmentN: 1,
+			Children:  []*pilosa.QueryPlan{{Call: "Bitmap(id=100)", FragmentN: 1}},
+		}}, nil
+	}
+
+	w := httptest.NewRecorder()
+	r := MustNewHTTPRequest("POST", "/index/i/query?explain=true", strings.NewReader(`Count(Bitmap(id=100))`))
+	r.Header.Set("Accept", "application/x-protobuf")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	var resp internal.QueryResponse
+	if err := proto.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	} else if p := resp.Results[0].GetPlan(); p == nil || p.Call != "Count(Bitmap(id=100))" || p.FragmentN != 1 || len(p.Children) != 1 {
+		t.Fatalf("unexpected plan: %+v", p)
+	}
+}
+
 // Ensure the handler can return an error as JSON.
 func TestHandler_Query_Err_JSON(t *testing.T) {
 	h := NewHandler()
@@ -503,6 +696,32 @@ func TestHandler_Query_ErrParse(t *testing.T) {
 	}
 }
 
+// Ensure the handler only includes index statistics when requested.
+func TestHandler_Index_Get(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+	hldr.MustCreateFragmentIfNotExists("i", "f", pilosa.ViewStandard, 0).MustSetBits(1, 2)
+
+	h := NewHandler()
+	h.Holder = hldr.Holder
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("GET", "/index/i", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); strings.Contains(body, "stats") {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("GET", "/index/i?summary=true", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); !strings.Contains(body, `"stats":{"bitCount":1,`) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
 // Ensure the handler can delete an index.
 func TestHandler_Index_Delete(t *testing.T) {
 	hldr := MustOpenHolder()
@@ -539,6 +758,49 @@ func TestHandler_Index_Delete(t *testing.T) {
 	}
 }
 
+// Ensure handler never deletes the index behind an alias.
+func TestHandler_Index_Delete_Alias(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	s := NewServer()
+	s.Handler.Holder = hldr.Holder
+	defer s.Close()
+
+	hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
+	if err := hldr.SetAlias("a", "i"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(MustNewHTTPRequest("DELETE", s.URL+"/index/a", strings.NewReader("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// Deleting through an alias is rejected and leaves the target index alone.
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	} else if string(body) != "a is an alias, use DELETE /alias/a to remove it\n" {
+		t.Fatalf("unexpected body: %q", body)
+	} else if hldr.Index("i") == nil {
+		t.Fatal("expected index")
+	} else if aliases := hldr.Aliases(); aliases["a"] != "i" {
+		t.Fatalf("unexpected aliases: %+v", aliases)
+	}
+
+	// Creating an index with an alias name is rejected too.
+	resp, err = http.DefaultClient.Do(MustNewHTTPRequest("POST", s.URL+"/index/a", strings.NewReader("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+}
+
 // Ensure handler can delete a frame.
 func TestHandler_DeleteFrame(t *testing.T) {
 	hldr := MustOpenHolder()
@@ -603,6 +865,119 @@ func TestHandler_SetFrameTimeQuantum(t *testing.T) {
 	}
 }
 
+// Ensure handler can change the frame cache settings.
+func TestHandler_SetFrameCache(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	// Create frame.
+	if _, err := hldr.MustCreateIndexIfNotExists("i0", pilosa.IndexOptions{}).CreateFrame("f1", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	hldr.MustCreateFragmentIfNotExists("i0", "f1", pilosa.ViewStandard, 0).MustSetBits(1, 1, 2)
+
+	b := &RecordingBroadcaster{}
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Broadcaster = b
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/cache", strings.NewReader(`{"cacheType":"ranked","cacheSize":10}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if body := w.Body.String(); body != `{}`+"\n" {
+		t.Fatalf("unexpected body: %s", body)
+	} else if opt := hldr.Frame("i0", "f1").Options(); opt.CacheType != pilosa.CacheTypeRanked || opt.CacheSize != 10 {
+		t.Fatalf("unexpected options: %+v", opt)
+	} else if cache := hldr.Fragment("i0", "f1", pilosa.ViewStandard, 0).Cache(); cache.Get(1) != 2 {
+		t.Fatalf("unexpected cache count: %d", cache.Get(1))
+	}
+
+	// Changes are sent to the rest of the cluster.
+	if msgs := b.Messages(); !reflect.DeepEqual(msgs, []proto.Message{
+		&internal.UpdateFrameMessage{Index: "i0", Frame: "f1", Option: "cacheType", Value: "ranked"},
+		&internal.UpdateFrameMessage{Index: "i0", Frame: "f1", Option: "cacheSize", Value: "10"},
+	}) {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+
+	// Invalid cache types are rejected.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/cache", strings.NewReader(`{"cacheType":"foo"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	// Decay caches require a half-life, which is applied before the type.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/cache", strings.NewReader(`{"cacheType":"decay"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+
+	// Nothing is applied if the resulting settings are invalid.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/cache", strings.NewReader(`{"cacheType":"window","cacheSize":20,"cacheWindow":"1h"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if opt := hldr.Frame("i0", "f1").Options(); opt.CacheSize != 10 || opt.CacheWindow != 0 {
+		t.Fatalf("unexpected options: %+v", opt)
+	} else if msgs := b.Messages(); len(msgs) != 2 {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/cache", strings.NewReader(`{"cacheType":"decay","cacheHalfLife":"1h","cacheInterval":"1s"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if opt := hldr.Frame("i0", "f1").Options(); opt.CacheType != pilosa.CacheTypeDecay || opt.CacheHalfLife != pilosa.Duration(time.Hour) || opt.CacheInterval != pilosa.Duration(time.Second) {
+		t.Fatalf("unexpected options: %+v", opt)
+	}
+}
+
+// Ensure handler can enable inverse storage on a frame.
+func TestHandler_EnableFrameInverse(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	// Create frame with existing data.
+	if _, err := hldr.MustCreateIndexIfNotExists("i0", pilosa.IndexOptions{}).CreateFrame("f1", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	hldr.MustCreateFragmentIfNotExists("i0", "f1", pilosa.ViewStandard, 0).MustSetBits(1, 5)
+
+	b := &RecordingBroadcaster{}
+	h := NewHandler()
+	h.Holder = hldr.Holder
+	h.Broadcaster = b
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/inverse", strings.NewReader(`{"inverseEnabled":true}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if !hldr.Frame("i0", "f1").InverseEnabled() {
+		t.Fatal("expected inverse enabled")
+	} else if msgs := b.Messages(); !reflect.DeepEqual(msgs, []proto.Message{
+		&internal.UpdateFrameMessage{Index: "i0", Frame: "f1", Option: "inverseEnabled", Value: "true"},
+	}) {
+		t.Fatalf("unexpected messages: %v", msgs)
+	}
+
+	// Wait for the backfill to complete.
+	for i := 0; ; i++ {
+		if frag := hldr.Fragment("i0", "f1", pilosa.ViewInverse, 0); frag != nil && frag.Row(5).Count() == 1 {
+			break
+		} else if i == 100 {
+			t.Fatal("inverse view not backfilled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Inverse storage cannot be disabled.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewHTTPRequest("PATCH", "/index/i0/frame/f1/inverse", strings.NewReader(`{"inverseEnabled":false}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	}
+}
+
 // Ensure the handler can return data in differing blocks for an index.
 func TestHandler_Index_AttrStore_Diff(t *testing.T) {
 	hldr := MustOpenHolder()
@@ -793,6 +1168,30 @@ func TestHandler_Expvars(t *testing.T) {
 	}
 }
 
+// Ensure the handler can return recent anti-entropy runs.
+func TestHandler_AntiEntropy(t *testing.T) {
+	h := NewHandler()
+	h.AntiEntropyHandler = &HandlerAntiEntropy{runs: []pilosa.SyncStats{
+		{FragmentsChecked: 3, BlocksMismatched: 2, BitsSet: 5, BitsCleared: 1, AttrBlocksRepaired: 4, Duration: time.Second},
+	}}
+
+	w := httptest.NewRecorder()
+	r := MustNewHTTPRequest("GET", "/debug/anti-entropy", nil)
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", w.Code)
+	} else if w.Body.String() != `{"runs":[{"start":"0001-01-01T00:00:00Z","duration":1000000000,"fragmentsChecked":3,"blocksMismatched":2,"bitsSet":5,"bitsCleared":1,"attrBlocksRepaired":4}]}`+"\n" {
+		t.Fatalf("unexpected body: %q", w.Body.String())
+	}
+}
+
+// HandlerAntiEntropy is a mock implementing pilosa.AntiEntropyHandler.
+type HandlerAntiEntropy struct {
+	runs []pilosa.SyncStats
+}
+
+func (h *HandlerAntiEntropy) AntiEntropyStats() []pilosa.SyncStats { return h.runs }
+
 // Handler represents a test wrapper for pilosa.Handler.
 type Handler struct {
 	*pilosa.Handler
@@ -825,6 +1224,28 @@ func (c *HandlerExecutor) Execute(ctx context.Context, index string, query *pql.
 	return c.ExecuteFn(ctx, index, query, slices, opt)
 }
 
+// RecordingBroadcaster is a Broadcaster which records the messages sent.
+type RecordingBroadcaster struct {
+	mu   sync.Mutex
+	msgs []proto.Message
+}
+
+func (b *RecordingBroadcaster) SendSync(pb proto.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgs = append(b.msgs, pb)
+	return nil
+}
+
+func (b *RecordingBroadcaster) SendAsync(pb proto.Message) error { return b.SendSync(pb) }
+
+// Messages returns the messages sent so far.
+func (b *RecordingBroadcaster) Messages() []proto.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.msgs
+}
+
 // Server represents a test wrapper for httptest.Server.
 type Server struct {
 	*httptest.Server
@@ -19,17 +19,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
 	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pilosa/pilosa/internal"
 )
 
 // DefaultCacheFlushInterval is the default value for Fragment.CacheFlushInterval.
 const DefaultCacheFlushInterval = 1 * time.Minute
 
+// DefaultCacheVerifyInterval is the default value for Holder.CacheVerifyInterval.
+const DefaultCacheVerifyInterval = 1 * time.Hour
+
+// DefaultWindowRefreshInterval is the default value for Holder.WindowRefreshInterval.
+const DefaultWindowRefreshInterval = DefaultCacheInterval
+
 // Holder represents a container for indexes.
 type Holder struct {
 	mu sync.Mutex
@@ -37,6 +47,9 @@ type Holder struct {
 	// Indexes by name.
 	indexes map[string]*Index
 
+	// Index names by alias.
+	aliases map[string]string
+
 	Broadcaster Broadcaster
 	// Close management
 	wg      sync.WaitGroup
@@ -45,12 +58,23 @@ type Holder struct {
 	// Stats
 	Stats StatsClient
 
+	// Memory budget for the rows cached by every fragment.
+	RowCache *RowCache
+
 	// Data directory path.
 	Path string
 
 	// The interval at which the cached row ids are persisted to disk.
 	CacheFlushInterval time.Duration
 
+	// The interval at which cached row counts are verified against storage.
+	// Verification is disabled if zero.
+	CacheVerifyInterval time.Duration
+
+	// The interval at which window caches are recounted. Each slice is
+	// recounted at most once per cache interval of its frame.
+	WindowRefreshInterval time.Duration
+
 	LogOutput io.Writer
 }
 
@@ -58,12 +82,16 @@ type Holder struct {
 func NewHolder() *Holder {
 	return &Holder{
 		indexes: make(map[string]*Index),
+		aliases: make(map[string]string),
 		closing: make(chan struct{}, 0),
 
 		Broadcaster: NopBroadcaster,
 		Stats:       NopStatsClient,
+		RowCache:    NewRowCache(DefaultRowCacheSize),
 
-		CacheFlushInterval: DefaultCacheFlushInterval,
+		CacheFlushInterval:    DefaultCacheFlushInterval,
+		CacheVerifyInterval:   DefaultCacheVerifyInterval,
+		WindowRefreshInterval: DefaultWindowRefreshInterval,
 
 		LogOutput: os.Stderr,
 	}
@@ -75,6 +103,9 @@ func (h *Holder) Open() error {
 		return err
 	}
 
+	// Report cached row usage with the holder's stats.
+	h.RowCache.Stats = h.Stats
+
 	// Open path to read all index directories.
 	f, err := os.Open(h.Path)
 	if err != nil {
@@ -113,10 +144,24 @@ func (h *Holder) Open() error {
 		h.Stats.Count("indexN", 1)
 	}
 
+	if err := h.loadAliases(); err != nil {
+		return err
+	}
+
 	// Periodically flush cache.
 	h.wg.Add(1)
 	go func() { defer h.wg.Done(); h.monitorCacheFlush() }()
 
+	// Periodically verify caches against storage.
+	if h.CacheVerifyInterval > 0 {
+		h.wg.Add(1)
+		go func() { defer h.wg.Done(); h.monitorCacheVerify() }()
+	}
+
+	// Periodically recount window caches.
+	h.wg.Add(1)
+	go func() { defer h.wg.Done(); h.monitorWindowCaches() }()
+
 	return nil
 }
 
@@ -173,6 +218,54 @@ func (h *Holder) Schema() []*IndexInfo {
 // IndexPath returns the path where a given index is stored.
 func (h *Holder) IndexPath(name string) string { return filepath.Join(h.Path, name) }
 
+// VerifyClusterMeta ensures that the cluster partitioning matches the settings
+// persisted by a previous run. Changing the partition count or hasher moves
+// slices between nodes so the settings are saved on the first run and cannot
+// silently change afterward.
+func (h *Holder) VerifyClusterMeta(c *Cluster) error {
+	path := filepath.Join(h.Path, ".cluster")
+	pb := internal.ClusterMeta{
+		PartitionN: uint32(c.PartitionN),
+		Hasher:     hasherType(c.Hasher),
+	}
+
+	// Read persisted settings, if available.
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		// Save settings on first run.
+		buf, err := proto.Marshal(&pb)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, buf, 0666)
+	} else if err != nil {
+		return err
+	}
+
+	var other internal.ClusterMeta
+	if err := proto.Unmarshal(buf, &other); err != nil {
+		return err
+	}
+
+	// Compare settings.
+	if other.PartitionN != pb.PartitionN {
+		return fmt.Errorf("partition count mismatch: persisted=%d, configured=%d", other.PartitionN, pb.PartitionN)
+	} else if other.Hasher != pb.Hasher {
+		return fmt.Errorf("hasher mismatch: persisted=%q, configured=%q", other.Hasher, pb.Hasher)
+	}
+	return nil
+}
+
+// IndexReplicaN returns the replica count for an index.
+// Returns zero if the index doesn't exist or uses the cluster default.
+func (h *Holder) IndexReplicaN(name string) int {
+	index := h.Index(name)
+	if index == nil {
+		return 0
+	}
+	return index.ReplicaN()
+}
+
 // Index returns the index by name.
 func (h *Holder) Index(name string) *Index {
 	h.mu.Lock()
@@ -202,8 +295,8 @@ func (h *Holder) CreateIndex(name string, opt IndexOptions) (*Index, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	// Ensure index doesn't already exist.
-	if h.indexes[name] != nil {
+	// Ensure index or alias doesn't already exist.
+	if h.indexes[name] != nil || h.aliases[name] != "" {
 		return nil, ErrIndexExists
 	}
 	return h.createIndex(name, opt)
@@ -226,6 +319,10 @@ func (h *Holder) CreateIndexIfNotExists(name string, opt IndexOptions) (*Index,
 func (h *Holder) createIndex(name string, opt IndexOptions) (*Index, error) {
 	if name == "" {
 		return nil, errors.New("index name required")
+	} else if h.aliases[name] != "" {
+		return nil, ErrIndexExists
+	} else if opt.ReplicaN < 0 {
+		return nil, ErrInvalidReplicaN
 	}
 
 	// Return index if it exists.
@@ -246,6 +343,7 @@ func (h *Holder) createIndex(name string, opt IndexOptions) (*Index, error) {
 	// Update options.
 	index.SetColumnLabel(opt.ColumnLabel)
 	index.SetTimeQuantum(opt.TimeQuantum)
+	index.SetReplicaN(opt.ReplicaN)
 
 	h.indexes[index.Name()] = index
 
@@ -261,6 +359,7 @@ func (h *Holder) newIndex(path, name string) (*Index, error) {
 	}
 	index.LogOutput = h.LogOutput
 	index.stats = h.Stats.WithTags(fmt.Sprintf("index:%s", index.Name()))
+	index.rowCache = h.RowCache
 	index.broadcaster = h.Broadcaster
 	return index, nil
 }
@@ -291,9 +390,161 @@ func (h *Holder) DeleteIndex(name string) error {
 
 	h.Stats.Count("indexN", -1)
 
+	// Remove aliases to the index.
+	var changed bool
+	for alias, index := range h.aliases {
+		if index == name {
+			delete(h.aliases, alias)
+			changed = true
+		}
+	}
+	if changed {
+		return h.saveAliases()
+	}
+
 	return nil
 }
 
+// AliasPath returns the path of the file which stores index aliases.
+func (h *Holder) AliasPath() string { return filepath.Join(h.Path, ".aliases") }
+
+// loadAliases reads the index aliases from disk, if any.
+func (h *Holder) loadAliases() error {
+	buf, err := ioutil.ReadFile(h.AliasPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var pb internal.AliasMeta
+	if err := proto.Unmarshal(buf, &pb); err != nil {
+		return err
+	}
+
+	for alias, index := range pb.Aliases {
+		h.aliases[alias] = index
+	}
+	return nil
+}
+
+// saveAliases writes the index aliases to disk.
+func (h *Holder) saveAliases() error {
+	buf, err := proto.Marshal(&internal.AliasMeta{Aliases: h.aliases})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(h.AliasPath(), buf, 0666)
+}
+
+// Aliases returns a copy of the index names by alias.
+func (h *Holder) Aliases() map[string]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	other := make(map[string]string, len(h.aliases))
+	for alias, index := range h.aliases {
+		other[alias] = index
+	}
+	return other
+}
+
+// SetAlias points alias at an existing index. An existing alias is
+// replaced atomically so queries use either the old or new index.
+func (h *Holder) SetAlias(alias, index string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := ValidateName(alias); err != nil {
+		return err
+	} else if h.indexes[alias] != nil {
+		return ErrIndexExists
+	} else if h.indexes[index] == nil {
+		return ErrIndexNotFound
+	}
+
+	// Ignore if no change occurred.
+	if h.aliases[alias] == index {
+		return nil
+	}
+
+	prev, ok := h.aliases[alias]
+	h.aliases[alias] = index
+	if err := h.saveAliases(); err != nil {
+		if ok {
+			h.aliases[alias] = prev
+		} else {
+			delete(h.aliases, alias)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAlias removes an alias. The index it refers to is not affected.
+func (h *Holder) DeleteAlias(alias string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	index, ok := h.aliases[alias]
+	if !ok {
+		return ErrAliasNotFound
+	}
+
+	delete(h.aliases, alias)
+	if err := h.saveAliases(); err != nil {
+		h.aliases[alias] = index
+		return err
+	}
+
+	return nil
+}
+
+// MergeAliases adds aliases from another node which are missing locally.
+// Aliases which already exist or whose index doesn't exist are ignored.
+// Returns the number of aliases added.
+func (h *Holder) MergeAliases(aliases map[string]string) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var added []string
+	for alias, index := range aliases {
+		if _, ok := h.aliases[alias]; ok {
+			continue
+		} else if h.indexes[alias] != nil || h.indexes[index] == nil {
+			continue
+		} else if err := ValidateName(alias); err != nil {
+			continue
+		}
+		h.aliases[alias] = index
+		added = append(added, alias)
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+
+	if err := h.saveAliases(); err != nil {
+		for _, alias := range added {
+			delete(h.aliases, alias)
+		}
+		return 0, err
+	}
+	return len(added), nil
+}
+
+// ResolveAlias returns the name of the index that name refers to.
+// Names which are not aliases are returned unchanged.
+func (h *Holder) ResolveAlias(name string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if index, ok := h.aliases[name]; ok {
+		return index
+	}
+	return name
+}
+
 // Frame returns the frame for an index and name.
 func (h *Holder) Frame(index, name string) *Frame {
 	idx := h.Index(index)
@@ -321,8 +572,8 @@ func (h *Holder) Fragment(index, frame, view string, slice uint64) *Fragment {
 	return v.Fragment(slice)
 }
 
-// monitorCacheFlush periodically flushes all fragment caches sequentially.
-// This is run in a goroutine.
+// monitorCacheFlush periodically flushes all fragment caches sequentially
+// and reports the size of the cached rows. This is run in a goroutine.
 func (h *Holder) monitorCacheFlush() {
 	ticker := time.NewTicker(h.CacheFlushInterval)
 	defer ticker.Stop()
@@ -332,6 +583,7 @@ func (h *Holder) monitorCacheFlush() {
 		case <-h.closing:
 			return
 		case <-ticker.C:
+			h.RowCache.ReportStats()
 			h.flushCaches()
 		}
 	}
@@ -357,6 +609,82 @@ func (h *Holder) flushCaches() {
 	}
 }
 
+// monitorCacheVerify periodically verifies all fragment caches sequentially.
+// This is run in a goroutine.
+func (h *Holder) monitorCacheVerify() {
+	ticker := time.NewTicker(h.CacheVerifyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			h.VerifyCaches()
+		}
+	}
+}
+
+// VerifyCaches recounts the cached rows of every fragment and corrects any
+// counts which have drifted from storage. Returns the number of rows corrected,
+// which is also reported as the cacheDriftRows gauge.
+func (h *Holder) VerifyCaches() int {
+	var total int
+	for _, index := range h.Indexes() {
+		for _, frame := range index.Frames() {
+			for _, view := range frame.Views() {
+				for _, fragment := range view.Fragments() {
+					select {
+					case <-h.closing:
+						return total
+					default:
+					}
+
+					if n := fragment.VerifyCache(); n > 0 {
+						h.logger().Printf("cache drift corrected: rows=%d, path=%s", n, fragment.CachePath())
+						total += n
+					}
+				}
+			}
+		}
+	}
+	h.Stats.Gauge("cacheDriftRows", float64(total))
+	return total
+}
+
+// monitorWindowCaches periodically recounts window caches from their time views.
+// This is run in a goroutine.
+func (h *Holder) monitorWindowCaches() {
+	ticker := time.NewTicker(h.WindowRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			h.RefreshWindowCaches(time.Now())
+		}
+	}
+}
+
+// RefreshWindowCaches recounts the window caches of every frame which uses one.
+func (h *Holder) RefreshWindowCaches(now time.Time) {
+	for _, index := range h.Indexes() {
+		for _, frame := range index.Frames() {
+			select {
+			case <-h.closing:
+				return
+			default:
+			}
+
+			if err := frame.RefreshWindowCaches(now); err != nil {
+				h.logger().Printf("error refreshing window cache: err=%s, path=%s", err, frame.Path())
+			}
+		}
+	}
+}
+
 func (h *Holder) logger() *log.Logger { return log.New(h.LogOutput, "", log.LstdFlags) }
 
 // HolderSyncer is an active anti-entropy tool that compares the local holder
@@ -369,6 +697,21 @@ type HolderSyncer struct {
 
 	// Signals that the sync should stop.
 	Closing <-chan struct{}
+
+	// Statistics from the most recent call to SyncHolder().
+	Stats SyncStats
+}
+
+// SyncStats represents the statistics collected during a single anti-entropy run.
+type SyncStats struct {
+	Start              time.Time     `json:"start"`
+	Duration           time.Duration `json:"duration"`
+	FragmentsChecked   int           `json:"fragmentsChecked"`
+	BlocksMismatched   int           `json:"blocksMismatched"`
+	BitsSet            int           `json:"bitsSet"`
+	BitsCleared        int           `json:"bitsCleared"`
+	AttrBlocksRepaired int           `json:"attrBlocksRepaired"`
+	Error              string        `json:"error,omitempty"`
 }
 
 // IsClosing returns true if the syncer has been marked to close.
@@ -383,6 +726,31 @@ func (s *HolderSyncer) IsClosing() bool {
 
 // SyncHolder compares the holder on host with the local holder and resolves differences.
 func (s *HolderSyncer) SyncHolder() error {
+	s.Stats = SyncStats{Start: time.Now()}
+	err := s.syncHolder()
+	s.Stats.Duration = time.Since(s.Stats.Start)
+	if err != nil {
+		s.Stats.Error = err.Error()
+	}
+
+	// Report run statistics.
+	stats := s.Holder.Stats
+	stats.Count("antiEntropy.fragmentN", int64(s.Stats.FragmentsChecked))
+	stats.Count("antiEntropy.blockMismatchN", int64(s.Stats.BlocksMismatched))
+	stats.Count("antiEntropy.setN", int64(s.Stats.BitsSet))
+	stats.Count("antiEntropy.clearN", int64(s.Stats.BitsCleared))
+	stats.Count("antiEntropy.attrBlockN", int64(s.Stats.AttrBlocksRepaired))
+	stats.Timing("antiEntropy.duration", s.Stats.Duration)
+
+	return err
+}
+
+func (s *HolderSyncer) syncHolder() error {
+	// Add aliases which were missed by this node.
+	if err := s.syncAliases(); err != nil {
+		return fmt.Errorf("alias sync error: %s", err)
+	}
+
 	// Iterate over schema in sorted order.
 	for _, di := range s.Holder.Schema() {
 		// Verify syncer has not closed.
@@ -435,6 +803,24 @@ func (s *HolderSyncer) SyncHolder() error {
 	return nil
 }
 
+// syncAliases adds index aliases from the rest of the cluster.
+func (s *HolderSyncer) syncAliases() error {
+	for _, node := range Nodes(s.Cluster.Nodes).FilterHost(s.Host) {
+		client, err := s.Cluster.NodeClient(node.Host)
+		if err != nil {
+			return err
+		}
+
+		m, err := client.Aliases(context.Background())
+		if err != nil {
+			return err
+		} else if _, err := s.Holder.MergeAliases(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // syncIndex synchronizes index attributes with the rest of the cluster.
 func (s *HolderSyncer) syncIndex(index string) error {
 	// Retrieve index reference.
@@ -451,7 +837,7 @@ func (s *HolderSyncer) syncIndex(index string) error {
 
 	// Sync with every other host.
 	for _, node := range Nodes(s.Cluster.Nodes).FilterHost(s.Host) {
-		client, err := NewClient(node.Host)
+		client, err := s.Cluster.NodeClient(node.Host)
 		if err != nil {
 			return err
 		}
@@ -470,11 +856,13 @@ func (s *HolderSyncer) syncIndex(index string) error {
 			return err
 		}
 
-		// Recompute blocks.
-		blks, err = idx.ColumnAttrStore().Blocks()
+		// Recompute blocks and record the number that changed.
+		newBlks, err := idx.ColumnAttrStore().Blocks()
 		if err != nil {
 			return err
 		}
+		s.Stats.AttrBlocksRepaired += len(AttrBlocks(blks).Diff(newBlks))
+		blks = newBlks
 	}
 
 	return nil
@@ -496,7 +884,7 @@ func (s *HolderSyncer) syncFrame(index, name string) error {
 
 	// Sync with every other host.
 	for _, node := range Nodes(s.Cluster.Nodes).FilterHost(s.Host) {
-		client, err := NewClient(node.Host)
+		client, err := s.Cluster.NodeClient(node.Host)
 		if err != nil {
 			return err
 		}
@@ -517,11 +905,13 @@ func (s *HolderSyncer) syncFrame(index, name string) error {
 			return err
 		}
 
-		// Recompute blocks.
-		blks, err = f.RowAttrStore().Blocks()
+		// Recompute blocks and record the number that changed.
+		newBlks, err := f.RowAttrStore().Blocks()
 		if err != nil {
 			return err
 		}
+		s.Stats.AttrBlocksRepaired += len(AttrBlocks(blks).Diff(newBlks))
+		blks = newBlks
 	}
 
 	return nil
@@ -553,6 +943,7 @@ func (s *HolderSyncer) syncFragment(index, frame, view string, slice uint64) err
 		Host:     s.Host,
 		Cluster:  s.Cluster,
 		Closing:  s.Closing,
+		Stats:    &s.Stats,
 	}
 	if err := fs.SyncFragment(); err != nil {
 		return err
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pilosa/pilosa"
@@ -59,6 +60,120 @@ func TestHolder_DeleteIndex(t *testing.T) {
 	}
 }
 
+// Ensure holder can alias indexes and persist the aliases.
+func TestHolder_Alias(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	hldr.MustCreateIndexIfNotExists("i0", pilosa.IndexOptions{})
+	hldr.MustCreateIndexIfNotExists("i1", pilosa.IndexOptions{})
+
+	// Create and swap an alias.
+	if err := hldr.SetAlias("a", "i0"); err != nil {
+		t.Fatal(err)
+	} else if name := hldr.ResolveAlias("a"); name != "i0" {
+		t.Fatalf("unexpected index: %s", name)
+	} else if err := hldr.SetAlias("a", "i1"); err != nil {
+		t.Fatal(err)
+	} else if name := hldr.ResolveAlias("a"); name != "i1" {
+		t.Fatalf("unexpected index: %s", name)
+	} else if name := hldr.ResolveAlias("i0"); name != "i0" {
+		t.Fatalf("unexpected index: %s", name)
+	}
+
+	// Aliases and indexes cannot share names.
+	if err := hldr.SetAlias("i0", "i1"); err != pilosa.ErrIndexExists {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, err := hldr.CreateIndex("a", pilosa.IndexOptions{}); err != pilosa.ErrIndexExists {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := hldr.SetAlias("b", "no_such_index"); err != pilosa.ErrIndexNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reopen holder and verify aliases are persisted.
+	if err := hldr.SetAlias("b", "i0"); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.Holder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := hldr.Path
+	hldr.Holder = pilosa.NewHolder()
+	hldr.Path = path
+	hldr.Holder.LogOutput = &hldr.LogOutput
+	if err := hldr.Open(); err != nil {
+		t.Fatal(err)
+	} else if aliases := hldr.Aliases(); !reflect.DeepEqual(aliases, map[string]string{"a": "i1", "b": "i0"}) {
+		t.Fatalf("unexpected aliases: %+v", aliases)
+	}
+
+	// Deleting an index removes its aliases.
+	if err := hldr.DeleteIndex("i0"); err != nil {
+		t.Fatal(err)
+	} else if aliases := hldr.Aliases(); !reflect.DeepEqual(aliases, map[string]string{"a": "i1"}) {
+		t.Fatalf("unexpected aliases: %+v", aliases)
+	}
+
+	// Delete alias.
+	if err := hldr.DeleteAlias("a"); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.DeleteAlias("a"); err != pilosa.ErrAliasNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if hldr.Index("i1") == nil {
+		t.Fatal("expected index to remain")
+	}
+}
+
+// Ensure holder only merges remote aliases which are missing locally.
+func TestHolder_MergeAliases(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	hldr.MustCreateIndexIfNotExists("i0", pilosa.IndexOptions{})
+	hldr.MustCreateIndexIfNotExists("i1", pilosa.IndexOptions{})
+	if err := hldr.SetAlias("a", "i0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := hldr.MergeAliases(map[string]string{
+		"a":  "i1",            // exists locally
+		"b":  "i1",            // new
+		"c":  "no_such_index", // unknown index
+		"i0": "i1",            // name is an index
+	}); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Fatalf("unexpected added count: %d", n)
+	} else if aliases := hldr.Aliases(); !reflect.DeepEqual(aliases, map[string]string{"a": "i0", "b": "i1"}) {
+		t.Fatalf("unexpected aliases: %+v", aliases)
+	}
+}
+
+// Ensure holder rejects changes to the persisted cluster partitioning.
+func TestHolder_VerifyClusterMeta(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	c := pilosa.NewCluster()
+	if err := hldr.VerifyClusterMeta(c); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.VerifyClusterMeta(c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the partition count should fail.
+	c.PartitionN = 32
+	if err := hldr.VerifyClusterMeta(c); err == nil || !strings.Contains(err.Error(), "partition count mismatch") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Changing the hasher should fail.
+	c.PartitionN = pilosa.DefaultPartitionN
+	c.Hasher, _ = pilosa.NewHasherByType(pilosa.HasherRendezvous)
+	if err := hldr.VerifyClusterMeta(c); err == nil || !strings.Contains(err.Error(), "hasher mismatch") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Ensure holder can sync with a remote holder.
 func TestHolderSyncer_SyncHolder(t *testing.T) {
 	cluster := NewCluster(2)
@@ -146,6 +261,11 @@ func TestHolderSyncer_SyncHolder(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Verify run statistics were recorded.
+	if st := syncer.Stats; st.FragmentsChecked == 0 || st.BlocksMismatched == 0 || st.BitsSet == 0 || st.Duration == 0 {
+		t.Fatalf("unexpected sync stats: %+v", st)
+	}
+
 	// Verify data is the same on both nodes.
 	for i, hldr := range []*Holder{hldr0, hldr1} {
 		f := hldr.Fragment("i", "f", pilosa.ViewStandard, 0)
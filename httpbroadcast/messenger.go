@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -86,8 +87,7 @@ func (h *HTTPBroadcaster) nodes() ([]*pilosa.Node, error) {
 }
 
 func (h *HTTPBroadcaster) sendNodeMessage(node *pilosa.Node, msg []byte) error {
-	var client *http.Client
-	client = http.DefaultClient
+	client := h.server.Cluster.NodeHTTPClient()
 
 	// Create HTTP request.
 	req, err := http.NewRequest("POST", (&url.URL{
@@ -125,6 +125,10 @@ type HTTPBroadcastReceiver struct {
 	port      string
 	handler   pilosa.BroadcastHandler
 	logOutput io.Writer
+
+	// Shared secret used to verify that messages are sent by other nodes.
+	// Messages are not verified if this is blank.
+	Secret []byte
 }
 
 // NewHTTPBroadcastReceiver returns a new instance of HTTPBroadcastReceiver.
@@ -153,6 +157,14 @@ func (rec *HTTPBroadcastReceiver) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Verify message was sent by another node.
+	if len(rec.Secret) > 0 {
+		if err := pilosa.VerifyRequest(r, rec.Secret, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
 	// Read entire body.
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
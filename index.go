@@ -47,6 +47,10 @@ type Index struct {
 	// Label used for referring to columns in index.
 	columnLabel string
 
+	// Number of replicas for each slice in the index.
+	// If zero then the cluster's replica count is used.
+	replicaN int
+
 	// Frames by name.
 	frames map[string]*Frame
 
@@ -57,6 +61,9 @@ type Index struct {
 	// Column attribute storage and cache
 	columnAttrStore *AttrStore
 
+	// Memory budget for cached rows, shared by the holder.
+	rowCache *RowCache
+
 	broadcaster Broadcaster
 	stats       StatsClient
 
@@ -130,6 +137,40 @@ func (i *Index) ColumnLabel() string {
 	return v
 }
 
+// SetReplicaN sets the number of replicas for the index's slices.
+// A value of zero uses the cluster default. Persists to meta file on update.
+func (i *Index) SetReplicaN(n int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// Validate input.
+	if n < 0 {
+		return ErrInvalidReplicaN
+	}
+
+	// Ignore if no change occurred.
+	if i.replicaN == n {
+		return nil
+	}
+
+	// Persist meta data to disk on change.
+	i.replicaN = n
+	if err := i.saveMeta(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReplicaN returns the number of replicas for the index's slices.
+// Returns zero if the cluster default is used.
+func (i *Index) ReplicaN() int {
+	i.mu.Lock()
+	v := i.replicaN
+	i.mu.Unlock()
+	return v
+}
+
 // Open opens and initializes the index.
 func (i *Index) Open() error {
 	// Ensure the path exists.
@@ -206,6 +247,7 @@ func (i *Index) loadMeta() error {
 	// Copy metadata fields.
 	i.timeQuantum = TimeQuantum(pb.TimeQuantum)
 	i.columnLabel = pb.ColumnLabel
+	i.replicaN = int(pb.ReplicaN)
 
 	return nil
 }
@@ -216,6 +258,7 @@ func (i *Index) saveMeta() error {
 	buf, err := proto.Marshal(&internal.IndexMeta{
 		TimeQuantum: string(i.timeQuantum),
 		ColumnLabel: i.columnLabel,
+		ReplicaN:    uint32(i.replicaN),
 	})
 	if err != nil {
 		return err
@@ -382,6 +425,15 @@ func (i *Index) createFrame(name string, opt FrameOptions) (*Frame, error) {
 		return nil, ErrInvalidCacheType
 	}
 
+	// Default the time quantum to what is set on the Index.
+	timeQuantum := i.timeQuantum
+	if opt.TimeQuantum != "" {
+		timeQuantum = opt.TimeQuantum
+	}
+	if err := validateCacheOptions(opt.CacheType, time.Duration(opt.CacheWindow), time.Duration(opt.CacheHalfLife), timeQuantum); err != nil {
+		return nil, err
+	}
+
 	// Initialize frame.
 	f, err := i.newFrame(i.FramePath(name), name)
 	if err != nil {
@@ -393,11 +445,7 @@ func (i *Index) createFrame(name string, opt FrameOptions) (*Frame, error) {
 		return nil, err
 	}
 
-	// Default the time quantum to what is set on the Index.
-	timeQuantum := i.timeQuantum
-	if opt.TimeQuantum != "" {
-		timeQuantum = opt.TimeQuantum
-	}
+	// Set the time quantum.
 	if err := f.SetTimeQuantum(timeQuantum); err != nil {
 		f.Close()
 		return nil, err
@@ -416,6 +464,9 @@ func (i *Index) createFrame(name string, opt FrameOptions) (*Frame, error) {
 	if opt.CacheSize != 0 {
 		f.cacheSize = opt.CacheSize
 	}
+	f.cacheInterval = time.Duration(opt.CacheInterval)
+	f.cacheWindow = time.Duration(opt.CacheWindow)
+	f.cacheHalfLife = time.Duration(opt.CacheHalfLife)
 
 	f.inverseEnabled = opt.InverseEnabled
 	if err := f.saveMeta(); err != nil {
@@ -438,6 +489,7 @@ func (i *Index) newFrame(path, name string) (*Frame, error) {
 	}
 	f.LogOutput = i.LogOutput
 	f.stats = i.stats.WithTags(fmt.Sprintf("frame:%s", name))
+	f.rowCache = i.rowCache
 	f.broadcaster = i.broadcaster
 	return f, nil
 }
@@ -545,16 +597,72 @@ func encodeIndex(d *Index) *internal.Index {
 		Meta: &internal.IndexMeta{
 			ColumnLabel: d.columnLabel,
 			TimeQuantum: string(d.timeQuantum),
+			ReplicaN:    uint32(d.replicaN),
 		},
 		MaxSlice: d.MaxSlice(),
 		Frames:   encodeFrames(d.Frames()),
 	}
 }
 
+// Summary returns the index's options, slice counts and frames. If stats is
+// true, the total bit count and on-disk size of its fragments on the local
+// node are also included. This scans every fragment.
+func (i *Index) Summary(stats bool) (IndexSummary, error) {
+	summary := IndexSummary{
+		Name: i.Name(),
+		Options: IndexOptions{
+			ColumnLabel: i.ColumnLabel(),
+			TimeQuantum: i.TimeQuantum(),
+			ReplicaN:    i.ReplicaN(),
+		},
+		MaxSlice:        i.MaxSlice(),
+		MaxInverseSlice: i.MaxInverseSlice(),
+		Frames:          []string{},
+	}
+	if stats {
+		summary.Stats = &IndexStats{}
+	}
+
+	for _, f := range i.Frames() {
+		summary.Frames = append(summary.Frames, f.Name())
+		if !stats {
+			continue
+		}
+
+		fs, err := f.Stats()
+		if err != nil {
+			return summary, err
+		}
+		summary.Stats.BitN += fs.BitN
+		summary.Stats.Size += fs.Size
+	}
+	return summary, nil
+}
+
+// IndexSummary represents an index's metadata and local statistics.
+type IndexSummary struct {
+	Name            string       `json:"name"`
+	Options         IndexOptions `json:"options"`
+	MaxSlice        uint64       `json:"maxSlice"`
+	MaxInverseSlice uint64       `json:"maxInverseSlice"`
+	Frames          []string     `json:"frames"`
+
+	// Only set if statistics are requested.
+	Stats *IndexStats `json:"stats,omitempty"`
+}
+
+// IndexStats represents the bit count and on-disk size of an index's
+// fragments on the local node.
+type IndexStats struct {
+	BitN uint64 `json:"bitCount"`
+	Size int64  `json:"size"`
+}
+
 // IndexOptions represents options to set when initializing an index.
 type IndexOptions struct {
 	ColumnLabel string      `json:"columnLabel,omitempty"`
 	TimeQuantum TimeQuantum `json:"timeQuantum,omitempty"`
+	ReplicaN    int         `json:"replicaN,omitempty"`
 }
 
 // Encode converts o into its internal representation.
@@ -562,6 +670,19 @@ func (o *IndexOptions) Encode() *internal.IndexMeta {
 	return &internal.IndexMeta{
 		ColumnLabel: o.ColumnLabel,
 		TimeQuantum: string(o.TimeQuantum),
+		ReplicaN:    uint32(o.ReplicaN),
+	}
+}
+
+// decodeIndexOptions converts pb into index options.
+func decodeIndexOptions(pb *internal.IndexMeta) IndexOptions {
+	if pb == nil {
+		return IndexOptions{}
+	}
+	return IndexOptions{
+		ColumnLabel: pb.ColumnLabel,
+		TimeQuantum: TimeQuantum(pb.TimeQuantum),
+		ReplicaN:    int(pb.ReplicaN),
 	}
 }
 
@@ -130,6 +130,31 @@ func TestIndex_SetTimeQuantum(t *testing.T) {
 	}
 }
 
+// Ensure index can set the replica count.
+func TestIndex_SetReplicaN(t *testing.T) {
+	index := MustOpenIndex()
+	defer index.Close()
+
+	// Set & retrieve replica count.
+	if err := index.SetReplicaN(3); err != nil {
+		t.Fatal(err)
+	} else if n := index.ReplicaN(); n != 3 {
+		t.Fatalf("unexpected replica count: %d", n)
+	}
+
+	// Reload index and verify that it is persisted.
+	if err := index.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := index.ReplicaN(); n != 3 {
+		t.Fatalf("unexpected replica count (reopen): %d", n)
+	}
+
+	// Negative counts are invalid.
+	if err := index.SetReplicaN(-1); err != pilosa.ErrInvalidReplicaN {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Index represents a test wrapper for pilosa.Index.
 type Index struct {
 	*pilosa.Index
@@ -23,6 +23,7 @@ import (
 
 //go:generate protoc --gofast_out=. private.proto
 //go:generate protoc --gofast_out=. public.proto
+//go:generate protoc --gofast_out=plugins=grpc:. service.proto
 
 type Request proto.Message
 
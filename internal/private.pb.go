@@ -25,6 +25,7 @@
 	It has these top-level messages:
 		IndexMeta
 		FrameMeta
+		ClusterMeta
 		ImportResponse
 		BlockDataRequest
 		BlockDataResponse
@@ -35,6 +36,11 @@
 		CreateIndexMessage
 		CreateFrameMessage
 		DeleteFrameMessage
+		SetAliasMessage
+		DeleteAliasMessage
+		UpdateIndexMessage
+		UpdateFrameMessage
+		AliasMeta
 		Frame
 		Index
 		NodeStatus
@@ -62,6 +68,7 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type IndexMeta struct {
 	ColumnLabel string `protobuf:"bytes,1,opt,name=ColumnLabel,proto3" json:"ColumnLabel,omitempty"`
 	TimeQuantum string `protobuf:"bytes,2,opt,name=TimeQuantum,proto3" json:"TimeQuantum,omitempty"`
+	ReplicaN    uint32 `protobuf:"varint,3,opt,name=ReplicaN,proto3" json:"ReplicaN,omitempty"`
 }
 
 func (m *IndexMeta) Reset()                    { *m = IndexMeta{} }
@@ -75,6 +82,9 @@ type FrameMeta struct {
 	CacheType      string `protobuf:"bytes,3,opt,name=CacheType,proto3" json:"CacheType,omitempty"`
 	CacheSize      uint32 `protobuf:"varint,4,opt,name=CacheSize,proto3" json:"CacheSize,omitempty"`
 	TimeQuantum    string `protobuf:"bytes,5,opt,name=TimeQuantum,proto3" json:"TimeQuantum,omitempty"`
+	CacheInterval  int64  `protobuf:"varint,6,opt,name=CacheInterval,proto3" json:"CacheInterval,omitempty"`
+	CacheWindow    int64  `protobuf:"varint,7,opt,name=CacheWindow,proto3" json:"CacheWindow,omitempty"`
+	CacheHalfLife  int64  `protobuf:"varint,8,opt,name=CacheHalfLife,proto3" json:"CacheHalfLife,omitempty"`
 }
 
 func (m *FrameMeta) Reset()                    { *m = FrameMeta{} }
@@ -82,6 +92,16 @@ func (m *FrameMeta) String() string            { return proto.CompactTextString(
 func (*FrameMeta) ProtoMessage()               {}
 func (*FrameMeta) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{1} }
 
+type ClusterMeta struct {
+	PartitionN uint32 `protobuf:"varint,1,opt,name=PartitionN,proto3" json:"PartitionN,omitempty"`
+	Hasher     string `protobuf:"bytes,2,opt,name=Hasher,proto3" json:"Hasher,omitempty"`
+}
+
+func (m *ClusterMeta) Reset()                    { *m = ClusterMeta{} }
+func (m *ClusterMeta) String() string            { return proto.CompactTextString(m) }
+func (*ClusterMeta) ProtoMessage()               {}
+func (*ClusterMeta) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{2} }
+
 type ImportResponse struct {
 	Err string `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
 }
@@ -89,7 +109,7 @@ type ImportResponse struct {
 func (m *ImportResponse) Reset()                    { *m = ImportResponse{} }
 func (m *ImportResponse) String() string            { return proto.CompactTextString(m) }
 func (*ImportResponse) ProtoMessage()               {}
-func (*ImportResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{2} }
+func (*ImportResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{3} }
 
 type BlockDataRequest struct {
 	Index string `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
@@ -102,7 +122,7 @@ type BlockDataRequest struct {
 func (m *BlockDataRequest) Reset()                    { *m = BlockDataRequest{} }
 func (m *BlockDataRequest) String() string            { return proto.CompactTextString(m) }
 func (*BlockDataRequest) ProtoMessage()               {}
-func (*BlockDataRequest) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{3} }
+func (*BlockDataRequest) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{4} }
 
 type BlockDataResponse struct {
 	RowIDs    []uint64 `protobuf:"varint,1,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
@@ -112,16 +132,18 @@ type BlockDataResponse struct {
 func (m *BlockDataResponse) Reset()                    { *m = BlockDataResponse{} }
 func (m *BlockDataResponse) String() string            { return proto.CompactTextString(m) }
 func (*BlockDataResponse) ProtoMessage()               {}
-func (*BlockDataResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{4} }
+func (*BlockDataResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{5} }
 
 type Cache struct {
-	IDs []uint64 `protobuf:"varint,1,rep,packed,name=IDs" json:"IDs,omitempty"`
+	IDs    []uint64 `protobuf:"varint,1,rep,packed,name=IDs" json:"IDs,omitempty"`
+	Counts []uint64 `protobuf:"varint,2,rep,packed,name=Counts" json:"Counts,omitempty"`
+	OpN    uint64   `protobuf:"varint,3,opt,name=OpN,proto3" json:"OpN,omitempty"`
 }
 
 func (m *Cache) Reset()                    { *m = Cache{} }
 func (m *Cache) String() string            { return proto.CompactTextString(m) }
 func (*Cache) ProtoMessage()               {}
-func (*Cache) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{5} }
+func (*Cache) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{6} }
 
 type MaxSlicesResponse struct {
 	MaxSlices map[string]uint64 `protobuf:"bytes,1,rep,name=MaxSlices" json:"MaxSlices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
@@ -130,7 +152,7 @@ type MaxSlicesResponse struct {
 func (m *MaxSlicesResponse) Reset()                    { *m = MaxSlicesResponse{} }
 func (m *MaxSlicesResponse) String() string            { return proto.CompactTextString(m) }
 func (*MaxSlicesResponse) ProtoMessage()               {}
-func (*MaxSlicesResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{6} }
+func (*MaxSlicesResponse) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{7} }
 
 func (m *MaxSlicesResponse) GetMaxSlices() map[string]uint64 {
 	if m != nil {
@@ -148,7 +170,7 @@ type CreateSliceMessage struct {
 func (m *CreateSliceMessage) Reset()                    { *m = CreateSliceMessage{} }
 func (m *CreateSliceMessage) String() string            { return proto.CompactTextString(m) }
 func (*CreateSliceMessage) ProtoMessage()               {}
-func (*CreateSliceMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{7} }
+func (*CreateSliceMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{8} }
 
 type DeleteIndexMessage struct {
 	Index string `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
@@ -157,7 +179,7 @@ type DeleteIndexMessage struct {
 func (m *DeleteIndexMessage) Reset()                    { *m = DeleteIndexMessage{} }
 func (m *DeleteIndexMessage) String() string            { return proto.CompactTextString(m) }
 func (*DeleteIndexMessage) ProtoMessage()               {}
-func (*DeleteIndexMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{8} }
+func (*DeleteIndexMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{9} }
 
 type CreateIndexMessage struct {
 	Index string     `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
@@ -167,7 +189,7 @@ type CreateIndexMessage struct {
 func (m *CreateIndexMessage) Reset()                    { *m = CreateIndexMessage{} }
 func (m *CreateIndexMessage) String() string            { return proto.CompactTextString(m) }
 func (*CreateIndexMessage) ProtoMessage()               {}
-func (*CreateIndexMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{9} }
+func (*CreateIndexMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{10} }
 
 func (m *CreateIndexMessage) GetMeta() *IndexMeta {
 	if m != nil {
@@ -185,7 +207,7 @@ type CreateFrameMessage struct {
 func (m *CreateFrameMessage) Reset()                    { *m = CreateFrameMessage{} }
 func (m *CreateFrameMessage) String() string            { return proto.CompactTextString(m) }
 func (*CreateFrameMessage) ProtoMessage()               {}
-func (*CreateFrameMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{10} }
+func (*CreateFrameMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{11} }
 
 func (m *CreateFrameMessage) GetMeta() *FrameMeta {
 	if m != nil {
@@ -202,7 +224,65 @@ type DeleteFrameMessage struct {
 func (m *DeleteFrameMessage) Reset()                    { *m = DeleteFrameMessage{} }
 func (m *DeleteFrameMessage) String() string            { return proto.CompactTextString(m) }
 func (*DeleteFrameMessage) ProtoMessage()               {}
-func (*DeleteFrameMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{11} }
+func (*DeleteFrameMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{12} }
+
+type SetAliasMessage struct {
+	Alias string `protobuf:"bytes,1,opt,name=Alias,proto3" json:"Alias,omitempty"`
+	Index string `protobuf:"bytes,2,opt,name=Index,proto3" json:"Index,omitempty"`
+}
+
+func (m *SetAliasMessage) Reset()                    { *m = SetAliasMessage{} }
+func (m *SetAliasMessage) String() string            { return proto.CompactTextString(m) }
+func (*SetAliasMessage) ProtoMessage()               {}
+func (*SetAliasMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{13} }
+
+type DeleteAliasMessage struct {
+	Alias string `protobuf:"bytes,1,opt,name=Alias,proto3" json:"Alias,omitempty"`
+}
+
+func (m *DeleteAliasMessage) Reset()                    { *m = DeleteAliasMessage{} }
+func (m *DeleteAliasMessage) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAliasMessage) ProtoMessage()               {}
+func (*DeleteAliasMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{14} }
+
+type UpdateIndexMessage struct {
+	Index  string `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Option string `protobuf:"bytes,2,opt,name=Option,proto3" json:"Option,omitempty"`
+	Value  string `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (m *UpdateIndexMessage) Reset()                    { *m = UpdateIndexMessage{} }
+func (m *UpdateIndexMessage) String() string            { return proto.CompactTextString(m) }
+func (*UpdateIndexMessage) ProtoMessage()               {}
+func (*UpdateIndexMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{15} }
+
+type UpdateFrameMessage struct {
+	Index  string `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Frame  string `protobuf:"bytes,2,opt,name=Frame,proto3" json:"Frame,omitempty"`
+	Option string `protobuf:"bytes,3,opt,name=Option,proto3" json:"Option,omitempty"`
+	Value  string `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (m *UpdateFrameMessage) Reset()                    { *m = UpdateFrameMessage{} }
+func (m *UpdateFrameMessage) String() string            { return proto.CompactTextString(m) }
+func (*UpdateFrameMessage) ProtoMessage()               {}
+func (*UpdateFrameMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{16} }
+
+type AliasMeta struct {
+	Aliases map[string]string `protobuf:"bytes,1,rep,name=Aliases" json:"Aliases,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *AliasMeta) Reset()                    { *m = AliasMeta{} }
+func (m *AliasMeta) String() string            { return proto.CompactTextString(m) }
+func (*AliasMeta) ProtoMessage()               {}
+func (*AliasMeta) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{17} }
+
+func (m *AliasMeta) GetAliases() map[string]string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
 
 type Frame struct {
 	Name string     `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
@@ -212,7 +292,7 @@ type Frame struct {
 func (m *Frame) Reset()                    { *m = Frame{} }
 func (m *Frame) String() string            { return proto.CompactTextString(m) }
 func (*Frame) ProtoMessage()               {}
-func (*Frame) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{12} }
+func (*Frame) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{18} }
 
 func (m *Frame) GetMeta() *FrameMeta {
 	if m != nil {
@@ -232,7 +312,7 @@ type Index struct {
 func (m *Index) Reset()                    { *m = Index{} }
 func (m *Index) String() string            { return proto.CompactTextString(m) }
 func (*Index) ProtoMessage()               {}
-func (*Index) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{13} }
+func (*Index) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{19} }
 
 func (m *Index) GetMeta() *IndexMeta {
 	if m != nil {
@@ -249,15 +329,16 @@ func (m *Index) GetFrames() []*Frame {
 }
 
 type NodeStatus struct {
-	Host    string   `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
-	State   string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
-	Indexes []*Index `protobuf:"bytes,3,rep,name=Indexes" json:"Indexes,omitempty"`
+	Host    string            `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
+	State   string            `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	Indexes []*Index          `protobuf:"bytes,3,rep,name=Indexes" json:"Indexes,omitempty"`
+	Aliases map[string]string `protobuf:"bytes,4,rep,name=Aliases" json:"Aliases,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *NodeStatus) Reset()                    { *m = NodeStatus{} }
 func (m *NodeStatus) String() string            { return proto.CompactTextString(m) }
 func (*NodeStatus) ProtoMessage()               {}
-func (*NodeStatus) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{14} }
+func (*NodeStatus) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{20} }
 
 func (m *NodeStatus) GetIndexes() []*Index {
 	if m != nil {
@@ -266,6 +347,13 @@ func (m *NodeStatus) GetIndexes() []*Index {
 	return nil
 }
 
+func (m *NodeStatus) GetAliases() map[string]string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
 type ClusterStatus struct {
 	Nodes []*NodeStatus `protobuf:"bytes,1,rep,name=Nodes" json:"Nodes,omitempty"`
 }
@@ -273,7 +361,7 @@ type ClusterStatus struct {
 func (m *ClusterStatus) Reset()                    { *m = ClusterStatus{} }
 func (m *ClusterStatus) String() string            { return proto.CompactTextString(m) }
 func (*ClusterStatus) ProtoMessage()               {}
-func (*ClusterStatus) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{15} }
+func (*ClusterStatus) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{21} }
 
 func (m *ClusterStatus) GetNodes() []*NodeStatus {
 	if m != nil {
@@ -285,6 +373,7 @@ func (m *ClusterStatus) GetNodes() []*NodeStatus {
 func init() {
 	proto.RegisterType((*IndexMeta)(nil), "internal.IndexMeta")
 	proto.RegisterType((*FrameMeta)(nil), "internal.FrameMeta")
+	proto.RegisterType((*ClusterMeta)(nil), "internal.ClusterMeta")
 	proto.RegisterType((*ImportResponse)(nil), "internal.ImportResponse")
 	proto.RegisterType((*BlockDataRequest)(nil), "internal.BlockDataRequest")
 	proto.RegisterType((*BlockDataResponse)(nil), "internal.BlockDataResponse")
@@ -295,6 +384,11 @@ func init() {
 	proto.RegisterType((*CreateIndexMessage)(nil), "internal.CreateIndexMessage")
 	proto.RegisterType((*CreateFrameMessage)(nil), "internal.CreateFrameMessage")
 	proto.RegisterType((*DeleteFrameMessage)(nil), "internal.DeleteFrameMessage")
+	proto.RegisterType((*SetAliasMessage)(nil), "internal.SetAliasMessage")
+	proto.RegisterType((*DeleteAliasMessage)(nil), "internal.DeleteAliasMessage")
+	proto.RegisterType((*UpdateIndexMessage)(nil), "internal.UpdateIndexMessage")
+	proto.RegisterType((*UpdateFrameMessage)(nil), "internal.UpdateFrameMessage")
+	proto.RegisterType((*AliasMeta)(nil), "internal.AliasMeta")
 	proto.RegisterType((*Frame)(nil), "internal.Frame")
 	proto.RegisterType((*Index)(nil), "internal.Index")
 	proto.RegisterType((*NodeStatus)(nil), "internal.NodeStatus")
@@ -327,6 +421,11 @@ func (m *IndexMeta) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeQuantum)))
 		i += copy(dAtA[i:], m.TimeQuantum)
 	}
+	if m.ReplicaN != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.ReplicaN))
+	}
 	return i, nil
 }
 
@@ -378,6 +477,50 @@ func (m *FrameMeta) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeQuantum)))
 		i += copy(dAtA[i:], m.TimeQuantum)
 	}
+	if m.CacheInterval != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CacheInterval))
+	}
+	if m.CacheWindow != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CacheWindow))
+	}
+	if m.CacheHalfLife != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.CacheHalfLife))
+	}
+	return i, nil
+}
+
+func (m *ClusterMeta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterMeta) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.PartitionN != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.PartitionN))
+	}
+	if len(m.Hasher) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Hasher)))
+		i += copy(dAtA[i:], m.Hasher)
+	}
 	return i, nil
 }
 
@@ -535,6 +678,28 @@ func (m *Cache) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPrivate(dAtA, i, uint64(j5))
 		i += copy(dAtA[i:], dAtA6[:j5])
 	}
+	if len(m.Counts) > 0 {
+		dAtA8 := make([]byte, len(m.Counts)*10)
+		var j7 int
+		for _, num := range m.Counts {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(j7))
+		i += copy(dAtA[i:], dAtA8[:j7])
+	}
+	if m.OpN != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.OpN))
+	}
 	return i, nil
 }
 
@@ -660,11 +825,11 @@ func (m *CreateIndexMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPrivate(dAtA, i, uint64(m.Meta.Size()))
-		n7, err := m.Meta.MarshalTo(dAtA[i:])
+		n9, err := m.Meta.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n9
 	}
 	return i, nil
 }
@@ -700,11 +865,11 @@ func (m *CreateFrameMessage) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintPrivate(dAtA, i, uint64(m.Meta.Size()))
-		n8, err := m.Meta.MarshalTo(dAtA[i:])
+		n10, err := m.Meta.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n10
 	}
 	return i, nil
 }
@@ -739,6 +904,173 @@ func (m *DeleteFrameMessage) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SetAliasMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetAliasMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Alias) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Alias)))
+		i += copy(dAtA[i:], m.Alias)
+	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	return i, nil
+}
+
+func (m *DeleteAliasMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteAliasMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Alias) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Alias)))
+		i += copy(dAtA[i:], m.Alias)
+	}
+	return i, nil
+}
+
+func (m *UpdateIndexMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateIndexMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Option) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Option)))
+		i += copy(dAtA[i:], m.Option)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *UpdateFrameMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateFrameMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Frame) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Frame)))
+		i += copy(dAtA[i:], m.Frame)
+	}
+	if len(m.Option) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Option)))
+		i += copy(dAtA[i:], m.Option)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *AliasMeta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AliasMeta) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Aliases) > 0 {
+		for k, _ := range m.Aliases {
+			dAtA[i] = 0xa
+			i++
+			v := m.Aliases[k]
+			mapSize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			i = encodeVarintPrivate(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPrivate(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
+}
+
 func (m *Frame) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -764,11 +1096,11 @@ func (m *Frame) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPrivate(dAtA, i, uint64(m.Meta.Size()))
-		n9, err := m.Meta.MarshalTo(dAtA[i:])
+		n11, err := m.Meta.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n11
 	}
 	return i, nil
 }
@@ -798,11 +1130,11 @@ func (m *Index) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintPrivate(dAtA, i, uint64(m.Meta.Size()))
-		n10, err := m.Meta.MarshalTo(dAtA[i:])
+		n12, err := m.Meta.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n10
+		i += n12
 	}
 	if m.MaxSlice != 0 {
 		dAtA[i] = 0x18
@@ -822,21 +1154,21 @@ func (m *Index) MarshalTo(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Slices) > 0 {
-		dAtA12 := make([]byte, len(m.Slices)*10)
-		var j11 int
+		dAtA14 := make([]byte, len(m.Slices)*10)
+		var j13 int
 		for _, num := range m.Slices {
 			for num >= 1<<7 {
-				dAtA12[j11] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA14[j13] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j11++
+				j13++
 			}
-			dAtA12[j11] = uint8(num)
-			j11++
+			dAtA14[j13] = uint8(num)
+			j13++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPrivate(dAtA, i, uint64(j11))
-		i += copy(dAtA[i:], dAtA12[:j11])
+		i = encodeVarintPrivate(dAtA, i, uint64(j13))
+		i += copy(dAtA[i:], dAtA14[:j13])
 	}
 	return i, nil
 }
@@ -880,6 +1212,23 @@ func (m *NodeStatus) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Aliases) > 0 {
+		for k, _ := range m.Aliases {
+			dAtA[i] = 0x22
+			i++
+			v := m.Aliases[k]
+			mapSize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			i = encodeVarintPrivate(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPrivate(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPrivate(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -951,6 +1300,9 @@ func (m *IndexMeta) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
+	if m.ReplicaN != 0 {
+		n += 1 + sovPrivate(uint64(m.ReplicaN))
+	}
 	return n
 }
 
@@ -975,6 +1327,28 @@ func (m *FrameMeta) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
+	if m.CacheInterval != 0 {
+		n += 1 + sovPrivate(uint64(m.CacheInterval))
+	}
+	if m.CacheWindow != 0 {
+		n += 1 + sovPrivate(uint64(m.CacheWindow))
+	}
+	if m.CacheHalfLife != 0 {
+		n += 1 + sovPrivate(uint64(m.CacheHalfLife))
+	}
+	return n
+}
+
+func (m *ClusterMeta) Size() (n int) {
+	var l int
+	_ = l
+	if m.PartitionN != 0 {
+		n += 1 + sovPrivate(uint64(m.PartitionN))
+	}
+	l = len(m.Hasher)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
 	return n
 }
 
@@ -1042,6 +1416,16 @@ func (m *Cache) Size() (n int) {
 		}
 		n += 1 + sovPrivate(uint64(l)) + l
 	}
+	if len(m.Counts) > 0 {
+		l = 0
+		for _, e := range m.Counts {
+			l += sovPrivate(uint64(e))
+		}
+		n += 1 + sovPrivate(uint64(l)) + l
+	}
+	if m.OpN != 0 {
+		n += 1 + sovPrivate(uint64(m.OpN))
+	}
 	return n
 }
 
@@ -1131,34 +1515,112 @@ func (m *DeleteFrameMessage) Size() (n int) {
 	return n
 }
 
-func (m *Frame) Size() (n int) {
+func (m *SetAliasMessage) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Name)
+	l = len(m.Alias)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Meta != nil {
-		l = m.Meta.Size()
+	l = len(m.Index)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
 	return n
 }
 
-func (m *Index) Size() (n int) {
+func (m *DeleteAliasMessage) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Name)
+	l = len(m.Alias)
 	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.Meta != nil {
-		l = m.Meta.Size()
+	return n
+}
+
+func (m *UpdateIndexMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
 		n += 1 + l + sovPrivate(uint64(l))
 	}
-	if m.MaxSlice != 0 {
-		n += 1 + sovPrivate(uint64(m.MaxSlice))
-	}
+	l = len(m.Option)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateFrameMessage) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Frame)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Option)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	return n
+}
+
+func (m *AliasMeta) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Aliases) > 0 {
+		for k, v := range m.Aliases {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *Frame) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	return n
+}
+
+func (m *Index) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovPrivate(uint64(l))
+	}
+	if m.MaxSlice != 0 {
+		n += 1 + sovPrivate(uint64(m.MaxSlice))
+	}
 	if len(m.Frames) > 0 {
 		for _, e := range m.Frames {
 			l = e.Size()
@@ -1192,6 +1654,14 @@ func (m *NodeStatus) Size() (n int) {
 			n += 1 + l + sovPrivate(uint64(l))
 		}
 	}
+	if len(m.Aliases) > 0 {
+		for k, v := range m.Aliases {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPrivate(uint64(len(k))) + 1 + len(v) + sovPrivate(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPrivate(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -1307,6 +1777,25 @@ func (m *IndexMeta) Unmarshal(dAtA []byte) error {
 			}
 			m.TimeQuantum = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaN", wireType)
+			}
+			m.ReplicaN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicaN |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -1483,6 +1972,161 @@ func (m *FrameMeta) Unmarshal(dAtA []byte) error {
 			}
 			m.TimeQuantum = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheInterval", wireType)
+			}
+			m.CacheInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheInterval |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheWindow", wireType)
+			}
+			m.CacheWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheWindow |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CacheHalfLife", wireType)
+			}
+			m.CacheHalfLife = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CacheHalfLife |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionN", wireType)
+			}
+			m.PartitionN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartitionN |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hasher", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hasher = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -2023,40 +2667,121 @@ func (m *Cache) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPrivate(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPrivate
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *MaxSlicesResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPrivate
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
+		case 2:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPrivate
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPrivate
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Counts = append(m.Counts, v)
+				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Counts = append(m.Counts, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpN", wireType)
+			}
+			m.OpN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OpN |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaxSlicesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
 			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= (uint64(b) & 0x7F) << shift
@@ -2758,7 +3483,7 @@ func (m *DeleteFrameMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Frame) Unmarshal(dAtA []byte) error {
+func (m *SetAliasMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2781,15 +3506,15 @@ func (m *Frame) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Frame: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetAliasMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Frame: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetAliasMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2814,13 +3539,13 @@ func (m *Frame) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Alias = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -2830,24 +3555,20 @@ func (m *Frame) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = &FrameMeta{}
-			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2870,7 +3591,7 @@ func (m *Frame) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Index) Unmarshal(dAtA []byte) error {
+func (m *DeleteAliasMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2893,15 +3614,15 @@ func (m *Index) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Index: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteAliasMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Index: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteAliasMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2926,13 +3647,63 @@ func (m *Index) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Alias = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateIndexMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateIndexMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateIndexMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -2942,30 +3713,26 @@ func (m *Index) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Meta == nil {
-				m.Meta = &IndexMeta{}
-			}
-			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Index = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxSlice", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Option", wireType)
 			}
-			m.MaxSlice = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -2975,16 +3742,26 @@ func (m *Index) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxSlice |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Option = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Frames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPrivate
@@ -2994,37 +3771,641 @@ func (m *Index) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPrivate
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Frames = append(m.Frames, &Frame{})
-			if err := m.Frames[len(m.Frames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPrivate
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateFrameMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateFrameMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateFrameMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frame", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Frame = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Option", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Option = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AliasMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AliasMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AliasMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.Aliases == nil {
+				m.Aliases = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthPrivate
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.Aliases[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.Aliases[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Frame) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Frame: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Frame: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &FrameMeta{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Index) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Index: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Index: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &IndexMeta{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSlice", wireType)
+			}
+			m.MaxSlice = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSlice |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frames", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Frames = append(m.Frames, &Frame{})
+			if err := m.Frames[len(m.Frames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
 						break
 					}
 				}
@@ -3212,6 +4593,122 @@ func (m *NodeStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.Aliases == nil {
+				m.Aliases = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPrivate
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthPrivate
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.Aliases[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.Aliases[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -3422,45 +4919,60 @@ var (
 func init() { proto.RegisterFile("private.proto", fileDescriptorPrivate) }
 
 var fileDescriptorPrivate = []byte{
-	// 640 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x54, 0xc1, 0x4e, 0x14, 0x4d,
-	0x10, 0xfe, 0x67, 0x77, 0x96, 0x7f, 0xa7, 0x08, 0x08, 0x2d, 0x31, 0x23, 0x21, 0x9b, 0x4d, 0x1f,
-	0x04, 0x39, 0x70, 0xc0, 0x8b, 0x51, 0x0f, 0x86, 0x5d, 0x0c, 0x93, 0x08, 0xc6, 0x5e, 0xe2, 0xd1,
-	0xa4, 0x81, 0x8a, 0x4e, 0x98, 0x9d, 0x59, 0xa7, 0x7b, 0x80, 0xf5, 0xe0, 0x73, 0x98, 0x78, 0xf2,
-	0x01, 0x7c, 0x0f, 0x8f, 0x3e, 0x82, 0xc1, 0x17, 0x31, 0x5d, 0xdd, 0x33, 0xb3, 0x0e, 0x22, 0xd1,
-	0x5b, 0xd7, 0x57, 0xd5, 0xf5, 0x7d, 0xfd, 0x4d, 0xd5, 0xc0, 0xc2, 0x24, 0x8f, 0xcf, 0xa4, 0xc6,
-	0xad, 0x49, 0x9e, 0xe9, 0x8c, 0x75, 0xe3, 0x54, 0x63, 0x9e, 0xca, 0x84, 0xbf, 0x80, 0x20, 0x4a,
-	0x4f, 0xf0, 0x62, 0x1f, 0xb5, 0x64, 0x7d, 0x98, 0x1f, 0x64, 0x49, 0x31, 0x4e, 0x9f, 0xcb, 0x23,
-	0x4c, 0x42, 0xaf, 0xef, 0x6d, 0x04, 0x62, 0x16, 0x32, 0x15, 0x87, 0xf1, 0x18, 0x5f, 0x16, 0x32,
-	0xd5, 0xc5, 0x38, 0x6c, 0xd9, 0x8a, 0x19, 0x88, 0x7f, 0xf1, 0x20, 0x78, 0x96, 0xcb, 0x31, 0x52,
-	0xc7, 0x55, 0xe8, 0x8a, 0xec, 0x7c, 0xb6, 0x5d, 0x15, 0xb3, 0x7b, 0xb0, 0x18, 0xa5, 0x67, 0x98,
-	0x2b, 0xdc, 0x4d, 0xe5, 0x51, 0x82, 0x27, 0xd4, 0xae, 0x2b, 0x1a, 0x28, 0x5b, 0x83, 0x60, 0x20,
-	0x8f, 0xdf, 0xe2, 0xe1, 0x74, 0x82, 0x61, 0x9b, 0x9a, 0xd4, 0x40, 0x95, 0x1d, 0xc5, 0xef, 0x31,
-	0xf4, 0xfb, 0xde, 0xc6, 0x82, 0xa8, 0x81, 0xa6, 0xde, 0xce, 0x55, 0xbd, 0x1c, 0x16, 0xa3, 0xf1,
-	0x24, 0xcb, 0xb5, 0x40, 0x35, 0xc9, 0x52, 0x85, 0x6c, 0x09, 0xda, 0xbb, 0x79, 0xee, 0xe4, 0x9a,
-	0x23, 0xff, 0x00, 0x4b, 0x3b, 0x49, 0x76, 0x7c, 0x3a, 0x94, 0x5a, 0x0a, 0x7c, 0x57, 0xa0, 0xd2,
-	0x6c, 0x05, 0x3a, 0x64, 0x9c, 0xab, 0xb3, 0x81, 0x41, 0xe9, 0xf1, 0xce, 0x19, 0x1b, 0x18, 0x94,
-	0xee, 0x93, 0x7a, 0x5f, 0xd8, 0xc0, 0xa0, 0xa3, 0x24, 0x3e, 0xb6, 0xaa, 0x7d, 0x61, 0x03, 0xc6,
-	0xc0, 0x7f, 0x15, 0xe3, 0xb9, 0x93, 0x4a, 0x67, 0x1e, 0xc1, 0xf2, 0x0c, 0xbf, 0x93, 0x79, 0x07,
-	0xe6, 0x44, 0x76, 0x1e, 0x0d, 0x55, 0xe8, 0xf5, 0xdb, 0x1b, 0xbe, 0x70, 0x11, 0x19, 0x42, 0x5f,
-	0xcc, 0xa4, 0x5a, 0x94, 0xaa, 0x01, 0x7e, 0x17, 0x3a, 0xe4, 0x8e, 0x79, 0x65, 0x7d, 0xd7, 0x1c,
-	0xf9, 0x27, 0x0f, 0x96, 0xf7, 0xe5, 0x05, 0xc9, 0x50, 0x15, 0xcd, 0x1e, 0x04, 0x15, 0x48, 0xd5,
-	0xf3, 0xdb, 0x9b, 0x5b, 0xe5, 0xf8, 0x6c, 0x5d, 0xa9, 0xaf, 0x91, 0xdd, 0x54, 0xe7, 0x53, 0x51,
-	0x5f, 0x5e, 0x7d, 0x02, 0x8b, 0xbf, 0x26, 0x8d, 0x86, 0x53, 0x9c, 0x96, 0x4e, 0x9f, 0xe2, 0xd4,
-	0x78, 0x72, 0x26, 0x93, 0xc2, 0xfa, 0xe7, 0x0b, 0x1b, 0x3c, 0x6a, 0x3d, 0xf4, 0xf8, 0x6b, 0x60,
-	0x83, 0x1c, 0xa5, 0x46, 0x6a, 0xb0, 0x8f, 0x4a, 0xc9, 0x37, 0x78, 0xfd, 0x57, 0xb0, 0xce, 0xb6,
-	0x66, 0x9d, 0x5d, 0x83, 0x20, 0x52, 0x6e, 0xb6, 0xe8, 0x4b, 0x74, 0x45, 0x0d, 0xf0, 0x4d, 0x60,
-	0x43, 0x4c, 0x50, 0xa3, 0x5b, 0x87, 0x3f, 0xf4, 0xe7, 0xa3, 0x52, 0xcb, 0xcd, 0xb5, 0x6c, 0x1d,
-	0x7c, 0xb3, 0x09, 0x24, 0x65, 0x7e, 0xfb, 0x76, 0x6d, 0x5d, 0xb5, 0x76, 0x82, 0x0a, 0x78, 0x5c,
-	0x36, 0x75, 0xdb, 0x73, 0xc3, 0x03, 0x7f, 0x33, 0x66, 0x25, 0x55, 0xbb, 0x49, 0x55, 0xed, 0xa3,
-	0xa3, 0x7a, 0x5a, 0xbe, 0xf5, 0x5f, 0xa9, 0xf8, 0xd0, 0xa1, 0x66, 0x5c, 0x0f, 0x4c, 0xd6, 0xde,
-	0xa1, 0xf3, 0xf5, 0x4f, 0x6e, 0xea, 0xf8, 0xec, 0x39, 0xca, 0xbf, 0x6b, 0xd3, 0x70, 0xce, 0xfc,
-	0x64, 0xca, 0xc1, 0x72, 0x1b, 0x56, 0xc5, 0x6c, 0x1d, 0xe6, 0x88, 0x55, 0x85, 0x3e, 0xcd, 0xee,
-	0xad, 0x86, 0x1a, 0xe1, 0xd2, 0x66, 0x9d, 0xdc, 0x90, 0x77, 0xec, 0x3a, 0xd9, 0x88, 0x4b, 0x80,
-	0x83, 0xec, 0x04, 0x47, 0x5a, 0xea, 0x42, 0x19, 0x9d, 0x7b, 0x99, 0xd2, 0xa5, 0x4e, 0x73, 0xa6,
-	0x69, 0xd3, 0x52, 0x57, 0x0e, 0x51, 0xc0, 0xee, 0xc3, 0xff, 0xa4, 0x13, 0x55, 0xd8, 0x6e, 0x32,
-	0x53, 0x42, 0x94, 0x79, 0xfe, 0x18, 0x16, 0x06, 0x49, 0xa1, 0x34, 0xe6, 0x8e, 0x65, 0x13, 0x3a,
-	0x86, 0xb3, 0xdc, 0xb7, 0x95, 0xfa, 0x66, 0x2d, 0x45, 0xd8, 0x92, 0x9d, 0xa5, 0xaf, 0x97, 0x3d,
-	0xef, 0xdb, 0x65, 0xcf, 0xfb, 0x7e, 0xd9, 0xf3, 0x3e, 0xfe, 0xe8, 0xfd, 0x77, 0x34, 0x47, 0xff,
-	0xf8, 0x07, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x1c, 0x79, 0x08, 0xf4, 0x05, 0x00, 0x00,
+	// 874 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x41, 0x6f, 0x1b, 0x45,
+	0x14, 0x66, 0xed, 0xb5, 0xe3, 0x7d, 0xc1, 0x69, 0x3a, 0x54, 0xd1, 0x2a, 0xaa, 0x2c, 0x33, 0x42,
+	0x34, 0xe4, 0x90, 0x43, 0xb9, 0xa0, 0x14, 0x24, 0xa8, 0x13, 0x14, 0x4b, 0x8d, 0x0b, 0xe3, 0x52,
+	0x38, 0x21, 0x4d, 0xe2, 0x57, 0xba, 0xca, 0x7a, 0x77, 0xd9, 0x19, 0x27, 0x35, 0x07, 0x2e, 0xfc,
+	0x09, 0xa4, 0x9e, 0xf8, 0x37, 0x1c, 0x39, 0x73, 0x42, 0xe1, 0x8f, 0xa0, 0x79, 0x33, 0xb3, 0xbb,
+	0x75, 0x03, 0x86, 0x4a, 0xdc, 0xf6, 0x7b, 0xf3, 0xe6, 0x7d, 0xef, 0x7d, 0xef, 0xcd, 0xb3, 0xa1,
+	0x5f, 0x94, 0xc9, 0xa5, 0xd4, 0x78, 0x50, 0x94, 0xb9, 0xce, 0x59, 0x2f, 0xc9, 0x34, 0x96, 0x99,
+	0x4c, 0xf9, 0x05, 0x44, 0xe3, 0x6c, 0x86, 0x2f, 0x4e, 0x51, 0x4b, 0x36, 0x84, 0xcd, 0x51, 0x9e,
+	0x2e, 0xe6, 0xd9, 0x23, 0x79, 0x86, 0x69, 0x1c, 0x0c, 0x83, 0xbd, 0x48, 0x34, 0x4d, 0xc6, 0xe3,
+	0x49, 0x32, 0xc7, 0x2f, 0x17, 0x32, 0xd3, 0x8b, 0x79, 0xdc, 0xb2, 0x1e, 0x0d, 0x13, 0xdb, 0x85,
+	0x9e, 0xc0, 0x22, 0x4d, 0xce, 0xe5, 0x24, 0x6e, 0x0f, 0x83, 0xbd, 0xbe, 0xa8, 0x30, 0x7f, 0xd9,
+	0x82, 0xe8, 0xf3, 0x52, 0xce, 0x91, 0xd8, 0x8c, 0x67, 0x7e, 0xd5, 0xa4, 0xaa, 0x30, 0x7b, 0x1f,
+	0xb6, 0xc6, 0xd9, 0x25, 0x96, 0x0a, 0x8f, 0x33, 0x79, 0x96, 0xe2, 0x8c, 0xa8, 0x7a, 0x62, 0xc5,
+	0xca, 0xee, 0x42, 0x34, 0x92, 0xe7, 0xcf, 0xf1, 0xc9, 0xb2, 0x40, 0xa2, 0x8b, 0x44, 0x6d, 0xa8,
+	0x4e, 0xa7, 0xc9, 0x0f, 0x18, 0x87, 0x94, 0x4c, 0x6d, 0x58, 0xad, 0xa5, 0xf3, 0x7a, 0x2d, 0xef,
+	0x41, 0x9f, 0xdc, 0xc7, 0x46, 0xad, 0x4b, 0x99, 0xc6, 0xdd, 0x61, 0xb0, 0xd7, 0x16, 0xaf, 0x1a,
+	0x49, 0x35, 0x63, 0xf8, 0x3a, 0xc9, 0x66, 0xf9, 0x55, 0xbc, 0x41, 0x3e, 0x4d, 0x53, 0x15, 0xe7,
+	0x44, 0xa6, 0xcf, 0x1e, 0x25, 0xcf, 0x30, 0xee, 0x35, 0xe2, 0x78, 0x23, 0x3f, 0x86, 0xcd, 0x51,
+	0xba, 0x50, 0x1a, 0x4b, 0x92, 0x67, 0x00, 0xf0, 0x85, 0x2c, 0x75, 0xa2, 0x93, 0x3c, 0x9b, 0x90,
+	0x40, 0x7d, 0xd1, 0xb0, 0xb0, 0x1d, 0xe8, 0x9e, 0x48, 0xf5, 0x1c, 0x4b, 0xd7, 0x05, 0x87, 0x38,
+	0x87, 0xad, 0xf1, 0xbc, 0xc8, 0x4b, 0x2d, 0x50, 0x15, 0x79, 0xa6, 0x90, 0x6d, 0x43, 0xfb, 0xb8,
+	0x2c, 0x9d, 0xc6, 0xe6, 0x93, 0xff, 0x08, 0xdb, 0x0f, 0xd3, 0xfc, 0xfc, 0xe2, 0x48, 0x6a, 0x29,
+	0xf0, 0xfb, 0x05, 0x2a, 0xcd, 0xee, 0x40, 0x87, 0x26, 0xc1, 0xf9, 0x59, 0x60, 0xac, 0xd4, 0x31,
+	0x47, 0x62, 0x81, 0xb1, 0xd2, 0x7d, 0x92, 0x3c, 0x14, 0x16, 0x18, 0xeb, 0x34, 0x4d, 0xce, 0xad,
+	0xd4, 0xa1, 0xb0, 0x80, 0x31, 0x08, 0x9f, 0x26, 0x78, 0xe5, 0xf4, 0xa5, 0x6f, 0x3e, 0x86, 0xdb,
+	0x0d, 0x7e, 0x97, 0xe6, 0x0e, 0x74, 0x45, 0x7e, 0x35, 0x3e, 0x52, 0x71, 0x30, 0x6c, 0xef, 0x85,
+	0xc2, 0x21, 0xea, 0x22, 0x8d, 0xa0, 0x39, 0x6a, 0xd1, 0x51, 0x6d, 0xe0, 0x23, 0xe8, 0x90, 0x8c,
+	0xa6, 0xca, 0xfa, 0xae, 0xf9, 0x34, 0x01, 0x47, 0xf9, 0x22, 0xd3, 0xfe, 0x96, 0x43, 0xc6, 0xf3,
+	0x71, 0x31, 0x71, 0xb9, 0x9b, 0x4f, 0xfe, 0x32, 0x80, 0xdb, 0xa7, 0xf2, 0x05, 0x25, 0xac, 0xaa,
+	0x84, 0x4e, 0x20, 0xaa, 0x8c, 0x14, 0x77, 0xf3, 0xfe, 0xfe, 0x81, 0x7f, 0x39, 0x07, 0xaf, 0xf9,
+	0xd7, 0x96, 0xe3, 0x4c, 0x97, 0x4b, 0x51, 0x5f, 0xde, 0xfd, 0x18, 0xb6, 0x5e, 0x3d, 0x34, 0x39,
+	0x5c, 0xe0, 0xd2, 0xf7, 0xe4, 0x02, 0x97, 0x46, 0xbd, 0x4b, 0x99, 0x2e, 0xac, 0xd2, 0xa1, 0xb0,
+	0xe0, 0xb0, 0xf5, 0x51, 0xc0, 0xbf, 0x05, 0x36, 0x2a, 0x51, 0x6a, 0xa4, 0x00, 0xa7, 0xa8, 0x94,
+	0xfc, 0x0e, 0xff, 0xbe, 0x5f, 0xb6, 0x07, 0xad, 0x66, 0x0f, 0xee, 0x42, 0x34, 0x56, 0xee, 0xe9,
+	0x50, 0xdd, 0x3d, 0x51, 0x1b, 0xf8, 0x3e, 0xb0, 0x23, 0x4c, 0x51, 0xa3, 0xdb, 0x04, 0xff, 0x10,
+	0x9f, 0x4f, 0x7d, 0x2e, 0xeb, 0x7d, 0xd9, 0x3d, 0x08, 0xcd, 0x24, 0x53, 0x2a, 0x9b, 0xf7, 0xdf,
+	0xa9, 0xa5, 0xab, 0x36, 0x8e, 0x20, 0x07, 0x9e, 0xf8, 0xa0, 0x6e, 0x39, 0xac, 0x29, 0xf0, 0x86,
+	0x81, 0xf4, 0x54, 0xed, 0x55, 0xaa, 0x6a, 0xdd, 0x38, 0xaa, 0x4f, 0x7d, 0xad, 0x6f, 0x4a, 0xc5,
+	0x3f, 0x81, 0x5b, 0x53, 0xd4, 0x9f, 0xa5, 0x89, 0x54, 0x8d, 0xeb, 0x84, 0xfd, 0x75, 0x02, 0x75,
+	0xd0, 0x56, 0x53, 0xc0, 0x4a, 0xec, 0xf5, 0x11, 0xf8, 0x37, 0xc0, 0xbe, 0x2a, 0x66, 0xff, 0x4e,
+	0xec, 0x1d, 0xe8, 0x3e, 0x2e, 0xcc, 0x66, 0xf0, 0xeb, 0xc0, 0x22, 0xe3, 0xfd, 0x94, 0xc6, 0xca,
+	0x6e, 0x47, 0x0b, 0x78, 0xe6, 0x23, 0xbf, 0xb1, 0xe2, 0x35, 0x5f, 0xfb, 0x66, 0xbe, 0xb0, 0xc9,
+	0xf7, 0x53, 0x00, 0x91, 0x2b, 0x58, 0x4b, 0x76, 0x08, 0x1b, 0x04, 0xaa, 0x67, 0x35, 0xac, 0x1b,
+	0x56, 0x79, 0x1d, 0x38, 0x17, 0xfb, 0x98, 0xfc, 0x85, 0xdd, 0x43, 0x78, 0xbb, 0x79, 0xb0, 0xee,
+	0x21, 0x45, 0xcd, 0x87, 0x74, 0xe4, 0x2a, 0x31, 0x3b, 0x69, 0x62, 0x2a, 0xb2, 0xb7, 0xc2, 0x49,
+	0x73, 0x84, 0x5a, 0xeb, 0x46, 0xe8, 0x97, 0xc0, 0xc9, 0xf4, 0xdf, 0xc2, 0xac, 0x0c, 0xbd, 0xf9,
+	0xf9, 0xf3, 0x3b, 0xc1, 0xad, 0xa2, 0x0a, 0xb3, 0x7b, 0xd0, 0x25, 0x56, 0x15, 0x87, 0xa4, 0xcf,
+	0xad, 0x95, 0x6c, 0x84, 0x3b, 0x36, 0x5d, 0x70, 0xfb, 0xa9, 0x63, 0x57, 0x9c, 0x45, 0xfc, 0xf7,
+	0x00, 0x60, 0x92, 0xcf, 0x70, 0xaa, 0xa5, 0x5e, 0x28, 0x93, 0xe8, 0x49, 0xae, 0xb4, 0x4f, 0xd4,
+	0x7c, 0xd3, 0xa6, 0xd0, 0x52, 0x57, 0x32, 0x11, 0x60, 0x1f, 0xc0, 0x06, 0x25, 0x8a, 0x2a, 0x6e,
+	0xaf, 0x52, 0xd3, 0x81, 0xf0, 0xe7, 0xec, 0x41, 0xdd, 0x45, 0x9b, 0xe5, 0xbb, 0xb5, 0x6b, 0xcd,
+	0xfd, 0x3f, 0xb4, 0xf1, 0x01, 0xf4, 0xdd, 0x0f, 0xa5, 0x2b, 0x6f, 0x1f, 0x3a, 0x86, 0xd0, 0x4f,
+	0xd3, 0x9d, 0x9b, 0xf2, 0x10, 0xd6, 0xe5, 0xe1, 0xf6, 0xaf, 0xd7, 0x83, 0xe0, 0xb7, 0xeb, 0x41,
+	0xf0, 0xc7, 0xf5, 0x20, 0xf8, 0xf9, 0xcf, 0xc1, 0x5b, 0x67, 0x5d, 0xfa, 0x4f, 0xf4, 0xe1, 0x5f,
+	0x03, 0x00, 0x6e, 0x70, 0xff, 0x0b, 0x24, 0x09, 0x00, 0x00,
 }
@@ -32,6 +32,10 @@
 		QueryRequest
 		QueryResponse
 		QueryResult
+		QueryPlan
+		PlanNode
+		PlanTiming
+		Estimate
 		ImportRequest
 */
 package internal
@@ -144,6 +148,9 @@ type QueryRequest struct {
 	ColumnAttrs bool     `protobuf:"varint,3,opt,name=ColumnAttrs,proto3" json:"ColumnAttrs,omitempty"`
 	Quantum     string   `protobuf:"bytes,4,opt,name=Quantum,proto3" json:"Quantum,omitempty"`
 	Remote      bool     `protobuf:"varint,5,opt,name=Remote,proto3" json:"Remote,omitempty"`
+	Index       string   `protobuf:"bytes,6,opt,name=Index,proto3" json:"Index,omitempty"`
+	Sample      float64  `protobuf:"fixed64,7,opt,name=Sample,proto3" json:"Sample,omitempty"`
+	Explain     bool     `protobuf:"varint,8,opt,name=Explain,proto3" json:"Explain,omitempty"`
 }
 
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
@@ -177,10 +184,13 @@ func (m *QueryResponse) GetColumnAttrSets() []*ColumnAttrSet {
 }
 
 type QueryResult struct {
-	Bitmap  *Bitmap `protobuf:"bytes,1,opt,name=Bitmap" json:"Bitmap,omitempty"`
-	N       uint64  `protobuf:"varint,2,opt,name=N,proto3" json:"N,omitempty"`
-	Pairs   []*Pair `protobuf:"bytes,3,rep,name=Pairs" json:"Pairs,omitempty"`
-	Changed bool    `protobuf:"varint,4,opt,name=Changed,proto3" json:"Changed,omitempty"`
+	Bitmap      *Bitmap    `protobuf:"bytes,1,opt,name=Bitmap" json:"Bitmap,omitempty"`
+	N           uint64     `protobuf:"varint,2,opt,name=N,proto3" json:"N,omitempty"`
+	Pairs       []*Pair    `protobuf:"bytes,3,rep,name=Pairs" json:"Pairs,omitempty"`
+	Changed     bool       `protobuf:"varint,4,opt,name=Changed,proto3" json:"Changed,omitempty"`
+	SliceCounts []uint64   `protobuf:"varint,5,rep,packed,name=SliceCounts" json:"SliceCounts,omitempty"`
+	Estimate    *Estimate  `protobuf:"bytes,6,opt,name=Estimate" json:"Estimate,omitempty"`
+	Plan        *QueryPlan `protobuf:"bytes,7,opt,name=Plan" json:"Plan,omitempty"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
@@ -202,6 +212,90 @@ func (m *QueryResult) GetPairs() []*Pair {
 	return nil
 }
 
+func (m *QueryResult) GetEstimate() *Estimate {
+	if m != nil {
+		return m.Estimate
+	}
+	return nil
+}
+
+func (m *QueryResult) GetPlan() *QueryPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+type QueryPlan struct {
+	Call       string        `protobuf:"bytes,1,opt,name=Call,proto3" json:"Call,omitempty"`
+	Nodes      []*PlanNode   `protobuf:"bytes,2,rep,name=Nodes" json:"Nodes,omitempty"`
+	FragmentN  uint64        `protobuf:"varint,3,opt,name=FragmentN,proto3" json:"FragmentN,omitempty"`
+	ContainerN uint64        `protobuf:"varint,4,opt,name=ContainerN,proto3" json:"ContainerN,omitempty"`
+	Children   []*QueryPlan  `protobuf:"bytes,5,rep,name=Children" json:"Children,omitempty"`
+	Duration   int64         `protobuf:"varint,6,opt,name=Duration,proto3" json:"Duration,omitempty"`
+	Timings    []*PlanTiming `protobuf:"bytes,7,rep,name=Timings" json:"Timings,omitempty"`
+}
+
+func (m *QueryPlan) Reset()                    { *m = QueryPlan{} }
+func (m *QueryPlan) String() string            { return proto.CompactTextString(m) }
+func (*QueryPlan) ProtoMessage()               {}
+func (*QueryPlan) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{9} }
+
+func (m *QueryPlan) GetNodes() []*PlanNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *QueryPlan) GetChildren() []*QueryPlan {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *QueryPlan) GetTimings() []*PlanTiming {
+	if m != nil {
+		return m.Timings
+	}
+	return nil
+}
+
+type PlanNode struct {
+	Host   string   `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
+	Slices []uint64 `protobuf:"varint,2,rep,packed,name=Slices" json:"Slices,omitempty"`
+}
+
+func (m *PlanNode) Reset()                    { *m = PlanNode{} }
+func (m *PlanNode) String() string            { return proto.CompactTextString(m) }
+func (*PlanNode) ProtoMessage()               {}
+func (*PlanNode) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{10} }
+
+type PlanTiming struct {
+	Host     string   `protobuf:"bytes,1,opt,name=Host,proto3" json:"Host,omitempty"`
+	Slices   []uint64 `protobuf:"varint,2,rep,packed,name=Slices" json:"Slices,omitempty"`
+	Duration int64    `protobuf:"varint,3,opt,name=Duration,proto3" json:"Duration,omitempty"`
+}
+
+func (m *PlanTiming) Reset()                    { *m = PlanTiming{} }
+func (m *PlanTiming) String() string            { return proto.CompactTextString(m) }
+func (*PlanTiming) ProtoMessage()               {}
+func (*PlanTiming) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{11} }
+
+type Estimate struct {
+	Value   uint64 `protobuf:"varint,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	Lower   uint64 `protobuf:"varint,2,opt,name=Lower,proto3" json:"Lower,omitempty"`
+	Upper   uint64 `protobuf:"varint,3,opt,name=Upper,proto3" json:"Upper,omitempty"`
+	SliceN  uint64 `protobuf:"varint,4,opt,name=SliceN,proto3" json:"SliceN,omitempty"`
+	SampleN uint64 `protobuf:"varint,5,opt,name=SampleN,proto3" json:"SampleN,omitempty"`
+}
+
+func (m *Estimate) Reset()                    { *m = Estimate{} }
+func (m *Estimate) String() string            { return proto.CompactTextString(m) }
+func (*Estimate) ProtoMessage()               {}
+func (*Estimate) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{12} }
+
 type ImportRequest struct {
 	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Frame      string   `protobuf:"bytes,2,opt,name=Frame,proto3" json:"Frame,omitempty"`
@@ -214,7 +308,7 @@ type ImportRequest struct {
 func (m *ImportRequest) Reset()                    { *m = ImportRequest{} }
 func (m *ImportRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()               {}
-func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{9} }
+func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{13} }
 
 func init() {
 	proto.RegisterType((*Bitmap)(nil), "internal.Bitmap")
@@ -226,6 +320,10 @@ func init() {
 	proto.RegisterType((*QueryRequest)(nil), "internal.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "internal.QueryResponse")
 	proto.RegisterType((*QueryResult)(nil), "internal.QueryResult")
+	proto.RegisterType((*QueryPlan)(nil), "internal.QueryPlan")
+	proto.RegisterType((*PlanNode)(nil), "internal.PlanNode")
+	proto.RegisterType((*PlanTiming)(nil), "internal.PlanTiming")
+	proto.RegisterType((*Estimate)(nil), "internal.Estimate")
 	proto.RegisterType((*ImportRequest)(nil), "internal.ImportRequest")
 }
 func (m *Bitmap) Marshal() (dAtA []byte, err error) {
@@ -520,6 +618,27 @@ func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.Index) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if m.Sample != 0 {
+		dAtA[i] = 0x39
+		i++
+		i = encodeFixed64Public(dAtA, i, uint64(math.Float64bits(float64(m.Sample))))
+	}
+	if m.Explain {
+		dAtA[i] = 0x40
+		i++
+		if m.Explain {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -623,6 +742,248 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.SliceCounts) > 0 {
+		dAtA7 := make([]byte, len(m.SliceCounts)*10)
+		var j6 int
+		for _, num := range m.SliceCounts {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
+	}
+	if m.Estimate != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Estimate.Size()))
+		n8, err := m.Estimate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.Plan != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Plan.Size()))
+		n9, err := m.Plan.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	return i, nil
+}
+
+func (m *QueryPlan) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPlan) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Call) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Call)))
+		i += copy(dAtA[i:], m.Call)
+	}
+	if len(m.Nodes) > 0 {
+		for _, msg := range m.Nodes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.FragmentN != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.FragmentN))
+	}
+	if m.ContainerN != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.ContainerN))
+	}
+	if len(m.Children) > 0 {
+		for _, msg := range m.Children {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Duration != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Duration))
+	}
+	if len(m.Timings) > 0 {
+		for _, msg := range m.Timings {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PlanNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlanNode) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Host) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Host)))
+		i += copy(dAtA[i:], m.Host)
+	}
+	if len(m.Slices) > 0 {
+		dAtA11 := make([]byte, len(m.Slices)*10)
+		var j10 int
+		for _, num := range m.Slices {
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
+	}
+	return i, nil
+}
+
+func (m *PlanTiming) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlanTiming) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Host) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Host)))
+		i += copy(dAtA[i:], m.Host)
+	}
+	if len(m.Slices) > 0 {
+		dAtA13 := make([]byte, len(m.Slices)*10)
+		var j12 int
+		for _, num := range m.Slices {
+			for num >= 1<<7 {
+				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j12++
+			}
+			dAtA13[j12] = uint8(num)
+			j12++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j12))
+		i += copy(dAtA[i:], dAtA13[:j12])
+	}
+	if m.Duration != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Duration))
+	}
+	return i, nil
+}
+
+func (m *Estimate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Estimate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Value))
+	}
+	if m.Lower != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Lower))
+	}
+	if m.Upper != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Upper))
+	}
+	if m.SliceN != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.SliceN))
+	}
+	if m.SampleN != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.SampleN))
+	}
 	return i, nil
 }
 
@@ -659,56 +1020,56 @@ func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Slice))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA7 := make([]byte, len(m.RowIDs)*10)
-		var j6 int
+		dAtA15 := make([]byte, len(m.RowIDs)*10)
+		var j14 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j6++
+				j14++
 			}
-			dAtA7[j6] = uint8(num)
-			j6++
+			dAtA15[j14] = uint8(num)
+			j14++
 		}
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j6))
-		i += copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintPublic(dAtA, i, uint64(j14))
+		i += copy(dAtA[i:], dAtA15[:j14])
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA9 := make([]byte, len(m.ColumnIDs)*10)
-		var j8 int
+		dAtA17 := make([]byte, len(m.ColumnIDs)*10)
+		var j16 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j8++
+				j16++
 			}
-			dAtA9[j8] = uint8(num)
-			j8++
+			dAtA17[j16] = uint8(num)
+			j16++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j8))
-		i += copy(dAtA[i:], dAtA9[:j8])
+		i = encodeVarintPublic(dAtA, i, uint64(j16))
+		i += copy(dAtA[i:], dAtA17[:j16])
 	}
 	if len(m.Timestamps) > 0 {
-		dAtA11 := make([]byte, len(m.Timestamps)*10)
-		var j10 int
+		dAtA19 := make([]byte, len(m.Timestamps)*10)
+		var j18 int
 		for _, num1 := range m.Timestamps {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j10++
+				j18++
 			}
-			dAtA11[j10] = uint8(num)
-			j10++
+			dAtA19[j18] = uint8(num)
+			j18++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j10))
-		i += copy(dAtA[i:], dAtA11[:j10])
+		i = encodeVarintPublic(dAtA, i, uint64(j18))
+		i += copy(dAtA[i:], dAtA19[:j18])
 	}
 	return i, nil
 }
@@ -863,6 +1224,16 @@ func (m *QueryRequest) Size() (n int) {
 	if m.Remote {
 		n += 2
 	}
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Sample != 0 {
+		n += 9
+	}
+	if m.Explain {
+		n += 2
+	}
 	return n
 }
 
@@ -907,11 +1278,121 @@ func (m *QueryResult) Size() (n int) {
 	if m.Changed {
 		n += 2
 	}
-	return n
-}
-
-func (m *ImportRequest) Size() (n int) {
-	var l int
+	if len(m.SliceCounts) > 0 {
+		l = 0
+		for _, e := range m.SliceCounts {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if m.Estimate != nil {
+		l = m.Estimate.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Plan != nil {
+		l = m.Plan.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPlan) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Call)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.FragmentN != 0 {
+		n += 1 + sovPublic(uint64(m.FragmentN))
+	}
+	if m.ContainerN != 0 {
+		n += 1 + sovPublic(uint64(m.ContainerN))
+	}
+	if len(m.Children) > 0 {
+		for _, e := range m.Children {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.Duration != 0 {
+		n += 1 + sovPublic(uint64(m.Duration))
+	}
+	if len(m.Timings) > 0 {
+		for _, e := range m.Timings {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PlanNode) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Slices) > 0 {
+		l = 0
+		for _, e := range m.Slices {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *PlanTiming) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Slices) > 0 {
+		l = 0
+		for _, e := range m.Slices {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if m.Duration != 0 {
+		n += 1 + sovPublic(uint64(m.Duration))
+	}
+	return n
+}
+
+func (m *Estimate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Value != 0 {
+		n += 1 + sovPublic(uint64(m.Value))
+	}
+	if m.Lower != 0 {
+		n += 1 + sovPublic(uint64(m.Lower))
+	}
+	if m.Upper != 0 {
+		n += 1 + sovPublic(uint64(m.Upper))
+	}
+	if m.SliceN != 0 {
+		n += 1 + sovPublic(uint64(m.SliceN))
+	}
+	if m.SampleN != 0 {
+		n += 1 + sovPublic(uint64(m.SampleN))
+	}
+	return n
+}
+
+func (m *ImportRequest) Size() (n int) {
+	var l int
 	_ = l
 	l = len(m.Index)
 	if l > 0 {
@@ -1853,6 +2334,73 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Remote = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sample", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 8
+			v = uint64(dAtA[iNdEx-8])
+			v |= uint64(dAtA[iNdEx-7]) << 8
+			v |= uint64(dAtA[iNdEx-6]) << 16
+			v |= uint64(dAtA[iNdEx-5]) << 24
+			v |= uint64(dAtA[iNdEx-4]) << 32
+			v |= uint64(dAtA[iNdEx-3]) << 40
+			v |= uint64(dAtA[iNdEx-2]) << 48
+			v |= uint64(dAtA[iNdEx-1]) << 56
+			m.Sample = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Explain", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Explain = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -2147,6 +2695,809 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Changed = bool(v != 0)
+		case 5:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.SliceCounts = append(m.SliceCounts, v)
+				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.SliceCounts = append(m.SliceCounts, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SliceCounts", wireType)
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Estimate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Estimate == nil {
+				m.Estimate = &Estimate{}
+			}
+			if err := m.Estimate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Plan", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Plan == nil {
+				m.Plan = &QueryPlan{}
+			}
+			if err := m.Plan.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryPlan) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryPlan: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryPlan: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Call", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Call = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &PlanNode{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FragmentN", wireType)
+			}
+			m.FragmentN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FragmentN |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerN", wireType)
+			}
+			m.ContainerN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContainerN |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Children", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Children = append(m.Children, &QueryPlan{})
+			if err := m.Children[len(m.Children)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			m.Duration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Duration |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timings = append(m.Timings, &PlanTiming{})
+			if err := m.Timings[len(m.Timings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlanNode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlanNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlanNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Slices = append(m.Slices, v)
+				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Slices = append(m.Slices, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Slices", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlanTiming) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlanTiming: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlanTiming: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Slices = append(m.Slices, v)
+				}
+			} else if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Slices = append(m.Slices, v)
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Slices", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			m.Duration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Duration |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Estimate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Estimate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Estimate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lower", wireType)
+			}
+			m.Lower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lower |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upper", wireType)
+			}
+			m.Upper = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Upper |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SliceN", wireType)
+			}
+			m.SliceN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SliceN |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleN", wireType)
+			}
+			m.SampleN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleN |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -2589,41 +3940,58 @@ var (
 func init() { proto.RegisterFile("public.proto", fileDescriptorPublic) }
 
 var fileDescriptorPublic = []byte{
-	// 576 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4b, 0x8e, 0xd3, 0x40,
-	0x10, 0xa5, 0x63, 0xe7, 0x57, 0xf9, 0x28, 0x6a, 0xf1, 0xb1, 0x10, 0x8a, 0x2c, 0x8b, 0x85, 0x57,
-	0x19, 0x69, 0x38, 0x00, 0xc2, 0x49, 0x46, 0xb2, 0x10, 0x23, 0xa6, 0x33, 0xb0, 0xf7, 0xcc, 0xb4,
-	0x06, 0x4b, 0xfe, 0xd1, 0xdd, 0x16, 0xe4, 0x00, 0xec, 0x91, 0xd8, 0x70, 0x03, 0x38, 0x0a, 0x4b,
-	0x8e, 0x80, 0xc2, 0x45, 0x50, 0x75, 0xbb, 0x63, 0x0f, 0x0b, 0xc4, 0xae, 0xdf, 0xab, 0xae, 0x76,
-	0xbd, 0x7a, 0x55, 0x86, 0x69, 0x55, 0x5f, 0x65, 0xe9, 0xf5, 0xaa, 0x12, 0xa5, 0x2a, 0xe9, 0x28,
-	0x2d, 0x14, 0x17, 0x45, 0x92, 0x05, 0x11, 0x0c, 0xa2, 0x54, 0xe5, 0x49, 0x45, 0x29, 0xb8, 0x51,
-	0xaa, 0xa4, 0x47, 0x7c, 0x27, 0x74, 0x99, 0x3e, 0xd3, 0xa7, 0xd0, 0x7f, 0xa1, 0x94, 0x90, 0x5e,
-	0xcf, 0x77, 0xc2, 0xc9, 0xe9, 0x7c, 0x65, 0xf3, 0x56, 0x48, 0x33, 0x13, 0x0c, 0x56, 0xe0, 0xbe,
-	0x4e, 0x52, 0x41, 0x17, 0xe0, 0xbc, 0xe4, 0x7b, 0x8f, 0xf8, 0x24, 0x74, 0x19, 0x1e, 0xe9, 0x7d,
-	0xe8, 0xaf, 0xcb, 0xba, 0x50, 0x5e, 0x4f, 0x73, 0x06, 0x04, 0x6f, 0xc0, 0x89, 0x52, 0x85, 0x41,
-	0x56, 0x7e, 0x88, 0x37, 0x4d, 0x82, 0x01, 0xf4, 0x31, 0x8c, 0xd6, 0x65, 0x56, 0xe7, 0x45, 0xbc,
-	0x69, 0xb2, 0x8e, 0x98, 0x3e, 0x81, 0xf1, 0x65, 0x9a, 0x73, 0xa9, 0x92, 0xbc, 0xf2, 0x1c, 0x9f,
-	0x84, 0x0e, 0x6b, 0x89, 0x60, 0x0b, 0x33, 0x73, 0x13, 0xab, 0xda, 0x71, 0x45, 0xe7, 0xd0, 0x3b,
-	0xbe, 0xde, 0x8b, 0x37, 0xff, 0xa9, 0xe6, 0x3b, 0x01, 0x17, 0x4f, 0x5d, 0x39, 0x63, 0x23, 0x87,
-	0x82, 0x7b, 0xb9, 0xaf, 0x78, 0x53, 0x97, 0x3e, 0x53, 0x1f, 0x26, 0x3b, 0x25, 0xd2, 0xe2, 0xf6,
-	0x6d, 0x92, 0xd5, 0x5c, 0x57, 0x35, 0x66, 0x5d, 0x0a, 0x15, 0xc5, 0x85, 0x32, 0x61, 0x57, 0x17,
-	0x7d, 0xc4, 0xa8, 0x28, 0x2a, 0xcb, 0xcc, 0x04, 0xfb, 0x3e, 0x09, 0x47, 0xac, 0x25, 0xe8, 0x12,
-	0xe0, 0x2c, 0x2b, 0x93, 0x26, 0x77, 0xe0, 0x93, 0x90, 0xb0, 0x0e, 0x13, 0x9c, 0xc0, 0x10, 0x2b,
-	0x7d, 0x95, 0x54, 0xad, 0x36, 0xf2, 0x2f, 0x6d, 0x9f, 0x09, 0x4c, 0x2f, 0x6a, 0x2e, 0xf6, 0x8c,
-	0xbf, 0xaf, 0xb9, 0xd4, 0x1e, 0x68, 0xdc, 0xa8, 0x34, 0x80, 0x3e, 0x84, 0xc1, 0x2e, 0x4b, 0xaf,
-	0xb9, 0xe9, 0x94, 0xcb, 0x1a, 0x84, 0x5a, 0xdb, 0x0e, 0x4b, 0xad, 0x75, 0xc4, 0xba, 0x14, 0xf5,
-	0x60, 0x78, 0x51, 0x27, 0x85, 0xaa, 0x73, 0x2d, 0x75, 0xcc, 0x2c, 0xc4, 0x37, 0x19, 0xcf, 0x4b,
-	0x65, 0x65, 0x36, 0x28, 0xf8, 0x42, 0x60, 0xd6, 0x94, 0x24, 0xab, 0xb2, 0x90, 0x1c, 0xfb, 0xbe,
-	0x15, 0xc2, 0xf6, 0x7d, 0x2b, 0x04, 0x3d, 0x81, 0x21, 0xe3, 0xb2, 0xce, 0x94, 0xb5, 0xee, 0x41,
-	0x2b, 0xcf, 0xe6, 0xd6, 0x99, 0x62, 0xf6, 0x16, 0x7d, 0x0e, 0xf3, 0x3b, 0xa3, 0x80, 0xb5, 0x62,
-	0xde, 0xa3, 0x36, 0xef, 0x4e, 0x9c, 0xfd, 0x75, 0x3d, 0xf8, 0x44, 0x60, 0xd2, 0x79, 0x99, 0x86,
-	0x76, 0x4d, 0x74, 0x59, 0x93, 0xd3, 0x45, 0xfb, 0x90, 0xe1, 0x99, 0x5d, 0xa3, 0x29, 0x90, 0xf3,
-	0x66, 0x40, 0xc8, 0x39, 0xda, 0x82, 0xab, 0x61, 0xbf, 0xdf, 0xb1, 0x05, 0x69, 0x66, 0x82, 0xd8,
-	0xb5, 0xf5, 0xbb, 0xa4, 0xb8, 0xe5, 0x37, 0xba, 0x6b, 0x23, 0x66, 0x61, 0xf0, 0x8d, 0xc0, 0x2c,
-	0xce, 0xab, 0x52, 0xa8, 0x8e, 0x63, 0x71, 0x71, 0xc3, 0x3f, 0x5a, 0xc7, 0x34, 0x40, 0xf6, 0x4c,
-	0x24, 0xb9, 0x19, 0xcd, 0x31, 0x33, 0x00, 0x59, 0xed, 0x9c, 0x76, 0xca, 0x65, 0x06, 0x68, 0x27,
-	0x70, 0xd5, 0xa4, 0xe7, 0x1a, 0x77, 0x0d, 0xc2, 0x59, 0xb4, 0x9b, 0x26, 0xbd, 0xbe, 0x0e, 0xb5,
-	0x04, 0xce, 0xe2, 0x71, 0xd5, 0xa4, 0x37, 0xf0, 0x9d, 0xd0, 0x61, 0x1d, 0x26, 0x5a, 0xfc, 0x38,
-	0x2c, 0xc9, 0xcf, 0xc3, 0x92, 0xfc, 0x3a, 0x2c, 0xc9, 0xd7, 0xdf, 0xcb, 0x7b, 0x57, 0x03, 0xfd,
-	0xaf, 0x79, 0xf6, 0x27, 0x00, 0x00, 0xff, 0xff, 0x48, 0x20, 0x4b, 0xe7, 0x7b, 0x04, 0x00, 0x00,
+	// 841 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x8e, 0xe3, 0x44,
+	0x10, 0xa6, 0x63, 0x27, 0x71, 0x2a, 0x33, 0xa3, 0x51, 0x33, 0x80, 0x85, 0x50, 0x14, 0x59, 0x48,
+	0xf8, 0x94, 0x91, 0x06, 0x89, 0x2b, 0x22, 0x99, 0x8c, 0x88, 0x80, 0x68, 0xb7, 0x67, 0x96, 0x7b,
+	0xef, 0x4e, 0x2b, 0x6b, 0xc9, 0x6e, 0x9b, 0x76, 0x5b, 0xbb, 0x73, 0x41, 0x1c, 0x78, 0x03, 0x2e,
+	0xbc, 0x01, 0x3c, 0x0a, 0x47, 0x8e, 0x1c, 0xd1, 0xf0, 0x22, 0xa8, 0xaa, 0xdd, 0xb6, 0xb3, 0xd2,
+	0x22, 0xf6, 0xd6, 0xdf, 0x57, 0x5d, 0xed, 0xfa, 0xea, 0xcf, 0x70, 0x52, 0x35, 0xcf, 0xf3, 0xec,
+	0xc5, 0xaa, 0x32, 0xa5, 0x2d, 0x79, 0x94, 0x69, 0xab, 0x8c, 0x96, 0x79, 0xb2, 0x86, 0xc9, 0x3a,
+	0xb3, 0x85, 0xac, 0x38, 0x87, 0x70, 0x9d, 0xd9, 0x3a, 0x66, 0xcb, 0x20, 0x0d, 0x05, 0x9d, 0xf9,
+	0xa7, 0x30, 0xfe, 0xca, 0x5a, 0x53, 0xc7, 0xa3, 0x65, 0x90, 0xce, 0xaf, 0xce, 0x56, 0xde, 0x6f,
+	0x85, 0xb4, 0x70, 0xc6, 0x64, 0x05, 0xe1, 0x13, 0x99, 0x19, 0x7e, 0x0e, 0xc1, 0x37, 0xea, 0x21,
+	0x66, 0x4b, 0x96, 0x86, 0x02, 0x8f, 0xfc, 0x02, 0xc6, 0x9b, 0xb2, 0xd1, 0x36, 0x1e, 0x11, 0xe7,
+	0x40, 0xf2, 0x0c, 0x82, 0x75, 0x66, 0xd1, 0x28, 0xca, 0x57, 0xbb, 0xeb, 0xd6, 0xc1, 0x01, 0xfe,
+	0x31, 0x44, 0x9b, 0x32, 0x6f, 0x0a, 0xbd, 0xbb, 0x6e, 0xbd, 0x3a, 0xcc, 0x3f, 0x81, 0xd9, 0x5d,
+	0x56, 0xa8, 0xda, 0xca, 0xa2, 0x8a, 0x83, 0x25, 0x4b, 0x03, 0xd1, 0x13, 0xc9, 0x16, 0x4e, 0xdd,
+	0x4d, 0x8c, 0xea, 0x56, 0x59, 0x7e, 0x06, 0xa3, 0xee, 0xf5, 0xd1, 0xee, 0xfa, 0x7f, 0xaa, 0xf9,
+	0x9d, 0x41, 0x88, 0xa7, 0xa1, 0x9c, 0x99, 0x93, 0xc3, 0x21, 0xbc, 0x7b, 0xa8, 0x54, 0x1b, 0x17,
+	0x9d, 0xf9, 0x12, 0xe6, 0xb7, 0xd6, 0x64, 0xfa, 0xf0, 0xbd, 0xcc, 0x1b, 0x45, 0x51, 0xcd, 0xc4,
+	0x90, 0x42, 0x45, 0x3b, 0x6d, 0x9d, 0x39, 0xa4, 0xa0, 0x3b, 0x8c, 0x8a, 0xd6, 0x65, 0x99, 0x3b,
+	0xe3, 0x78, 0xc9, 0xd2, 0x48, 0xf4, 0x04, 0x5f, 0x00, 0xdc, 0xe4, 0xa5, 0x6c, 0x7d, 0x27, 0x4b,
+	0x96, 0x32, 0x31, 0x60, 0x92, 0x4b, 0x98, 0x62, 0xa4, 0xdf, 0xc9, 0xaa, 0xd7, 0xc6, 0xfe, 0x4b,
+	0xdb, 0x5f, 0x0c, 0x4e, 0x9e, 0x36, 0xca, 0x3c, 0x08, 0xf5, 0x43, 0xa3, 0x6a, 0xaa, 0x01, 0xe1,
+	0x56, 0xa5, 0x03, 0xfc, 0x43, 0x98, 0xdc, 0xe6, 0xd9, 0x0b, 0xe5, 0x32, 0x15, 0x8a, 0x16, 0xa1,
+	0xd6, 0x3e, 0xc3, 0x35, 0x69, 0x8d, 0xc4, 0x90, 0xe2, 0x31, 0x4c, 0x9f, 0x36, 0x52, 0xdb, 0xa6,
+	0x20, 0xa9, 0x33, 0xe1, 0x21, 0xbe, 0x29, 0x54, 0x51, 0x5a, 0x2f, 0xb3, 0x45, 0x18, 0xc1, 0x4e,
+	0xdf, 0xab, 0xd7, 0x24, 0x6f, 0x26, 0x1c, 0xa0, 0x08, 0x64, 0x51, 0xe5, 0x2a, 0x9e, 0x92, 0xea,
+	0x16, 0xe1, 0xfb, 0xdb, 0xd7, 0x55, 0x2e, 0x33, 0x1d, 0x47, 0xf4, 0x8c, 0x87, 0xc9, 0x2f, 0x0c,
+	0x4e, 0x5b, 0x69, 0x75, 0x55, 0xea, 0x5a, 0x61, 0xfd, 0xb6, 0xc6, 0xf8, 0xfa, 0x6d, 0x8d, 0xe1,
+	0x97, 0x30, 0x15, 0xaa, 0x6e, 0x72, 0xeb, 0x5b, 0xe0, 0x83, 0x3e, 0x4d, 0xde, 0xb7, 0xc9, 0xad,
+	0xf0, 0xb7, 0xf8, 0x97, 0x70, 0x76, 0xd4, 0x52, 0xa8, 0x19, 0xfd, 0x3e, 0xea, 0xfd, 0x8e, 0xec,
+	0xe2, 0x8d, 0xeb, 0xc9, 0x4f, 0x23, 0x98, 0x0f, 0x5e, 0xe6, 0xa9, 0x1f, 0x37, 0x0a, 0x6b, 0x7e,
+	0x75, 0xde, 0x3f, 0xe4, 0x78, 0xe1, 0xc7, 0xf1, 0x04, 0xd8, 0xbe, 0x6d, 0x34, 0xb6, 0xc7, 0xf2,
+	0xe2, 0x88, 0xf9, 0xef, 0x0f, 0xca, 0x8b, 0xb4, 0x70, 0x46, 0xcc, 0xce, 0xe6, 0xa5, 0xd4, 0x07,
+	0x75, 0x4f, 0xd9, 0x8f, 0x84, 0x87, 0xd4, 0xa5, 0x58, 0x43, 0x1a, 0xc0, 0x3a, 0x1e, 0x53, 0x59,
+	0x87, 0x14, 0x5f, 0x41, 0xb4, 0xad, 0x6d, 0x56, 0x48, 0xeb, 0x3a, 0x6d, 0x7e, 0xc5, 0xfb, 0x8f,
+	0x78, 0x8b, 0xe8, 0xee, 0xf0, 0xcf, 0x20, 0x7c, 0x92, 0x4b, 0x4d, 0xf5, 0x99, 0x5f, 0xbd, 0xff,
+	0x46, 0x22, 0xd1, 0x24, 0xe8, 0x42, 0xf2, 0xf3, 0x08, 0x66, 0x1d, 0x87, 0x23, 0xb4, 0x91, 0x79,
+	0xde, 0x56, 0x85, 0xce, 0x3c, 0x85, 0xf1, 0xbe, 0xbc, 0x57, 0xbe, 0x28, 0x83, 0xef, 0xa2, 0x0b,
+	0x9a, 0x84, 0xbb, 0x80, 0xe3, 0x72, 0x63, 0xe4, 0xa1, 0x50, 0xda, 0xee, 0xa9, 0xfd, 0x42, 0xd1,
+	0x13, 0x38, 0x2e, 0x9b, 0x52, 0x5b, 0x99, 0x69, 0x65, 0xf6, 0x94, 0x81, 0x50, 0x0c, 0x18, 0x7e,
+	0x09, 0xd1, 0xe6, 0x65, 0x96, 0xdf, 0x1b, 0xa5, 0x29, 0x03, 0x6f, 0x09, 0xbb, 0xbb, 0x84, 0x93,
+	0x7b, 0xdd, 0x18, 0x69, 0xb3, 0x52, 0x53, 0x4e, 0x02, 0xd1, 0x61, 0xbe, 0x82, 0xe9, 0x5d, 0x56,
+	0x64, 0xfa, 0x50, 0xc7, 0x53, 0x7a, 0xeb, 0xe2, 0x38, 0x6c, 0x67, 0x14, 0xfe, 0x52, 0xf2, 0x05,
+	0x44, 0x5e, 0x0d, 0x26, 0xe1, 0xeb, 0xb2, 0xb6, 0x3e, 0x09, 0x78, 0x7e, 0xdb, 0xcc, 0x25, 0x77,
+	0x00, 0xfd, 0x73, 0xef, 0xe2, 0x79, 0x14, 0x7d, 0x70, 0x1c, 0x7d, 0xf2, 0x63, 0x5f, 0x6d, 0x9c,
+	0x40, 0xb7, 0x60, 0xda, 0x3d, 0x4c, 0x00, 0xd9, 0x6f, 0xcb, 0x57, 0xca, 0xf8, 0xd5, 0x4d, 0x00,
+	0xd9, 0x67, 0x55, 0xa5, 0x4c, 0x9b, 0x7c, 0x07, 0xba, 0x08, 0x7c, 0xd2, 0x5b, 0x84, 0xfd, 0xe8,
+	0xe6, 0x76, 0x4f, 0x43, 0x1f, 0x0a, 0x0f, 0x93, 0xdf, 0x18, 0x9c, 0xee, 0x8a, 0xaa, 0x34, 0x76,
+	0xb0, 0x89, 0xdc, 0x1e, 0x60, 0xc3, 0x3d, 0x70, 0x01, 0xe3, 0x1b, 0x23, 0x0b, 0xb7, 0x72, 0x67,
+	0xc2, 0x01, 0x64, 0xe9, 0x0b, 0x3e, 0x0a, 0x02, 0xb4, 0x61, 0xf0, 0x17, 0x52, 0xc7, 0xa1, 0xcb,
+	0x83, 0x43, 0xd8, 0x34, 0xfe, 0x0f, 0xe2, 0x3b, 0xbf, 0x27, 0xb0, 0x69, 0xba, 0x5f, 0x48, 0x1d,
+	0x4f, 0x96, 0x41, 0x1a, 0x88, 0x01, 0xb3, 0x3e, 0xff, 0xe3, 0x71, 0xc1, 0xfe, 0x7c, 0x5c, 0xb0,
+	0xbf, 0x1f, 0x17, 0xec, 0xd7, 0x7f, 0x16, 0xef, 0x3d, 0x9f, 0xd0, 0x3f, 0xf4, 0xf3, 0x7f, 0x07,
+	0x00, 0x6c, 0xac, 0x73, 0x03, 0x53, 0x07, 0x00, 0x00,
 }
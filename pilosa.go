@@ -29,14 +29,23 @@ var (
 	ErrIndexExists   = errors.New("index already exists")
 	ErrIndexNotFound = errors.New("index not found")
 
+	ErrAliasNotFound = errors.New("alias not found")
+
+	ErrInvalidReplicaN = errors.New("invalid replica count")
+
 	// ErrFrameRequired is returned when no frame is specified.
 	ErrFrameRequired        = errors.New("frame required")
 	ErrFrameExists          = errors.New("frame already exists")
 	ErrFrameNotFound        = errors.New("frame not found")
 	ErrFrameInverseDisabled = errors.New("frame inverse disabled")
+	ErrFrameInverseEnabled  = errors.New("frame inverse cannot be disabled")
 
 	ErrInvalidView      = errors.New("invalid view")
 	ErrInvalidCacheType = errors.New("invalid cache type")
+	ErrInvalidHasher    = errors.New("invalid hasher")
+
+	ErrCacheWindowRequired   = errors.New("window cache requires a cache window and time quantum")
+	ErrCacheHalfLifeRequired = errors.New("decay cache requires a cache half-life")
 
 	ErrName  = errors.New("invalid index or frame's name, must match [a-z0-9_-]")
 	ErrLabel = errors.New("invalid row or column label, must match [A-Za-z0-9_-]")
@@ -44,6 +53,12 @@ var (
 	// ErrFragmentNotFound is returned when a fragment does not exist.
 	ErrFragmentNotFound = errors.New("fragment not found")
 	ErrQueryRequired    = errors.New("query required")
+
+	ErrQueryTimeout      = errors.New("query timeout")
+	ErrQueryCanceled     = errors.New("query canceled")
+	ErrTooManyQueries    = errors.New("too many concurrent queries")
+	ErrTooManyResultBits = errors.New("query result exceeds max result bits")
+	ErrInvalidSample     = errors.New("sample must be greater than 0 and at most 1")
 )
 
 // Regular expression to validate index and frame names.
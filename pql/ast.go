@@ -159,6 +159,8 @@ func (c *Call) String() string {
 			fmt.Fprintf(&buf, "%v=%s", key, joinUint64Slice(v))
 		case time.Time:
 			fmt.Fprintf(&buf, "%v=\"%s\"", key, v.Format(TimeFormat))
+		case *Condition:
+			fmt.Fprintf(&buf, "%v%s", key, v.String())
 		default:
 			fmt.Fprintf(&buf, "%v=%v", key, v)
 		}
@@ -194,6 +196,25 @@ func (c *Call) IsInverse(rowLabel, columnLabel string) bool {
 	return false
 }
 
+// Condition represents an argument compared with an operator other than "=".
+// A list value with the NEQ operator matches none of the values.
+type Condition struct {
+	Op    Token
+	Value interface{}
+}
+
+// String returns the operator and value as they appear in PQL.
+func (cond *Condition) String() string {
+	switch v := cond.Value.(type) {
+	case string:
+		return fmt.Sprintf("%s%q", cond.Op, v)
+	case []interface{}:
+		return fmt.Sprintf("%s%s", cond.Op, joinInterfaceSlice(v))
+	default:
+		return fmt.Sprintf("%s%v", cond.Op, v)
+	}
+}
+
 // CopyArgs returns a copy of m.
 func CopyArgs(m map[string]interface{}) map[string]interface{} {
 	other := make(map[string]interface{}, len(m))
@@ -28,6 +28,19 @@ func TestCall_String(t *testing.T) {
 			t.Fatalf("unexpected string: %s", s)
 		}
 	})
+	t.Run("Conditions", func(t *testing.T) {
+		c := &pql.Call{
+			Name: "ColumnAttr",
+			Args: map[string]interface{}{
+				"a": &pql.Condition{Op: pql.GT, Value: int64(10)},
+				"b": &pql.Condition{Op: pql.PREFIX, Value: "x"},
+				"c": &pql.Condition{Op: pql.NEQ, Value: []interface{}{"y", int64(2)}},
+			},
+		}
+		if s := c.String(); s != `ColumnAttr(a>10, b^="x", c!=["y",2])` {
+			t.Fatalf("unexpected string: %s", s)
+		}
+	})
 }
 
 // Ensure call can be converted into a string.
@@ -97,7 +97,7 @@ func (p *Parser) parseCall() (*Call, error) {
 	}
 
 	// Parse key/value arguments.
-	args, err := p.parseArgs()
+	args, err := p.parseArgs(c.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -147,8 +147,9 @@ func (p *Parser) parseChildren() ([]*Call, error) {
 	}
 }
 
-// parseArgs parses key/value arguments.
-func (p *Parser) parseArgs() (map[string]interface{}, error) {
+// parseArgs parses key/value arguments for the call named name.
+// Comparison operators are only accepted by calls which evaluate conditions.
+func (p *Parser) parseArgs(name string) (map[string]interface{}, error) {
 	args := make(map[string]interface{})
 	for {
 		// Parse key.
@@ -161,9 +162,12 @@ func (p *Parser) parseArgs() (map[string]interface{}, error) {
 		}
 		key := lit
 
-		// Expect '=' next.
-		if tok, pos, lit := p.scanIgnoreWhitespace(); tok != EQ {
+		// Expect '=' or a comparison operator next.
+		op, pos, lit := p.scanIgnoreWhitespace()
+		if !op.isOperator() {
 			return nil, parseErrorf(pos, "expected equals sign, found %q", lit)
+		} else if op != EQ && !allowsConditions(name) {
+			return nil, parseErrorf(pos, "%s() does not support operator %q", name, lit)
 		}
 
 		// Parse value.
@@ -204,6 +208,16 @@ func (p *Parser) parseArgs() (map[string]interface{}, error) {
 			return nil, parseErrorf(pos, "invalid argument value: %q", lit)
 		}
 
+		// Wrap the value in a condition for comparison operators.
+		if op == PREFIX {
+			if _, ok := value.(string); !ok {
+				return nil, parseErrorf(pos, "prefix value must be a string: %q", lit)
+			}
+		}
+		if op != EQ {
+			value = &Condition{Op: op, Value: value}
+		}
+
 		// Ensure key doesn't already exist.
 		if _, ok := args[key]; ok {
 			return nil, parseErrorf(pos, "argument key already used: %s", key)
@@ -222,7 +236,18 @@ func (p *Parser) parseArgs() (map[string]interface{}, error) {
 	}
 }
 
-// parseList parses a list of primitives. This is used by the TopN() filters.
+// allowsConditions returns true if the call named name accepts arguments
+// compared with an operator other than "=".
+func allowsConditions(name string) bool {
+	switch name {
+	case "ColumnAttr", "RowAttr":
+		return true
+	}
+	return false
+}
+
+// parseList parses a list of primitives. This is used by the TopN() filters
+// and attribute conditions.
 func (p *Parser) parseList() ([]interface{}, error) {
 	var values []interface{}
 	for {
@@ -245,6 +270,12 @@ func (p *Parser) parseList() ([]interface{}, error) {
 				return nil, err
 			}
 			values = append(values, v)
+		case FLOAT:
+			v, err := strconv.ParseFloat(lit, 64)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
 		default:
 			return nil, parseErrorf(pos, "invalid list value: %q", lit)
 		}
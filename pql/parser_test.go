@@ -168,4 +168,42 @@ func TestParser_Parse(t *testing.T) {
 			t.Fatalf("unexpected call: %#v", q.Calls[0])
 		}
 	})
+
+	// Parse comparison conditions.
+	t.Run("Conditions", func(t *testing.T) {
+		q, err := pql.ParseString(`ColumnAttr(a!=1, b<2.5, c<=-3, d>"x", e>=4, f^="pre", g=["x",1.5], h!=[true])`)
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(q.Calls[0],
+			&pql.Call{
+				Name: "ColumnAttr",
+				Args: map[string]interface{}{
+					"a": &pql.Condition{Op: pql.NEQ, Value: int64(1)},
+					"b": &pql.Condition{Op: pql.LT, Value: 2.5},
+					"c": &pql.Condition{Op: pql.LTE, Value: int64(-3)},
+					"d": &pql.Condition{Op: pql.GT, Value: "x"},
+					"e": &pql.Condition{Op: pql.GTE, Value: int64(4)},
+					"f": &pql.Condition{Op: pql.PREFIX, Value: "pre"},
+					"g": []interface{}{"x", 1.5},
+					"h": &pql.Condition{Op: pql.NEQ, Value: []interface{}{true}},
+				},
+			},
+		) {
+			t.Fatalf("unexpected call: %#v", q.Calls[0])
+		}
+	})
+
+	// Prefix conditions require a string.
+	t.Run("ErrPrefixNotString", func(t *testing.T) {
+		if _, err := pql.ParseString(`ColumnAttr(a^=1)`); err == nil || err.Error() != `prefix value must be a string: "1" occurred at line 1, char 15` {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	// Comparison operators are only allowed in attribute predicates.
+	t.Run("ErrConditionNotAllowed", func(t *testing.T) {
+		if _, err := pql.ParseString(`Bitmap(frame=f, rowID>1)`); err == nil || err.Error() != `Bitmap() does not support operator ">" occurred at line 1, char 22` {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
 }
@@ -63,6 +63,26 @@ func (s *Scanner) Scan() (tok Token, pos Pos, lit string) {
 		return
 	case '=':
 		tok = EQ
+	case '!':
+		if s.scanEQ() {
+			return NEQ, pos, "!="
+		}
+		tok = ILLEGAL
+	case '^':
+		if s.scanEQ() {
+			return PREFIX, pos, "^="
+		}
+		tok = ILLEGAL
+	case '<':
+		if s.scanEQ() {
+			return LTE, pos, "<="
+		}
+		tok = LT
+	case '>':
+		if s.scanEQ() {
+			return GTE, pos, ">="
+		}
+		tok = GT
 	case ',':
 		tok = COMMA
 	case '(':
@@ -81,6 +101,16 @@ func (s *Scanner) Scan() (tok Token, pos Pos, lit string) {
 	return
 }
 
+// scanEQ consumes the next code point if it is an equals sign.
+func (s *Scanner) scanEQ() bool {
+	if ch := s.read(); ch == '=' {
+		return true
+	} else if ch != eof {
+		s.unread()
+	}
+	return false
+}
+
 // read returns the next code point from the underlying reader and updates the pos.
 func (s *Scanner) read() rune {
 	// Read next rune from underlying reader.
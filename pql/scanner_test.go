@@ -36,6 +36,13 @@ func TestScanner_Scan(t *testing.T) {
 		{s: "\n", tok: pql.WS, lit: "\n"},
 
 		{s: `=`, tok: pql.EQ, lit: `=`},
+		{s: `!=`, tok: pql.NEQ, lit: `!=`},
+		{s: `<`, tok: pql.LT, lit: `<`},
+		{s: `<=`, tok: pql.LTE, lit: `<=`},
+		{s: `>`, tok: pql.GT, lit: `>`},
+		{s: `>=`, tok: pql.GTE, lit: `>=`},
+		{s: `^=`, tok: pql.PREFIX, lit: `^=`},
+		{s: `!`, tok: pql.ILLEGAL, lit: `!`},
 		{s: `,`, tok: pql.COMMA, lit: `,`},
 		{s: `(`, tok: pql.LPAREN, lit: `(`},
 		{s: `)`, tok: pql.RPAREN, lit: `)`},
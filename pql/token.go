@@ -38,6 +38,12 @@ const (
 	keyword_end
 
 	EQ     // =
+	NEQ    // !=
+	LT     // <
+	LTE    // <=
+	GT     // >
+	GTE    // >=
+	PREFIX // ^=
 	COMMA  // ,
 	LPAREN // (
 	RPAREN // )
@@ -57,6 +63,12 @@ var tokens = [...]string{
 	ALL: "ALL",
 
 	EQ:     "=",
+	NEQ:    "!=",
+	LT:     "<",
+	LTE:    "<=",
+	GT:     ">",
+	GTE:    ">=",
+	PREFIX: "^=",
 	COMMA:  ",",
 	LPAREN: "(",
 	RPAREN: ")",
@@ -81,6 +93,15 @@ func (tok Token) String() string {
 	return ""
 }
 
+// isOperator returns true if tok can separate an argument key from its value.
+func (tok Token) isOperator() bool {
+	switch tok {
+	case EQ, NEQ, LT, LTE, GT, GTE, PREFIX:
+		return true
+	}
+	return false
+}
+
 // Lookup returns the token associated with a given string.
 func Lookup(ident string) Token {
 	if tok, ok := keywords[strings.ToLower(ident)]; ok {
@@ -0,0 +1,181 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultResultCacheSize is the default maximum size of a result cache, in bytes.
+const DefaultResultCacheSize = 64 * (1 << 20)
+
+// ResultCache holds the bitmap results of query sub-expressions for a single
+// slice. Entries are evicted in least recently used order once the total size
+// of the cached bitmaps exceeds the maximum size.
+//
+// Each entry records the versions of the fragments it was computed from and
+// is discarded on lookup once any of them has changed.
+type ResultCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	ll       *list.List
+	entries  map[string]*list.Element
+
+	Stats StatsClient
+}
+
+// NewResultCache returns a new instance of ResultCache.
+func NewResultCache(maxBytes int64) *ResultCache {
+	return &ResultCache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+		Stats:    NopStatsClient,
+	}
+}
+
+// resultEntry is a single bitmap held by the ResultCache.
+type resultEntry struct {
+	key  string
+	bm   *Bitmap
+	size int64
+	deps []resultDep
+}
+
+// valid returns true if none of the entry's dependencies have changed in h.
+func (e *resultEntry) valid(h *Holder) bool {
+	for _, dep := range e.deps {
+		if !dep.valid(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// resultDep is a fragment, or the time quantum of a frame, that a cached
+// result was computed from.
+type resultDep struct {
+	index, frame, view string
+	slice              uint64
+
+	// Version of the fragment or zero if it did not exist.
+	version uint64
+
+	// Time quantum of the frame. Only used if view is blank.
+	quantum TimeQuantum
+}
+
+// valid returns true if the dependency is unchanged in h.
+func (d resultDep) valid(h *Holder) bool {
+	if d.view == "" {
+		f := h.Frame(d.index, d.frame)
+		return f != nil && f.TimeQuantum() == d.quantum
+	}
+
+	var version uint64
+	if f := h.Fragment(d.index, d.frame, d.view, d.slice); f != nil {
+		version = f.Version()
+	}
+	return version == d.version
+}
+
+// Len returns the number of entries in the cache.
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Size returns the total size of the cached bitmaps, in bytes.
+func (c *ResultCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// get returns a copy of the bitmap and the dependencies stored under key.
+// Entries whose dependencies have changed in h are discarded.
+func (c *ResultCache) get(h *Holder, key string) (*Bitmap, []resultDep, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.Stats.Count("resultCacheMiss", 1)
+		return nil, nil, false
+	}
+
+	entry := el.Value.(*resultEntry)
+	if !entry.valid(h) {
+		c.remove(el)
+		c.Stats.Count("resultCacheMiss", 1)
+		return nil, nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	c.Stats.Count("resultCacheHit", 1)
+	return entry.bm.copyOnWrite(), entry.deps, true
+}
+
+// add stores a copy of bm under key, evicting the least recently used
+// entries to make room. Bitmaps larger than the cache itself are not stored.
+// Returns a copy of bm for the caller to use in place of bm.
+func (c *ResultCache) add(key string, bm *Bitmap, deps []resultDep) *Bitmap {
+	size := int64(bm.size() + len(key))
+	if size > c.maxBytes {
+		return bm
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bm = bm.copyOnWrite()
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.ll.PushFront(&resultEntry{key: key, bm: bm, size: size, deps: deps})
+	c.size += size
+
+	for c.size > c.maxBytes {
+		c.remove(c.ll.Back())
+		c.Stats.Count("resultCacheEvict", 1)
+	}
+	return bm.copyOnWrite()
+}
+
+// remove deletes el from the cache.
+func (c *ResultCache) remove(el *list.Element) {
+	entry := c.ll.Remove(el).(*resultEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// resultDepsKey is the context key for a resultDeps.
+type resultDepsKey struct{}
+
+// resultDeps collects the dependencies of a bitmap call while it executes.
+type resultDeps struct {
+	a []resultDep
+}
+
+// add appends deps to the dependencies. Safe to call on a nil receiver.
+func (d *resultDeps) add(deps ...resultDep) {
+	if d == nil {
+		return
+	}
+	d.a = append(d.a, deps...)
+}
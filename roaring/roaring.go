@@ -180,35 +180,56 @@ func (b *Bitmap) CountRange(start, end uint64) (n uint64) {
 	j := search64(b.keys, highbits(end))
 
 	// If range is entirely in one container then just count that range.
-	if i > 0 && i == j {
+	if i >= 0 && i == j {
 		return uint64(b.containers[i].countRange(lowbits(start), lowbits(end)))
 	}
 
-	// Count first partial container.
+	// Count first partial container. If the first container doesn't exist
+	// then start from the next container.
 	if i < 0 {
-		i = -i
+		i = -i - 1
 	} else {
 		n += uint64(b.containers[i].countRange(lowbits(start), (bitmapN*64)+1))
+		i++
 	}
 
-	// Count last container.
+	// Count last partial container. If the last container doesn't exist
+	// then stop before the next container.
 	if j < 0 {
-		j = -j
-		if j > len(b.containers) {
-			j = len(b.containers)
-		}
+		j = -j - 1
 	} else {
 		n += uint64(b.containers[j].countRange(0, lowbits(end)))
 	}
 
 	// Count containers in between.
-	for x := i + 1; x < j; x++ {
+	for x := i; x < j; x++ {
 		n += uint64(b.containers[x].n)
 	}
 
 	return n
 }
 
+// ContainerCount returns the number of containers in the bitmap.
+func (b *Bitmap) ContainerCount() int { return len(b.keys) }
+
+// ContainerCountRange returns the number of containers holding values between [start, end).
+func (b *Bitmap) ContainerCountRange(start, end uint64) int {
+	if end <= start {
+		return 0
+	}
+	i := search64(b.keys, highbits(start))
+	if i < 0 {
+		i = -i - 1
+	}
+	j := search64(b.keys, highbits(end-1))
+	if j < 0 {
+		j = -j - 1
+	} else {
+		j++
+	}
+	return j - i
+}
+
 // Slice returns a slice of all integers in the bitmap.
 func (b *Bitmap) Slice() []uint64 {
 	var a []uint64
@@ -627,6 +648,49 @@ func (b *Bitmap) writeOp(op *op) error {
 	return nil
 }
 
+// OpN returns the number of ops in the ops log.
+func (b *Bitmap) OpN() int { return b.opN }
+
+// Size returns the number of bytes used by the container data in the bitmap.
+func (b *Bitmap) Size() int {
+	var n int
+	for _, c := range b.containers {
+		n += c.size()
+	}
+	return n
+}
+
+// HeapSize returns the number of bytes used by containers which are
+// allocated on the heap. Containers mapped to a byte slice are excluded.
+func (b *Bitmap) HeapSize() int {
+	var n int
+	for _, c := range b.containers {
+		if !c.mapped {
+			n += c.size()
+		}
+	}
+	return n
+}
+
+// OpValues returns the values of the last n ops in the ops log of data, which
+// must be the encoded bitmap passed to UnmarshalBinary.
+func OpValues(data []byte, n int) ([]uint64, error) {
+	var op op
+	start := len(data) - n*op.size()
+	if n < 0 || start < headerSize {
+		return nil, fmt.Errorf("op count out of bounds: n=%d", n)
+	}
+
+	values := make([]uint64, 0, n)
+	for buf := data[start:]; len(buf) > 0; buf = buf[op.size():] {
+		if err := op.UnmarshalBinary(buf); err != nil {
+			return nil, err
+		}
+		values = append(values, op.value)
+	}
+	return values, nil
+}
+
 // Iterator returns a new iterator for the bitmap.
 func (b *Bitmap) Iterator() *Iterator {
 	itr := &Iterator{bitmap: b}
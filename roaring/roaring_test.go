@@ -94,6 +94,41 @@ func TestBitmap_Max(t *testing.T) {
 	}
 }
 
+// Ensure bitmap can count the containers within a range.
+func TestBitmap_ContainerCountRange(t *testing.T) {
+	bm := roaring.NewBitmap(1, 70000, 70001, 200000, 1<<20)
+	if n := bm.ContainerCount(); n != 4 {
+		t.Fatalf("unexpected count: %d", n)
+	} else if n := bm.ContainerCountRange(0, 1<<20); n != 3 {
+		t.Fatalf("unexpected range count: %d", n)
+	} else if n := bm.ContainerCountRange(65536, 200000); n != 2 {
+		t.Fatalf("unexpected range count: %d", n)
+	} else if n := bm.ContainerCountRange(2<<20, 3<<20); n != 0 {
+		t.Fatalf("unexpected range count: %d", n)
+	}
+}
+
+// Ensure bitmap can count the values within a range.
+func TestBitmap_CountRange(t *testing.T) {
+	bm := roaring.NewBitmap(1, 2, 70000, 70001, 200000, 1<<20, 1<<20+1, 1<<20+2)
+	for _, tt := range []struct {
+		start, end uint64
+		n          uint64
+	}{
+		{0, 1 << 20, 5},
+		{0, 3, 2},
+		{2, 70001, 2},
+		{65536, 200001, 3},
+		{300000, 1 << 20, 0},
+		{1 << 20, 2 << 20, 3},
+		{3 << 20, 4 << 20, 0},
+	} {
+		if n := bm.CountRange(tt.start, tt.end); n != tt.n {
+			t.Fatalf("unexpected count for [%d, %d): %d", tt.start, tt.end, n)
+		}
+	}
+}
+
 func TestBitmap_Intersection(t *testing.T) {
 	bm0 := roaring.NewBitmap(0, 2683177)
 	bm1 := roaring.NewBitmap()
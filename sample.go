@@ -0,0 +1,240 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+
+	"github.com/pilosa/pilosa/internal"
+	"github.com/pilosa/pilosa/pql"
+)
+
+// sampleZ is the normal quantile used for the 95% confidence interval
+// reported with sampled counts.
+const sampleZ = 1.96
+
+// validateSample returns an error if rate is not a valid sample rate.
+// A zero rate disables sampling and is valid.
+func validateSample(rate float64) error {
+	if rate < 0 || rate > 1 || math.IsNaN(rate) {
+		return ErrInvalidSample
+	}
+	return nil
+}
+
+// Estimate is the approximate result of a sampled Count() call.
+type Estimate struct {
+	// Estimated count and the bounds of its 95% confidence interval.
+	Value uint64 `json:"value"`
+	Lower uint64 `json:"lower"`
+	Upper uint64 `json:"upper"`
+
+	// Number of slices in the query and number of slices evaluated.
+	SliceN  int `json:"slices"`
+	SampleN int `json:"sampled"`
+}
+
+// newEstimate returns an estimate of the total count over sliceN slices
+// from the counts of a simple random sample of those slices.
+func newEstimate(counts []uint64, sliceN int) *Estimate {
+	est := &Estimate{SliceN: sliceN, SampleN: len(counts)}
+	m := len(counts)
+	if m == 0 {
+		return est
+	}
+
+	var sum float64
+	for _, n := range counts {
+		sum += float64(n)
+	}
+	mean := sum / float64(m)
+
+	// Sample variance of the per-slice counts.
+	var variance float64
+	if m > 1 {
+		for _, n := range counts {
+			d := float64(n) - mean
+			variance += d * d
+		}
+		variance /= float64(m - 1)
+	}
+
+	// Scale the mean up to all slices. The standard error includes the
+	// finite population correction so a full sample is exact.
+	N := float64(sliceN)
+	value := N * mean
+	se := N * math.Sqrt((1-float64(m)/N)*variance/float64(m))
+
+	// The total can never be lower than the count observed in the sample.
+	lower := math.Max(value-sampleZ*se, sum)
+
+	est.Value = uint64(value + 0.5)
+	est.Lower = uint64(lower + 0.5)
+	est.Upper = uint64(value + sampleZ*se + 0.5)
+	return est
+}
+
+func encodeEstimate(e *Estimate) *internal.Estimate {
+	return &internal.Estimate{
+		Value:   e.Value,
+		Lower:   e.Lower,
+		Upper:   e.Upper,
+		SliceN:  uint64(e.SliceN),
+		SampleN: uint64(e.SampleN),
+	}
+}
+
+func decodeEstimate(pb *internal.Estimate) *Estimate {
+	return &Estimate{
+		Value:   pb.Value,
+		Lower:   pb.Lower,
+		Upper:   pb.Upper,
+		SliceN:  int(pb.SliceN),
+		SampleN: int(pb.SampleN),
+	}
+}
+
+// sliceCounts holds the count of each slice evaluated by a sampled Count().
+// Remote nodes return the individual counts so the coordinating node can
+// compute the variance of the sample.
+type sliceCounts []uint64
+
+// sampleSlices returns a deterministic subset of slices containing rate of
+// the slices, rounded up. Slices are ranked by a hash of their id so the same
+// slices are chosen by every query and a larger rate includes every slice
+// chosen by a smaller one. The subset is returned in ascending order.
+func sampleSlices(slices []uint64, rate float64) []uint64 {
+	m := int(math.Ceil(rate * float64(len(slices))))
+	if m >= len(slices) {
+		return slices
+	}
+
+	ranked := make(sliceRanks, len(slices))
+	for i, slice := range slices {
+		ranked[i] = sliceRank{slice: slice, hash: sliceHash(slice)}
+	}
+	sort.Sort(ranked)
+
+	other := make([]uint64, m)
+	for i := range other {
+		other[i] = ranked[i].slice
+	}
+	sort.Sort(uint64Slice(other))
+	return other
+}
+
+// sliceHash returns the hash used to rank a slice for sampling.
+func sliceHash(slice uint64) uint64 {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], slice)
+
+	h := fnv.New64a()
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+type sliceRank struct {
+	slice uint64
+	hash  uint64
+}
+
+// sliceRanks sorts slices by hash, then by id.
+type sliceRanks []sliceRank
+
+func (p sliceRanks) Len() int      { return len(p) }
+func (p sliceRanks) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p sliceRanks) Less(i, j int) bool {
+	if p[i].hash != p[j].hash {
+		return p[i].hash < p[j].hash
+	}
+	return p[i].slice < p[j].slice
+}
+
+// executeSampledCount executes a Count() call against a sample of slices.
+// Returns an estimate on the coordinating node and the count of each slice
+// on remote nodes.
+func (e *Executor) executeSampledCount(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) (interface{}, error) {
+	// Remote nodes evaluate the slices chosen by the coordinating node.
+	if opt.Remote {
+		return e.executeSliceCounts(ctx, index, c, slices, opt)
+	}
+
+	counts, err := e.executeSliceCounts(ctx, index, c, sampleSlices(slices, opt.Sample), opt)
+	if err != nil {
+		return nil, err
+	}
+	return newEstimate(counts, len(slices)), nil
+}
+
+// executeSliceCounts returns the count of a Count() call on each slice.
+func (e *Executor) executeSliceCounts(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) (sliceCounts, error) {
+	if len(c.Children) == 0 {
+		return nil, errors.New("Count() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("Count() only accepts a single bitmap input")
+	}
+
+	mapFn := func(slice uint64) (interface{}, error) {
+		bm, err := e.executeBitmapCallSlice(ctx, index, c.Children[0], slice)
+		if err != nil {
+			return nil, err
+		}
+		return sliceCounts{bm.Count()}, nil
+	}
+
+	// Nodes which don't support sampling return a plain total count, which
+	// can't be used to estimate the variance so the query fails.
+	reduceFn := func(prev, v interface{}) (interface{}, error) {
+		counts, ok := v.(sliceCounts)
+		if _, total := v.(uint64); total {
+			return nil, errors.New("remote node does not support sampling")
+		} else if !ok {
+			return nil, errors.New("sampled Count() did not return slice counts")
+		}
+		other, _ := prev.(sliceCounts)
+		return append(other, counts...), nil
+	}
+
+	result, err := e.mapReduce(ctx, index, slices, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	counts, _ := result.(sliceCounts)
+	return counts, nil
+}
+
+// executeSampledTopN executes a TopN() call against a sample of slices and
+// scales the counts up to all slices. Thresholds apply to the sampled counts.
+func (e *Executor) executeSampledTopN(ctx context.Context, index string, c *pql.Call, slices []uint64, opt *ExecOptions) ([]Pair, error) {
+	sampled := sampleSlices(slices, opt.Sample)
+
+	other := *opt
+	other.Sample = 0
+	pairs, err := e.executeTopN(ctx, index, c, sampled, &other)
+	if err != nil || len(sampled) == 0 {
+		return pairs, err
+	}
+
+	scale := float64(len(slices)) / float64(len(sampled))
+	for i := range pairs {
+		pairs[i].Count = uint64(float64(pairs[i].Count)*scale + 0.5)
+	}
+	return pairs, nil
+}
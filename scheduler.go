@@ -0,0 +1,212 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HeaderQueryPriority is the HTTP header used to select a query's priority class.
+const HeaderQueryPriority = "X-Pilosa-Priority"
+
+// QueryPriority represents the class used to order queued queries.
+type QueryPriority int
+
+// Query priority classes, highest first.
+const (
+	PriorityInteractive QueryPriority = iota
+	PriorityBatch
+
+	queryPriorityN = 2
+)
+
+// ParseQueryPriority parses a priority class name. Blank names are interactive.
+func ParseQueryPriority(s string) (QueryPriority, error) {
+	switch s {
+	case "", "interactive":
+		return PriorityInteractive, nil
+	case "batch":
+		return PriorityBatch, nil
+	default:
+		return 0, fmt.Errorf("invalid query priority: %q", s)
+	}
+}
+
+// String returns the name of the priority class.
+func (p QueryPriority) String() string {
+	if p == PriorityBatch {
+		return "batch"
+	}
+	return "interactive"
+}
+
+// QueryScheduler limits the number of queries executing against each index.
+//
+// Queries over the limit wait in a queue per index. Interactive queries are
+// always started before batch queries; queries of the same class are started
+// in the order they arrived.
+type QueryScheduler struct {
+	mu     sync.Mutex
+	queues map[string]*queryQueue
+
+	// Maximum number of queries executing per index, unless overridden
+	// in IndexConcurrency. Queries are not limited if zero.
+	Concurrency      int
+	IndexConcurrency map[string]int
+
+	// Maximum number of queries waiting per index. Queries arriving at a
+	// full queue are rejected with ErrTooManyQueries. Unbounded if zero.
+	MaxQueueLength int
+
+	Stats StatsClient
+}
+
+// NewQueryScheduler returns a new instance of QueryScheduler.
+func NewQueryScheduler(concurrency int) *QueryScheduler {
+	return &QueryScheduler{
+		queues:      make(map[string]*queryQueue),
+		Concurrency: concurrency,
+		Stats:       NopStatsClient,
+	}
+}
+
+// queryQueue holds the executing and waiting queries for an index.
+type queryQueue struct {
+	running int
+	waiting [queryPriorityN]*list.List
+	stats   StatsClient
+}
+
+// queryWaiter is a query waiting for a slot.
+type queryWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// Acquire blocks until a query against index can start, or until ctx is done.
+// The returned function must be called once the query finishes.
+func (s *QueryScheduler) Acquire(ctx context.Context, index string, priority QueryPriority) (release func(), err error) {
+	s.mu.Lock()
+	q := s.queue(index)
+	release = func() { s.release(q) }
+
+	// Start immediately if a slot is free and nobody is waiting ahead.
+	limit := s.concurrency(index)
+	if limit <= 0 || (q.running < limit && q.waitingN(priority) == 0) {
+		q.running++
+		q.stats.Gauge("queryQueue.running", float64(q.running))
+		s.mu.Unlock()
+		return release, nil
+	}
+
+	// Reject the query if the queue is full.
+	if s.MaxQueueLength > 0 && q.waitingN(queryPriorityN-1) >= s.MaxQueueLength {
+		q.stats.Count("queryQueue.rejected", 1)
+		s.mu.Unlock()
+		return nil, ErrTooManyQueries
+	}
+
+	w := &queryWaiter{ready: make(chan struct{})}
+	el := q.waiting[priority].PushBack(w)
+	q.stats.Gauge("queryQueue.waiting", float64(q.waitingN(queryPriorityN-1)))
+	s.mu.Unlock()
+
+	t := time.Now()
+	select {
+	case <-w.ready:
+		q.stats.WithTags("priority:"+priority.String()).Timing("queryQueue.wait", time.Since(t))
+		return release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := w.granted
+		if !granted {
+			q.waiting[priority].Remove(el)
+			q.stats.Gauge("queryQueue.waiting", float64(q.waitingN(queryPriorityN-1)))
+		}
+		s.mu.Unlock()
+
+		// Pass on a slot granted while the context was finishing.
+		if granted {
+			release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release frees a slot on q and starts the next waiting query. The queue may
+// have been removed from the scheduler if its index was deleted.
+func (s *QueryScheduler) release(q *queryQueue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q.running--
+	for p := range q.waiting {
+		if el := q.waiting[p].Front(); el != nil {
+			w := q.waiting[p].Remove(el).(*queryWaiter)
+			w.granted = true
+			close(w.ready)
+			q.running++
+			q.stats.Gauge("queryQueue.waiting", float64(q.waitingN(queryPriorityN-1)))
+			break
+		}
+	}
+	q.stats.Gauge("queryQueue.running", float64(q.running))
+}
+
+// DeleteIndex removes the queue for index. Queries which are already running
+// or waiting finish on the removed queue; later queries start a new queue.
+func (s *QueryScheduler) DeleteIndex(index string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.queues, index)
+}
+
+// queue returns the queue for index, creating it if necessary.
+// The scheduler lock must be held.
+func (s *QueryScheduler) queue(index string) *queryQueue {
+	q := s.queues[index]
+	if q == nil {
+		q = &queryQueue{stats: s.Stats.WithTags(fmt.Sprintf("index:%s", index))}
+		for i := range q.waiting {
+			q.waiting[i] = list.New()
+		}
+		s.queues[index] = q
+	}
+	return q
+}
+
+// concurrency returns the query limit for index.
+func (s *QueryScheduler) concurrency(index string) int {
+	if n, ok := s.IndexConcurrency[index]; ok {
+		return n
+	}
+	return s.Concurrency
+}
+
+// waitingN returns the number of queries waiting with priority p or higher.
+func (q *queryQueue) waitingN(p QueryPriority) int {
+	var n int
+	for i := 0; i <= int(p); i++ {
+		n += q.waiting[i].Len()
+	}
+	return n
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa"
+)
+
+// Ensure queued interactive queries start before batch queries.
+func TestQueryScheduler_Priority(t *testing.T) {
+	s := pilosa.NewQueryScheduler(1)
+
+	release, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Queue a batch query followed by an interactive query.
+	started := make(chan pilosa.QueryPriority, 2)
+	acquire := func(p pilosa.QueryPriority) {
+		release, err := s.Acquire(context.Background(), "i", p)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		started <- p
+		release()
+	}
+	go acquire(pilosa.PriorityBatch)
+	time.Sleep(10 * time.Millisecond)
+	go acquire(pilosa.PriorityInteractive)
+	time.Sleep(10 * time.Millisecond)
+
+	// Other indexes are scheduled independently.
+	if release, err := s.Acquire(context.Background(), "j", pilosa.PriorityBatch); err != nil {
+		t.Fatal(err)
+	} else {
+		release()
+	}
+
+	release()
+	if p := <-started; p != pilosa.PriorityInteractive {
+		t.Fatalf("unexpected first priority: %s", p)
+	} else if p := <-started; p != pilosa.PriorityBatch {
+		t.Fatalf("unexpected second priority: %s", p)
+	}
+}
+
+// Ensure queries are rejected when the queue is full and removed when cancelled.
+func TestQueryScheduler_Queue(t *testing.T) {
+	s := pilosa.NewQueryScheduler(1)
+	s.MaxQueueLength = 1
+
+	release, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// The first waiting query times out.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := s.Acquire(ctx, "i", pilosa.PriorityBatch)
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	// The queue is full until then.
+	if _, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive); err != pilosa.ErrTooManyQueries {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-done; err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A cancelled query frees its place in the queue.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Acquire(ctx, "i", pilosa.PriorityInteractive); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure deleting an index removes its queue without disturbing running queries.
+func TestQueryScheduler_DeleteIndex(t *testing.T) {
+	s := pilosa.NewQueryScheduler(1)
+
+	release0, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.DeleteIndex("i")
+
+	// A recreated index starts with an empty queue.
+	release1, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Releasing the query started before the delete doesn't free a slot on the new queue.
+	release0()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx, "i", pilosa.PriorityInteractive); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	release1()
+	if release, err := s.Acquire(context.Background(), "i", pilosa.PriorityInteractive); err != nil {
+		t.Fatal(err)
+	} else {
+		release()
+	}
+}
+
+// Ensure priority classes can be parsed.
+func TestParseQueryPriority(t *testing.T) {
+	if p, err := pilosa.ParseQueryPriority(""); err != nil || p != pilosa.PriorityInteractive {
+		t.Fatalf("unexpected priority: %s, %v", p, err)
+	} else if p, err := pilosa.ParseQueryPriority("batch"); err != nil || p != pilosa.PriorityBatch {
+		t.Fatalf("unexpected priority: %s, %v", p, err)
+	} else if _, err := pilosa.ParseQueryPriority("urgent"); err == nil {
+		t.Fatal("expected error")
+	}
+}
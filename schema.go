@@ -0,0 +1,332 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Schema change actions.
+const (
+	SchemaActionCreateIndex = "createIndex"
+	SchemaActionUpdateIndex = "updateIndex"
+	SchemaActionDeleteIndex = "deleteIndex"
+	SchemaActionCreateFrame = "createFrame"
+	SchemaActionUpdateFrame = "updateFrame"
+	SchemaActionDeleteFrame = "deleteFrame"
+)
+
+// ErrSchemaChangeDestructive is returned when a schema contains destructive
+// changes but they have not been explicitly allowed.
+var ErrSchemaChangeDestructive = errors.New("schema contains destructive changes")
+
+// SchemaDefinition represents the desired set of indexes and frames.
+type SchemaDefinition struct {
+	Indexes []IndexDefinition `json:"indexes" toml:"index"`
+}
+
+// IndexDefinition represents the desired options and frames for an index.
+type IndexDefinition struct {
+	Name    string            `json:"name" toml:"name"`
+	Options IndexOptions      `json:"options" toml:"options"`
+	Frames  []FrameDefinition `json:"frames" toml:"frame"`
+}
+
+// SchemaChange represents a single change required to bring the holder's
+// schema in line with a schema definition. Update changes affect a single
+// option and carry its new value.
+type SchemaChange struct {
+	Action       string        `json:"action"`
+	Index        string        `json:"index"`
+	Frame        string        `json:"frame,omitempty"`
+	Option       string        `json:"option,omitempty"`
+	Value        string        `json:"value,omitempty"`
+	IndexOptions *IndexOptions `json:"indexOptions,omitempty"`
+	FrameOptions *FrameOptions `json:"frameOptions,omitempty"`
+	Destructive  bool          `json:"destructive,omitempty"`
+}
+
+// String returns a description of the change.
+func (c SchemaChange) String() string {
+	target := c.Index
+	if c.Frame != "" {
+		target += "/" + c.Frame
+	}
+	if c.Option != "" {
+		return fmt.Sprintf("%s %s %s=%s", c.Action, target, c.Option, c.Value)
+	}
+	return fmt.Sprintf("%s %s", c.Action, target)
+}
+
+// DiffSchema returns the changes required for the holder to match def.
+// Options which are left empty in def are not compared. Indexes and
+// frames which are not in def are deleted; those changes are marked as
+// destructive.
+func (h *Holder) DiffSchema(def *SchemaDefinition) ([]SchemaChange, error) {
+	changes := []SchemaChange{}
+
+	indexNames := make(map[string]struct{})
+	for _, idef := range def.Indexes {
+		if err := ValidateName(idef.Name); err != nil {
+			return nil, fmt.Errorf("index %q: %s", idef.Name, err)
+		} else if _, ok := indexNames[idef.Name]; ok {
+			return nil, fmt.Errorf("index %q: defined more than once", idef.Name)
+		}
+		indexNames[idef.Name] = struct{}{}
+
+		index := h.Index(idef.Name)
+		if index == nil {
+			opt := idef.Options
+			changes = append(changes, SchemaChange{
+				Action:       SchemaActionCreateIndex,
+				Index:        idef.Name,
+				IndexOptions: &opt,
+			})
+		} else {
+			changes = append(changes, diffIndexOptions(index, idef.Options)...)
+		}
+
+		frameNames := make(map[string]struct{})
+		for _, fdef := range idef.Frames {
+			if err := ValidateName(fdef.Name); err != nil {
+				return nil, fmt.Errorf("frame %q: %s", fdef.Name, err)
+			} else if _, ok := frameNames[fdef.Name]; ok {
+				return nil, fmt.Errorf("frame %q: defined more than once", fdef.Name)
+			}
+			frameNames[fdef.Name] = struct{}{}
+
+			var frame *Frame
+			if index != nil {
+				frame = index.Frame(fdef.Name)
+			}
+
+			if frame == nil {
+				opt := fdef.Options
+				changes = append(changes, SchemaChange{
+					Action:       SchemaActionCreateFrame,
+					Index:        idef.Name,
+					Frame:        fdef.Name,
+					FrameOptions: &opt,
+				})
+				continue
+			}
+			changes = append(changes, diffFrameOptions(idef.Name, frame, fdef.Options)...)
+		}
+
+		// Remove frames which are no longer defined.
+		if index != nil {
+			for _, f := range index.Frames() {
+				if _, ok := frameNames[f.Name()]; ok {
+					continue
+				}
+				changes = append(changes, SchemaChange{
+					Action:      SchemaActionDeleteFrame,
+					Index:       idef.Name,
+					Frame:       f.Name(),
+					Destructive: true,
+				})
+			}
+		}
+	}
+
+	// Remove indexes which are no longer defined.
+	for _, index := range h.Indexes() {
+		if _, ok := indexNames[index.Name()]; ok {
+			continue
+		}
+		changes = append(changes, SchemaChange{
+			Action:      SchemaActionDeleteIndex,
+			Index:       index.Name(),
+			Destructive: true,
+		})
+	}
+
+	return changes, nil
+}
+
+// diffIndexOptions returns update changes for each option in opt which
+// differs from index.
+func diffIndexOptions(index *Index, opt IndexOptions) []SchemaChange {
+	var changes []SchemaChange
+	update := func(option, value string) {
+		changes = append(changes, SchemaChange{
+			Action: SchemaActionUpdateIndex,
+			Index:  index.Name(),
+			Option: option,
+			Value:  value,
+		})
+	}
+
+	if opt.ColumnLabel != "" && opt.ColumnLabel != index.ColumnLabel() {
+		update("columnLabel", opt.ColumnLabel)
+	}
+	if opt.TimeQuantum != "" && opt.TimeQuantum != index.TimeQuantum() {
+		update("timeQuantum", string(opt.TimeQuantum))
+	}
+	if opt.ReplicaN != 0 && opt.ReplicaN != index.ReplicaN() {
+		update("replicaN", strconv.Itoa(opt.ReplicaN))
+	}
+	return changes
+}
+
+// diffFrameOptions returns update changes for each option in opt which
+// differs from frame. Inverse storage can be enabled but not disabled.
+func diffFrameOptions(index string, frame *Frame, opt FrameOptions) []SchemaChange {
+	var changes []SchemaChange
+	update := func(option, value string) {
+		changes = append(changes, SchemaChange{
+			Action: SchemaActionUpdateFrame,
+			Index:  index,
+			Frame:  frame.Name(),
+			Option: option,
+			Value:  value,
+		})
+	}
+
+	cur := frame.Options()
+	if opt.RowLabel != "" && opt.RowLabel != cur.RowLabel {
+		update("rowLabel", opt.RowLabel)
+	}
+	// Settings required by a cache type are updated before the type.
+	if opt.TimeQuantum != "" && opt.TimeQuantum != cur.TimeQuantum {
+		update("timeQuantum", string(opt.TimeQuantum))
+	}
+	if opt.CacheInterval != 0 && opt.CacheInterval != cur.CacheInterval {
+		update("cacheInterval", opt.CacheInterval.String())
+	}
+	if opt.CacheWindow != 0 && opt.CacheWindow != cur.CacheWindow {
+		update("cacheWindow", opt.CacheWindow.String())
+	}
+	if opt.CacheHalfLife != 0 && opt.CacheHalfLife != cur.CacheHalfLife {
+		update("cacheHalfLife", opt.CacheHalfLife.String())
+	}
+	if opt.CacheType != "" && opt.CacheType != cur.CacheType {
+		update("cacheType", opt.CacheType)
+	}
+	if opt.CacheSize != 0 && opt.CacheSize != cur.CacheSize {
+		update("cacheSize", strconv.FormatUint(uint64(opt.CacheSize), 10))
+	}
+	if opt.InverseEnabled && !cur.InverseEnabled {
+		update("inverseEnabled", "true")
+	}
+	return changes
+}
+
+// ApplySchemaChange applies a single schema change to the holder. Enabling
+// inverse storage does not backfill the inverse views.
+func (h *Holder) ApplySchemaChange(c SchemaChange) error {
+	switch c.Action {
+	case SchemaActionCreateIndex:
+		var opt IndexOptions
+		if c.IndexOptions != nil {
+			opt = *c.IndexOptions
+		}
+		_, err := h.CreateIndex(c.Index, opt)
+		return err
+
+	case SchemaActionDeleteIndex:
+		return h.DeleteIndex(c.Index)
+
+	case SchemaActionUpdateIndex:
+		index := h.Index(c.Index)
+		if index == nil {
+			return ErrIndexNotFound
+		}
+		switch c.Option {
+		case "columnLabel":
+			return index.SetColumnLabel(c.Value)
+		case "timeQuantum":
+			tq, err := ParseTimeQuantum(c.Value)
+			if err != nil {
+				return err
+			}
+			return index.SetTimeQuantum(tq)
+		case "replicaN":
+			n, err := strconv.Atoi(c.Value)
+			if err != nil {
+				return err
+			}
+			return index.SetReplicaN(n)
+		}
+
+	case SchemaActionCreateFrame:
+		index := h.Index(c.Index)
+		if index == nil {
+			return ErrIndexNotFound
+		}
+		var opt FrameOptions
+		if c.FrameOptions != nil {
+			opt = *c.FrameOptions
+		}
+		_, err := index.CreateFrame(c.Frame, opt)
+		return err
+
+	case SchemaActionDeleteFrame:
+		index := h.Index(c.Index)
+		if index == nil {
+			return ErrIndexNotFound
+		}
+		return index.DeleteFrame(c.Frame)
+
+	case SchemaActionUpdateFrame:
+		frame := h.Frame(c.Index, c.Frame)
+		if frame == nil {
+			return ErrFrameNotFound
+		}
+		switch c.Option {
+		case "rowLabel":
+			return frame.SetRowLabel(c.Value)
+		case "cacheType":
+			return frame.SetCacheType(c.Value)
+		case "cacheSize":
+			n, err := strconv.ParseUint(c.Value, 10, 32)
+			if err != nil {
+				return err
+			}
+			return frame.SetCacheSize(uint32(n))
+		case "cacheInterval":
+			d, err := time.ParseDuration(c.Value)
+			if err != nil {
+				return err
+			}
+			return frame.SetCacheInterval(d)
+		case "cacheWindow":
+			d, err := time.ParseDuration(c.Value)
+			if err != nil {
+				return err
+			}
+			return frame.SetCacheWindow(d)
+		case "cacheHalfLife":
+			d, err := time.ParseDuration(c.Value)
+			if err != nil {
+				return err
+			}
+			return frame.SetCacheHalfLife(d)
+		case "timeQuantum":
+			tq, err := ParseTimeQuantum(c.Value)
+			if err != nil {
+				return err
+			}
+			return frame.SetTimeQuantum(tq)
+		case "inverseEnabled":
+			return frame.EnableInverse()
+		}
+	}
+
+	return fmt.Errorf("unsupported schema change: %s %s", c.Action, c.Option)
+}
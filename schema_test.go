@@ -0,0 +1,112 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa"
+)
+
+// Ensure the holder can compute the changes required to match a schema.
+func TestHolder_DiffSchema(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	idx := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{ColumnLabel: "col"})
+	if _, err := idx.CreateFrame("f", pilosa.FrameOptions{CacheType: pilosa.CacheTypeRanked, CacheSize: 100}); err != nil {
+		t.Fatal(err)
+	} else if _, err := idx.CreateFrame("g", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	hldr.MustCreateIndexIfNotExists("j", pilosa.IndexOptions{})
+
+	changes, err := hldr.DiffSchema(&pilosa.SchemaDefinition{
+		Indexes: []pilosa.IndexDefinition{
+			{
+				Name:    "i",
+				Options: pilosa.IndexOptions{ColumnLabel: "col", TimeQuantum: "YM"},
+				Frames: []pilosa.FrameDefinition{
+					{Name: "f", Options: pilosa.FrameOptions{CacheType: pilosa.CacheTypeLRU, CacheSize: 200}},
+					{Name: "h", Options: pilosa.FrameOptions{RowLabel: "x"}},
+				},
+			},
+			{Name: "k"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []pilosa.SchemaChange{
+		{Action: pilosa.SchemaActionUpdateIndex, Index: "i", Option: "timeQuantum", Value: "YM"},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "cacheType", Value: pilosa.CacheTypeLRU},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "cacheSize", Value: "200"},
+		{Action: pilosa.SchemaActionCreateFrame, Index: "i", Frame: "h", FrameOptions: &pilosa.FrameOptions{RowLabel: "x"}},
+		{Action: pilosa.SchemaActionDeleteFrame, Index: "i", Frame: "g", Destructive: true},
+		{Action: pilosa.SchemaActionCreateIndex, Index: "k", IndexOptions: &pilosa.IndexOptions{}},
+		{Action: pilosa.SchemaActionDeleteIndex, Index: "j", Destructive: true},
+	}
+	if !reflect.DeepEqual(changes, exp) {
+		t.Fatalf("unexpected changes:\n%+v\nexpected:\n%+v", changes, exp)
+	}
+
+	// Duplicate names are rejected.
+	if _, err := hldr.DiffSchema(&pilosa.SchemaDefinition{
+		Indexes: []pilosa.IndexDefinition{{Name: "i"}, {Name: "i"}},
+	}); err == nil {
+		t.Fatal("expected error for duplicate index")
+	}
+}
+
+// Ensure the holder can apply schema changes.
+func TestHolder_ApplySchemaChange(t *testing.T) {
+	hldr := MustOpenHolder()
+	defer hldr.Close()
+
+	for _, c := range []pilosa.SchemaChange{
+		{Action: pilosa.SchemaActionCreateIndex, Index: "i", IndexOptions: &pilosa.IndexOptions{ColumnLabel: "col"}},
+		{Action: pilosa.SchemaActionUpdateIndex, Index: "i", Option: "timeQuantum", Value: "YMD"},
+		{Action: pilosa.SchemaActionCreateFrame, Index: "i", Frame: "f", FrameOptions: &pilosa.FrameOptions{RowLabel: "row"}},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "cacheSize", Value: "300"},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "rowLabel", Value: "r"},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "cacheType", Value: pilosa.CacheTypeRanked},
+		{Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "inverseEnabled", Value: "true"},
+	} {
+		if err := hldr.ApplySchemaChange(c); err != nil {
+			t.Fatalf("%s %s: %s", c.Action, c.Option, err)
+		}
+	}
+
+	if idx := hldr.Index("i"); idx.ColumnLabel() != "col" || idx.TimeQuantum() != "YMD" {
+		t.Fatalf("unexpected index options: %s, %s", idx.ColumnLabel(), idx.TimeQuantum())
+	} else if opt := hldr.Frame("i", "f").Options(); opt.RowLabel != "r" || opt.CacheSize != 300 || opt.CacheType != pilosa.CacheTypeRanked || !opt.InverseEnabled {
+		t.Fatalf("unexpected frame options: %+v", opt)
+	}
+
+	// Unknown options are rejected.
+	if err := hldr.ApplySchemaChange(pilosa.SchemaChange{
+		Action: pilosa.SchemaActionUpdateFrame, Index: "i", Frame: "f", Option: "foo", Value: "bar",
+	}); err == nil {
+		t.Fatal("expected error for unknown option")
+	}
+
+	if err := hldr.ApplySchemaChange(pilosa.SchemaChange{Action: pilosa.SchemaActionDeleteIndex, Index: "i"}); err != nil {
+		t.Fatal(err)
+	} else if hldr.Index("i") != nil {
+		t.Fatal("expected index to be deleted")
+	}
+}
@@ -15,6 +15,7 @@
 package pilosa
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -30,17 +31,22 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/internal"
+	"google.golang.org/grpc"
 )
 
 // Default server settings.
 const (
 	DefaultAntiEntropyInterval = 10 * time.Minute
 	DefaultPollingInterval     = 60 * time.Second
+
+	// DefaultAntiEntropyHistoryN is the number of anti-entropy runs retained.
+	DefaultAntiEntropyHistoryN = 10
 )
 
 // Server represents a holder wrapped by a running HTTP server.
 type Server struct {
-	ln net.Listener
+	ln   net.Listener
+	grpc *grpc.Server
 
 	// Close management.
 	wg      sync.WaitGroup
@@ -52,15 +58,52 @@ type Server struct {
 	Broadcaster       Broadcaster
 	BroadcastReceiver BroadcastReceiver
 
+	// Records schema changes received over HTTP and broadcast.
+	AuditLogger AuditLogger
+
+	// Address to serve the gRPC API on. gRPC is disabled if blank.
+	// The port is replaced with the actual port after opening if ":0".
+	GRPCHost string
+
+	// TLS configuration used to serve HTTPS.
+	// The server uses plain HTTP if this is nil.
+	TLSConfig *tls.Config
+
 	// Cluster configuration.
 	// Host is replaced with actual host after opening if port is ":0".
 	Host    string
 	Cluster *Cluster
 
+	// Maximum size of the executor's result cache, in bytes.
+	// Query results are not cached if zero.
+	ResultCacheSize int64
+
+	// Limits on client queries. A zero value disables a limit.
+	QueryTimeout         time.Duration
+	MaxConcurrentQueries int
+	MaxResultBits        uint64
+
+	// Maximum number of client queries executing per index, with optional
+	// overrides by index name. Queries are not queued if both are unset.
+	QueryConcurrency      int
+	IndexQueryConcurrency map[string]int
+	MaxQueryQueueLength   int
+
+	// Number of goroutines executing local slices across all queries and the
+	// maximum number of contiguous slices each executes at once.
+	// The executor's defaults are used if zero.
+	QueryWorkers        int
+	QuerySliceBatchSize int
+
 	// Background monitoring intervals.
 	AntiEntropyInterval time.Duration
 	PollingInterval     time.Duration
 
+	// Statistics for the most recent anti-entropy runs.
+	mu                  sync.Mutex
+	antiEntropyStats    []SyncStats
+	AntiEntropyHistoryN int
+
 	LogOutput io.Writer
 }
 
@@ -73,9 +116,12 @@ func NewServer() *Server {
 		Handler:           NewHandler(),
 		Broadcaster:       NopBroadcaster,
 		BroadcastReceiver: NopBroadcastReceiver,
+		AuditLogger:       NopAuditLogger,
 
 		AntiEntropyInterval: DefaultAntiEntropyInterval,
 		PollingInterval:     DefaultPollingInterval,
+		AntiEntropyHistoryN: DefaultAntiEntropyHistoryN,
+		ResultCacheSize:     DefaultResultCacheSize,
 
 		LogOutput: os.Stderr,
 	}
@@ -100,6 +146,11 @@ func (s *Server) Open() error {
 	if err != nil {
 		return err
 	}
+
+	// Serve over TLS, if configured.
+	if s.TLSConfig != nil {
+		ln = tls.NewListener(ln, s.TLSConfig)
+	}
 	s.ln = ln
 
 	// Determine hostname based on listening port.
@@ -110,11 +161,27 @@ func (s *Server) Open() error {
 		s.Cluster.Nodes = []*Node{{Host: s.Host}}
 	}
 
+	// Wrap broadcaster so that slice creation is reliably delivered.
+	sb := newSliceBroadcaster(s.Broadcaster, s.closing)
+	sb.LogOutput = s.LogOutput
+
+	// Initialize Holder before opening so that existing indexes broadcast.
+	s.Holder.Broadcaster = sb
+	s.Holder.LogOutput = s.LogOutput
+
 	// Open holder.
 	if err := s.Holder.Open(); err != nil {
 		return err
 	}
 
+	// Ensure partitioning has not changed since the last run.
+	if err := s.Holder.VerifyClusterMeta(s.Cluster); err != nil {
+		return err
+	}
+
+	// Use per-index replica counts when determining fragment owners.
+	s.Cluster.IndexReplicaN = s.Holder.IndexReplicaN
+
 	if err := s.BroadcastReceiver.Start(s); err != nil {
 		return err
 	}
@@ -129,24 +196,51 @@ func (s *Server) Open() error {
 	e.Holder = s.Holder
 	e.Host = s.Host
 	e.Cluster = s.Cluster
+	e.HTTPClient = s.Cluster.NodeHTTPClient()
+	e.Timeout = s.QueryTimeout
+	e.MaxConcurrentQueries = s.MaxConcurrentQueries
+	e.MaxResultBits = s.MaxResultBits
+	if s.QueryWorkers > 0 {
+		e.Workers = NewWorkerPool(s.QueryWorkers)
+	}
+	if s.QuerySliceBatchSize > 0 {
+		e.SliceBatchSize = s.QuerySliceBatchSize
+	}
+	if s.ResultCacheSize > 0 {
+		e.ResultCache = NewResultCache(s.ResultCacheSize)
+		e.ResultCache.Stats = s.Holder.Stats
+	}
 
 	// Initialize HTTP handler.
 	s.Handler.Broadcaster = s.Broadcaster
 	s.Handler.StatusHandler = s
+	s.Handler.AntiEntropyHandler = s
 	s.Handler.Host = s.Host
 	s.Handler.Cluster = s.Cluster
 	s.Handler.Executor = e
 	s.Handler.LogOutput = s.LogOutput
-
-	// Initialize Holder.
-	s.Holder.Broadcaster = s.Broadcaster
-	s.Holder.LogOutput = s.LogOutput
+	s.Handler.AuditLogger = s.AuditLogger
+	if s.QueryConcurrency > 0 || len(s.IndexQueryConcurrency) > 0 {
+		scheduler := NewQueryScheduler(s.QueryConcurrency)
+		scheduler.IndexConcurrency = s.IndexQueryConcurrency
+		scheduler.MaxQueueLength = s.MaxQueryQueueLength
+		scheduler.Stats = s.Holder.Stats
+		s.Handler.Scheduler = scheduler
+	}
 
 	// Serve HTTP.
 	go func() { http.Serve(ln, s.Handler) }()
 
+	// Serve gRPC, if configured.
+	if s.GRPCHost != "" {
+		if err := s.openGRPC(); err != nil {
+			return err
+		}
+	}
+
 	// Start background monitoring.
-	s.wg.Add(2)
+	s.wg.Add(3)
+	go func() { defer s.wg.Done(); sb.run() }()
 	go func() { defer s.wg.Done(); s.monitorAntiEntropy() }()
 	go func() { defer s.wg.Done(); s.monitorMaxSlices() }()
 
@@ -162,6 +256,9 @@ func (s *Server) Close() error {
 	if s.ln != nil {
 		s.ln.Close()
 	}
+	if s.grpc != nil {
+		s.grpc.Stop()
+	}
 	if s.Holder != nil {
 		s.Holder.Close()
 	}
@@ -203,17 +300,45 @@ func (s *Server) monitorAntiEntropy() {
 		syncer.Closing = s.closing
 
 		// Sync holders.
-		if err := syncer.SyncHolder(); err != nil {
+		err := syncer.SyncHolder()
+		s.recordAntiEntropyStats(syncer.Stats)
+		if err != nil {
 			s.logger().Printf("holder sync error: err=%s", err)
 			continue
 		}
 
 		// Record successful sync in log.
-		s.logger().Printf("holder sync complete")
+		st := syncer.Stats
+		s.logger().Printf("holder sync complete: fragments=%d, blocks=%d, set=%d, cleared=%d, attrs=%d, duration=%s",
+			st.FragmentsChecked, st.BlocksMismatched, st.BitsSet, st.BitsCleared, st.AttrBlocksRepaired, st.Duration)
 	}
 }
 
+// recordAntiEntropyStats appends stats to the history of anti-entropy runs.
+func (s *Server) recordAntiEntropyStats(stats SyncStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.antiEntropyStats = append(s.antiEntropyStats, stats)
+	if n := len(s.antiEntropyStats) - s.AntiEntropyHistoryN; n > 0 {
+		s.antiEntropyStats = s.antiEntropyStats[n:]
+	}
+}
+
+// AntiEntropyStats returns the statistics of the most recent anti-entropy runs,
+// ordered from oldest to newest. Implements AntiEntropyHandler.
+func (s *Server) AntiEntropyStats() []SyncStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	other := make([]SyncStats, len(s.antiEntropyStats))
+	copy(other, s.antiEntropyStats)
+	return other
+}
+
 // monitorMaxSlices periodically pulls the highest slice from each node in the cluster.
+// Slice creation is normally broadcast to all nodes so this is only a fallback
+// for messages which could not be delivered.
 func (s *Server) monitorMaxSlices() {
 	// Ignore if only one node in the cluster.
 	if len(s.Cluster.Nodes) <= 1 {
@@ -233,7 +358,11 @@ func (s *Server) monitorMaxSlices() {
 		oldmaxslices := s.Holder.MaxSlices()
 		for _, node := range s.Cluster.Nodes {
 			if s.Host != node.Host {
-				maxSlices, _ := checkMaxSlices(node.Host)
+				maxSlices, err := s.checkMaxSlices(node.Host)
+				if err != nil {
+					s.logger().Printf("check max slices error: host=%s, err=%s", node.Host, err)
+					continue
+				}
 				for index, newmax := range maxSlices {
 					// if we don't know about an index locally, log an error because
 					// indexes should be created and synced prior to slice creation
@@ -251,25 +380,83 @@ func (s *Server) monitorMaxSlices() {
 	}
 }
 
+// openGRPC starts serving the gRPC API on GRPCHost.
+func (s *Server) openGRPC() error {
+	ln, err := net.Listen("tcp", s.GRPCHost)
+	if err != nil {
+		return err
+	}
+
+	// Determine hostname based on listening port.
+	host, _, err := net.SplitHostPort(s.GRPCHost)
+	if err != nil {
+		return err
+	}
+	s.GRPCHost = net.JoinHostPort(host, strconv.Itoa(ln.Addr().(*net.TCPAddr).Port))
+
+	s.grpc = NewGRPCHandler(s.Handler).NewServer(s.TLSConfig)
+	go func() { s.grpc.Serve(ln) }()
+	return nil
+}
+
 // ReceiveMessage represents an implementation of BroadcastHandler.
 func (s *Server) ReceiveMessage(pb proto.Message) error {
+	err := s.receiveMessage(pb)
+	s.auditMessage(pb, err)
+	return err
+}
+
+// auditMessage records schema changes received from other nodes.
+func (s *Server) auditMessage(pb proto.Message, err error) {
+	entry := &AuditEntry{Source: AuditSourceBroadcast}
+	switch obj := pb.(type) {
+	case *internal.CreateIndexMessage:
+		entry.Endpoint, entry.Index = "CreateIndex", obj.Index
+	case *internal.DeleteIndexMessage:
+		entry.Endpoint, entry.Index = "DeleteIndex", obj.Index
+	case *internal.CreateFrameMessage:
+		entry.Endpoint, entry.Index, entry.Frame = "CreateFrame", obj.Index, obj.Frame
+	case *internal.DeleteFrameMessage:
+		entry.Endpoint, entry.Index, entry.Frame = "DeleteFrame", obj.Index, obj.Frame
+	case *internal.SetAliasMessage:
+		entry.Endpoint, entry.Alias, entry.Index = "SetAlias", obj.Alias, obj.Index
+	case *internal.DeleteAliasMessage:
+		entry.Endpoint, entry.Alias = "DeleteAlias", obj.Alias
+	case *internal.UpdateIndexMessage:
+		entry.Endpoint, entry.Index = "UpdateIndex", obj.Index
+	case *internal.UpdateFrameMessage:
+		entry.Endpoint, entry.Index, entry.Frame = "UpdateFrame", obj.Index, obj.Frame
+	default:
+		return
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if err := s.AuditLogger.Log(entry); err != nil {
+		s.logger().Printf("audit log error: %s", err)
+	}
+}
+
+func (s *Server) receiveMessage(pb proto.Message) error {
 	switch obj := pb.(type) {
 	case *internal.CreateSliceMessage:
 		idx := s.Holder.Index(obj.Index)
 		if idx == nil {
 			return fmt.Errorf("Local Index not found: %s", obj.Index)
 		}
+		// Ignore out-of-order messages for lower slices.
 		if obj.IsInverse {
-			idx.SetRemoteMaxInverseSlice(obj.Slice)
+			if obj.Slice > idx.MaxInverseSlice() {
+				idx.SetRemoteMaxInverseSlice(obj.Slice)
+			}
 		} else {
-			idx.SetRemoteMaxSlice(obj.Slice)
+			if obj.Slice > idx.MaxSlice() {
+				idx.SetRemoteMaxSlice(obj.Slice)
+			}
 		}
 	case *internal.CreateIndexMessage:
-		opt := IndexOptions{
-			ColumnLabel: obj.Meta.ColumnLabel,
-			TimeQuantum: TimeQuantum(obj.Meta.TimeQuantum),
-		}
-		_, err := s.Holder.CreateIndex(obj.Index, opt)
+		_, err := s.Holder.CreateIndex(obj.Index, decodeIndexOptions(obj.Meta))
 		if err != nil {
 			return err
 		}
@@ -277,16 +464,10 @@ func (s *Server) ReceiveMessage(pb proto.Message) error {
 		if err := s.Holder.DeleteIndex(obj.Index); err != nil {
 			return err
 		}
+		s.Handler.Scheduler.DeleteIndex(obj.Index)
 	case *internal.CreateFrameMessage:
 		index := s.Holder.Index(obj.Index)
-		opt := FrameOptions{
-			RowLabel:       obj.Meta.RowLabel,
-			InverseEnabled: obj.Meta.InverseEnabled,
-			CacheType:      obj.Meta.CacheType,
-			CacheSize:      obj.Meta.CacheSize,
-			TimeQuantum:    TimeQuantum(obj.Meta.TimeQuantum),
-		}
-		_, err := index.CreateFrame(obj.Frame, opt)
+		_, err := index.CreateFrame(obj.Frame, decodeFrameOptions(obj.Meta))
 		if err != nil {
 			return err
 		}
@@ -295,6 +476,38 @@ func (s *Server) ReceiveMessage(pb proto.Message) error {
 		if err := index.DeleteFrame(obj.Frame); err != nil {
 			return err
 		}
+	case *internal.SetAliasMessage:
+		if err := s.Holder.SetAlias(obj.Alias, obj.Index); err != nil {
+			return err
+		}
+	case *internal.DeleteAliasMessage:
+		if err := s.Holder.DeleteAlias(obj.Alias); err != nil && err != ErrAliasNotFound {
+			return err
+		}
+	case *internal.UpdateIndexMessage:
+		if err := s.Holder.ApplySchemaChange(SchemaChange{
+			Action: SchemaActionUpdateIndex,
+			Index:  obj.Index,
+			Option: obj.Option,
+			Value:  obj.Value,
+		}); err != nil {
+			return err
+		}
+	case *internal.UpdateFrameMessage:
+		if err := s.Holder.ApplySchemaChange(SchemaChange{
+			Action: SchemaActionUpdateFrame,
+			Index:  obj.Index,
+			Frame:  obj.Frame,
+			Option: obj.Option,
+			Value:  obj.Value,
+		}); err != nil {
+			return err
+		}
+
+		// Each node fills the inverse views of its own fragments.
+		if obj.Option == "inverseEnabled" {
+			backfillInverse(s.Holder.Frame(obj.Index, obj.Frame), s.logger())
+		}
 	}
 	return nil
 }
@@ -312,6 +525,7 @@ func (s *Server) LocalStatus() (proto.Message, error) {
 		Host:    s.Host,
 		State:   NodeStateUp,
 		Indexes: encodeIndexes(s.Holder.Indexes()),
+		Aliases: s.Holder.Aliases(),
 	}
 
 	// Append Slice list per this Node's indexes
@@ -364,6 +578,7 @@ func (s *Server) mergeRemoteStatus(ns *internal.NodeStatus) error {
 		opt := IndexOptions{
 			ColumnLabel: index.Meta.ColumnLabel,
 			TimeQuantum: TimeQuantum(index.Meta.TimeQuantum),
+			ReplicaN:    int(index.Meta.ReplicaN),
 		}
 		idx, err := s.Holder.CreateIndexIfNotExists(index.Name, opt)
 		if err != nil {
@@ -383,13 +598,18 @@ func (s *Server) mergeRemoteStatus(ns *internal.NodeStatus) error {
 		}
 	}
 
+	// Add aliases that don't exist.
+	if _, err := s.Holder.MergeAliases(ns.Aliases); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func checkMaxSlices(hostport string) (map[string]uint64, error) {
+func (s *Server) checkMaxSlices(hostport string) (map[string]uint64, error) {
 	// Create HTTP request.
 	req, err := http.NewRequest("GET", (&url.URL{
-		Scheme: "http",
+		Scheme: s.Cluster.Scheme(),
 		Host:   hostport,
 		Path:   "/slices/max",
 	}).String(), nil)
@@ -403,7 +623,7 @@ func checkMaxSlices(hostport string) (map[string]uint64, error) {
 	req.Header.Set("Content-Type", "application/x-protobuf")
 
 	// Send request to remote node.
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.Cluster.NodeHTTPClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -430,6 +650,11 @@ func checkMaxSlices(hostport string) (map[string]uint64, error) {
 	return pb.MaxSlices, nil
 }
 
+// AntiEntropyHandler returns statistics for recent anti-entropy runs.
+type AntiEntropyHandler interface {
+	AntiEntropyStats() []SyncStats
+}
+
 // StatusHandler specifies two methods which an object must implement to share
 // state in the cluster. These are used by the GossipNodeSet to implement the
 // LocalState and MergeRemoteState methods of memberlist.Delegate
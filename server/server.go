@@ -99,25 +99,31 @@ func (m *Command) Run(args ...string) (err error) {
 	if err = m.Server.Open(); err != nil {
 		return fmt.Errorf("server.Open: %v", err)
 	}
-	fmt.Fprintf(m.Stderr, "Listening as http://%s\n", m.Server.Host)
+	fmt.Fprintf(m.Stderr, "Listening as %s://%s\n", m.Server.Cluster.Scheme(), m.Server.Host)
 	return nil
 }
 
 // SetupServer use the cluster configuration to setup this server
 func (m *Command) SetupServer() error {
-	cluster := pilosa.NewCluster()
-	cluster.ReplicaN = m.Config.Cluster.ReplicaN
+	cluster, err := m.Config.NewCluster()
+	if err != nil {
+		return err
+	}
+	m.Server.Cluster = cluster
+	m.Server.TLSConfig = cluster.TLSConfig
 
-	for _, hostport := range m.Config.Cluster.Hosts {
-		cluster.Nodes = append(cluster.Nodes, &pilosa.Node{Host: hostport})
+	accessControl, err := m.Config.AccessControl()
+	if err != nil {
+		return err
 	}
-	// TODO: if InternalHosts is not provided then pilosa.Node.InternalHost is empty.
-	// This will throw an error when trying to Broadcast messages over HTTP.
-	// One option may be to fall back to using host from hostport + config.InternalPort.
-	for i, internalhostport := range m.Config.Cluster.InternalHosts {
-		cluster.Nodes[i].InternalHost = internalhostport
+	m.Server.Handler.AccessControl = accessControl
+
+	// Unsigned remote queries wait in the query queue like client queries,
+	// so nodes could wait on each other without a secret to sign them.
+	if len(m.Config.Cluster.Hosts) > 1 && m.Config.Cluster.Secret == "" &&
+		(m.Config.Query.Concurrency > 0 || len(m.Config.Query.IndexConcurrency) > 0) {
+		return errors.New("cluster secret required when query concurrency is enabled")
 	}
-	m.Server.Cluster = cluster
 
 	// Setup logging output.
 	if m.Config.LogPath == "" {
@@ -130,16 +136,39 @@ func (m *Command) SetupServer() error {
 		m.Server.LogOutput = logFile
 	}
 
+	// Setup audit log.
+	if m.Config.Audit.Path != "" {
+		auditLog := pilosa.NewAuditLog(m.Config.Audit.Path)
+		auditLog.MaxSize = int64(m.Config.Audit.MaxSize) << 20
+		auditLog.MaxBackups = m.Config.Audit.MaxBackups
+		if err := auditLog.Open(); err != nil {
+			return err
+		}
+		m.Server.AuditLogger = auditLog
+	}
+
+	m.Server.ResultCacheSize = int64(m.Config.Query.ResultCacheSize) << 20
+	m.Server.QueryTimeout = time.Duration(m.Config.Query.Timeout)
+	m.Server.MaxConcurrentQueries = m.Config.Query.MaxConcurrent
+	m.Server.MaxResultBits = m.Config.Query.MaxResultBits
+	m.Server.QueryConcurrency = m.Config.Query.Concurrency
+	m.Server.IndexQueryConcurrency = m.Config.Query.IndexConcurrency
+	m.Server.MaxQueryQueueLength = m.Config.Query.MaxQueueLength
+	m.Server.QueryWorkers = m.Config.Query.Workers
+	m.Server.QuerySliceBatchSize = m.Config.Query.SliceBatchSize
+
 	// Configure holder.
 	fmt.Fprintf(m.Stderr, "Using data from: %s\n", m.Config.DataDir)
 	m.Server.Holder.Path = m.Config.DataDir
 	m.Server.Holder.Stats = pilosa.NewExpvarStatsClient()
+	m.Server.Holder.RowCache = pilosa.NewRowCache(int64(m.Config.Cache.RowCacheSize) << 20)
+	m.Server.Holder.CacheVerifyInterval = time.Duration(m.Config.Cache.VerifyInterval)
 
-	var err error
 	m.Server.Host, err = normalizeHost(m.Config.Host)
 	if err != nil {
 		return err
 	}
+	m.Server.GRPCHost = m.Config.GRPC.Host
 
 	// Set internal port (string).
 	internalPortStr := pilosa.DefaultInternalPort
@@ -150,7 +179,9 @@ func (m *Command) SetupServer() error {
 	switch m.Config.Cluster.Type {
 	case "http":
 		m.Server.Broadcaster = httpbroadcast.NewHTTPBroadcaster(m.Server, internalPortStr)
-		m.Server.BroadcastReceiver = httpbroadcast.NewHTTPBroadcastReceiver(internalPortStr, m.Stderr)
+		receiver := httpbroadcast.NewHTTPBroadcastReceiver(internalPortStr, m.Stderr)
+		receiver.Secret = m.Server.Cluster.Secret
+		m.Server.BroadcastReceiver = receiver
 		m.Server.Cluster.NodeSet = httpbroadcast.NewHTTPNodeSet()
 		err := m.Server.Cluster.NodeSet.(*httpbroadcast.HTTPNodeSet).Join(m.Server.Cluster.Nodes)
 		if err != nil {
@@ -171,6 +202,7 @@ func (m *Command) SetupServer() error {
 			gossipHost = m.Config.Host
 		}
 		gossipNodeSet := gossip.NewGossipNodeSet(m.Config.Host, gossipHost, gossipPort, gossipSeed, m.Server)
+		gossipNodeSet.SetSecret(m.Server.Cluster.Secret)
 		m.Server.Cluster.NodeSet = gossipNodeSet
 		m.Server.Broadcaster = gossipNodeSet
 		m.Server.BroadcastReceiver = gossipNodeSet
@@ -212,6 +244,11 @@ func (m *Command) Close() error {
 	if closer, ok := logOutput.(io.Closer); ok {
 		logErr = closer.Close()
 	}
+	if closer, ok := m.Server.AuditLogger.(io.Closer); ok {
+		if err := closer.Close(); err != nil && logErr == nil {
+			logErr = err
+		}
+	}
 	close(m.Done)
 	if serveErr != nil && logErr != nil {
 		return fmt.Errorf("closing server: '%v', closing logs: '%v'", serveErr, logErr)
@@ -240,6 +240,41 @@ func TestMain_SetColumnAttrs(t *testing.T) {
 	}
 }
 
+// Ensure program can bulk import row & column attributes.
+func TestMain_ImportAttrs(t *testing.T) {
+	m := MustRunMain()
+	defer m.Close()
+
+	client := m.Client()
+	if err := client.CreateIndex(context.Background(), "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if err := client.CreateFrame(context.Background(), "i", "x", pilosa.FrameOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.Query("i", "", `SetBit(rowID=1, frame="x", columnID=100)`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Import row attributes as CSV.
+	if resp, err := http.Post(m.URL()+"/index/i/frame/x/attr/import", "text/csv", strings.NewReader("id,foo,n\n1,bar,10\n")); err != nil {
+		t.Fatal(err)
+	} else if resp.Body.Close(); resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+
+	// Import column attributes through the client.
+	if err := client.ImportAttrs(context.Background(), "i", "", map[uint64]map[string]interface{}{
+		100: {"baz": true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := m.Query("i", "columnAttrs=true", `Bitmap(rowID=1, frame="x")`); err != nil {
+		t.Fatal(err)
+	} else if res != `{"results":[{"attrs":{"foo":"bar","n":10},"bits":[100]}],"columnAttrs":[{"id":100,"attrs":{"baz":true}}]}`+"\n" {
+		t.Fatalf("unexpected result: %s", res)
+	}
+}
+
 // Ensure program can set column attributes with columnLabel option.
 func TestMain_SetColumnAttrsWithColumnOption(t *testing.T) {
 	m := MustRunMain()
@@ -341,6 +376,60 @@ func TestMain_FrameRestore(t *testing.T) {
 	}
 }
 
+// Ensure queries and imports can use an index alias which is swapped to a rebuilt index.
+func TestMain_IndexAlias(t *testing.T) {
+	m := MustRunMain()
+	defer m.Close()
+
+	// Create two versions of the index.
+	client := m.Client()
+	for _, index := range []string{"x1", "x2"} {
+		if err := client.CreateIndex(context.Background(), index, pilosa.IndexOptions{}); err != nil {
+			t.Fatal(err)
+		} else if err := client.CreateFrame(context.Background(), index, "f", pilosa.FrameOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Query("x1", "", `SetBit(rowID=1, frame="f", columnID=100)`); err != nil {
+		t.Fatal(err)
+	} else if err := client.Import(context.Background(), "x2", "f", 0, []pilosa.Bit{{RowID: 1, ColumnID: 200}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Query through the alias.
+	if err := client.SetAlias(context.Background(), "x", "x1"); err != nil {
+		t.Fatal(err)
+	} else if res, err := m.Query("x", "", `Bitmap(rowID=1, frame="f")`); err != nil {
+		t.Fatal(err)
+	} else if res != `{"results":[{"attrs":{},"bits":[100]}]}`+"\n" {
+		t.Fatalf("unexpected result: %s", res)
+	}
+
+	// Swap the alias and write through it.
+	if err := client.SetAlias(context.Background(), "x", "x2"); err != nil {
+		t.Fatal(err)
+	} else if err := client.Import(context.Background(), "x", "f", 0, []pilosa.Bit{{RowID: 1, ColumnID: 300}}); err != nil {
+		t.Fatal(err)
+	} else if res, err := m.Query("x", "", `Bitmap(rowID=1, frame="f")`); err != nil {
+		t.Fatal(err)
+	} else if res != `{"results":[{"attrs":{},"bits":[200,300]}]}`+"\n" {
+		t.Fatalf("unexpected result: %s", res)
+	}
+
+	// List and remove the alias.
+	if aliases, err := client.Aliases(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(aliases, map[string]string{"x": "x2"}) {
+		t.Fatalf("unexpected aliases: %+v", aliases)
+	} else if err := client.DeleteAlias(context.Background(), "x"); err != nil {
+		t.Fatal(err)
+	} else if err := client.DeleteAlias(context.Background(), "x"); err != pilosa.ErrAliasNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := client.SetAlias(context.Background(), "x", "no_such_index"); err != pilosa.ErrIndexNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // Ensure the host can be parsed.
 func TestConfig_Parse_Host(t *testing.T) {
 	if c, err := ParseConfig(`host = "local"`); err != nil {
@@ -493,6 +582,21 @@ func TestMain_SendReceiveMessage(t *testing.T) {
 	if maxSlices1["i"] != 2 {
 		t.Fatalf("unexpected maxSlice on node1: %d", maxSlices1["i"])
 	}
+
+	// Option updates applied on one node are applied on every node.
+	if _, err := client0.ApplySchema(context.Background(), &pilosa.SchemaDefinition{
+		Indexes: []pilosa.IndexDefinition{{
+			Name:    "i",
+			Options: pilosa.IndexOptions{ColumnLabel: "col"},
+			Frames:  []pilosa.FrameDefinition{{Name: "f", Options: pilosa.FrameOptions{CacheSize: 10}}},
+		}},
+	}, false, false); err != nil {
+		t.Fatal(err)
+	} else if label := m1.Server.Holder.Index("i").ColumnLabel(); label != "col" {
+		t.Fatalf("unexpected column label on node1: %s", label)
+	} else if n := m1.Server.Holder.Frame("i", "f").CacheSize(); n != 10 {
+		t.Fatalf("unexpected cache size on node1: %d", n)
+	}
 }
 
 // availablePorts returns a slice of ports that can be used for testing.
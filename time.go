@@ -166,6 +166,30 @@ func ViewsByTimeRange(name string, start, end time.Time, q TimeQuantum) []string
 	return results
 }
 
+// timeWindow returns the time range of the window ending at t. The range ends
+// after the unit of q containing t and starts on a boundary of that unit.
+func timeWindow(t time.Time, window time.Duration, q TimeQuantum) (start, end time.Time) {
+	t = t.UTC()
+	switch {
+	case q.HasHour():
+		end = t.Truncate(time.Hour).Add(time.Hour)
+		start = end.Add(-window).Truncate(time.Hour)
+	case q.HasDay():
+		end = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		s := end.Add(-window)
+		start = time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, time.UTC)
+	case q.HasMonth():
+		end = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		s := end.Add(-window)
+		start = time.Date(s.Year(), s.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		end = time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		s := end.Add(-window)
+		start = time.Date(s.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return start, end
+}
+
 func nextYearGTE(t time.Time, end time.Time) bool {
 	next := t.AddDate(1, 0, 0)
 	if next.Year() == end.Year() {
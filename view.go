@@ -20,9 +20,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pilosa/pilosa/internal"
 )
@@ -49,8 +51,10 @@ type View struct {
 	cacheSize uint32
 
 	// Fragments by slice.
-	cacheType string // passed in by frame
-	fragments map[uint64]*Fragment
+	cacheType     string // passed in by frame
+	cacheInterval time.Duration
+	cacheHalfLife time.Duration
+	fragments     map[uint64]*Fragment
 
 	// maxSlice maintains this view's max slice in order to
 	// prevent sending multiple `CreateSliceMessage` messages
@@ -59,6 +63,9 @@ type View struct {
 	broadcaster Broadcaster
 	stats       StatsClient
 
+	// Memory budget for cached rows, shared by the holder.
+	rowCache *RowCache
+
 	RowAttrStore *AttrStore
 	LogOutput    io.Writer
 }
@@ -212,37 +219,47 @@ func (v *View) Fragments() []*Fragment {
 // CreateFragmentIfNotExists returns a fragment in the view by slice.
 func (v *View) CreateFragmentIfNotExists(slice uint64) (*Fragment, error) {
 	v.mu.Lock()
-	defer v.mu.Unlock()
-	return v.createFragmentIfNotExists(slice)
+	frag, maxSlice, err := v.createFragmentIfNotExists(slice)
+	v.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Broadcast a message that a new max slice was just created. The message
+	// is sent once the lock is released so a slow peer doesn't block the view.
+	if maxSlice {
+		err := v.broadcaster.SendAsync(
+			&internal.CreateSliceMessage{
+				Index:     v.index,
+				Slice:     slice,
+				IsInverse: IsInverseView(v.name),
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return frag, nil
 }
 
-func (v *View) createFragmentIfNotExists(slice uint64) (*Fragment, error) {
+// createFragmentIfNotExists returns a fragment in the view by slice and
+// whether it was created as the view's new max slice.
+func (v *View) createFragmentIfNotExists(slice uint64) (*Fragment, bool, error) {
 	// Find fragment in cache first.
 	if frag := v.fragments[slice]; frag != nil {
-		return frag, nil
+		return frag, false, nil
 	}
 
 	// Initialize and open fragment.
 	frag := v.newFragment(v.FragmentPath(slice), slice)
 	if err := frag.Open(); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	frag.RowAttrStore = v.RowAttrStore
 
-	// Broadcast a message that a new max slice was just created.
-	if slice > v.maxSlice {
+	maxSlice := slice > v.maxSlice
+	if maxSlice {
 		v.maxSlice = slice
-
-		// Send the create slice message to all nodes.
-		err := v.broadcaster.SendAsync(
-			&internal.CreateSliceMessage{
-				Index:     v.index,
-				Slice:     slice,
-				IsInverse: IsInverseView(v.name),
-			})
-		if err != nil {
-			return nil, err
-		}
 	}
 
 	// Save to lookup.
@@ -250,15 +267,20 @@ func (v *View) createFragmentIfNotExists(slice uint64) (*Fragment, error) {
 
 	v.stats.Count("maxSlice", 1)
 
-	return frag, nil
+	return frag, maxSlice, nil
 }
 
 func (v *View) newFragment(path string, slice uint64) *Fragment {
 	frag := NewFragment(path, v.index, v.frame, v.name, slice)
 	frag.cacheType = v.cacheType
 	frag.cacheSize = v.cacheSize
+	frag.cacheInterval = v.cacheInterval
+	frag.cacheHalfLife = v.cacheHalfLife
 	frag.LogOutput = v.LogOutput
 	frag.stats = v.stats.WithTags(fmt.Sprintf("slice:%d", slice))
+	if v.rowCache != nil {
+		frag.RowCache = v.rowCache
+	}
 	return frag
 }
 
@@ -282,11 +304,51 @@ func (v *View) ClearBit(rowID, columnID uint64) (changed bool, err error) {
 	return frag.ClearBit(rowID, columnID)
 }
 
+// ResetCache sets the cache settings used by the view's fragments and
+// rebuilds the row count cache of each existing fragment.
+func (v *View) ResetCache(cacheType string, cacheSize uint32, cacheInterval, cacheHalfLife time.Duration) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.cacheType, v.cacheSize = cacheType, cacheSize
+	v.cacheInterval, v.cacheHalfLife = cacheInterval, cacheHalfLife
+	for _, frag := range v.fragments {
+		if err := frag.ResetCache(cacheType, cacheSize, cacheInterval, cacheHalfLife); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IsInverseView returns true if the view is used for storing an inverted representation.
 func IsInverseView(name string) bool {
 	return strings.HasPrefix(name, ViewInverse)
 }
 
+// Stats returns statistics for each fragment in the view on the local node.
+func (v *View) Stats() (ViewStats, error) {
+	stats := ViewStats{Name: v.Name(), Slices: []FragmentStats{}}
+	for _, frag := range v.Fragments() {
+		fs, err := frag.Stats()
+		if err != nil {
+			return stats, err
+		}
+		stats.BitN += fs.BitN
+		stats.Size += fs.Size
+		stats.Slices = append(stats.Slices, fs)
+	}
+	sort.Sort(fragmentStatsSlice(stats.Slices))
+	return stats, nil
+}
+
+// ViewStats represents statistics for a view on the local node.
+type ViewStats struct {
+	Name   string          `json:"name"`
+	BitN   uint64          `json:"bitCount"`
+	Size   int64           `json:"size"`
+	Slices []FragmentStats `json:"slices"`
+}
+
 type viewSlice []*View
 
 func (p viewSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }